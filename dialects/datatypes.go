@@ -0,0 +1,87 @@
+package dialects
+
+import "strings"
+
+/*
+Data types
+
+Each dialect provides a catalog of its built-in data types. Each type is
+categorized and mapped to a dialect neutral (generic) type. Each
+dialect also provides the name of the type to use for each of the
+generic types so that types may be mapped from one dialect to another.
+
+The generic types are:
+
+    BOOLEAN, TINYINT, SMALLINT, INTEGER, BIGINT, DECIMAL, REAL, DOUBLE,
+    CHAR, VARCHAR, NCHAR, NVARCHAR, TEXT, BINARY, VARBINARY, BLOB,
+    DATE, TIME, TIMESTAMP, TIMESTAMPTZ, INTERVAL, JSON, XML, UUID
+
+*/
+
+// Data type categories
+const (
+	// NullDataType indicates an undetermined data type category
+	NullDataType = iota
+	// NumericDataType is an integer, fixed point, or floating point type
+	NumericDataType
+	// StringDataType is a character string type
+	StringDataType
+	// BinaryDataType is a binary string type
+	BinaryDataType
+	// DateTimeDataType is a date, time, timestamp, or interval type
+	DateTimeDataType
+	// BooleanDataType is a boolean type
+	BooleanDataType
+	// DocumentDataType is a JSON or XML type
+	DocumentDataType
+	// OtherDataType is any other type (UUID, network address, geometry, ...)
+	OtherDataType
+)
+
+// TypeInfo provides the catalog entry for a data type
+type TypeInfo struct {
+	Category int    // the category of the data type
+	Generic  string // the dialect neutral type that the type maps to, if any
+}
+
+// typeCatalog provides the data types of a dialect
+type typeCatalog struct {
+	types map[string]TypeInfo // map[type name]type info, multi-word names are separated by a single space
+	names map[string]string   // map[generic type]the name of the type to use in the dialect
+}
+
+// DataTypeCategoryName returns the string representation of the data
+// type category
+func DataTypeCategoryName(category int) (s string) {
+
+	var names = map[int]string{
+		NullDataType:     "NullDataType",
+		NumericDataType:  "NumericDataType",
+		StringDataType:   "StringDataType",
+		BinaryDataType:   "BinaryDataType",
+		DateTimeDataType: "DateTimeDataType",
+		BooleanDataType:  "BooleanDataType",
+		DocumentDataType: "DocumentDataType",
+		OtherDataType:    "OtherDataType",
+	}
+
+	if s, ok := names[category]; ok {
+		return s
+	}
+	return ""
+}
+
+// lookup returns the catalog entry for the supplied type name. Names
+// are matched without regard to case or the amount of white space
+// between words.
+func (d typeCatalog) lookup(s string) (t TypeInfo, ok bool) {
+	t, ok = d.types[strings.ToUpper(strings.Join(strings.Fields(s), " "))]
+	return t, ok
+}
+
+// typeName returns the name of the type to use for the supplied generic
+// type
+func (d typeCatalog) typeName(generic string) (s string, ok bool) {
+	s, ok = d.names[strings.ToUpper(generic)]
+	return s, ok
+}
@@ -0,0 +1,70 @@
+package dialects
+
+import "strings"
+
+/*
+Built-in functions
+
+Each dialect provides a catalog of its built-in functions. Each function
+is identified as a scalar, aggregate, or window function and has the
+minimum and maximum number of arguments that it accepts. Aggregate
+functions that may also be used as window functions are listed as
+aggregate functions.
+
+*/
+
+// Function kinds
+const (
+	// NullFunction indicates an undetermined kind of function
+	NullFunction = iota
+	// ScalarFunction returns one value for each row
+	ScalarFunction
+	// AggregateFunction returns one value for a group of rows
+	AggregateFunction
+	// WindowFunction returns one value for each row from a window of rows
+	WindowFunction
+)
+
+// VariadicArgs is the maximum number of arguments of a function that
+// accepts any number of arguments
+const VariadicArgs = -1
+
+// FunctionInfo provides the catalog entry for a built-in function
+type FunctionInfo struct {
+	Kind    int // ScalarFunction, AggregateFunction, or WindowFunction
+	MinArgs int // the minimum number of arguments
+	MaxArgs int // the maximum number of arguments, VariadicArgs for no limit
+}
+
+// functionCatalog provides the built-in functions of a dialect
+type functionCatalog map[string]FunctionInfo
+
+// FunctionKindName returns the string representation of the function
+// kind
+func FunctionKindName(kind int) (s string) {
+
+	var names = map[int]string{
+		NullFunction:      "NullFunction",
+		ScalarFunction:    "ScalarFunction",
+		AggregateFunction: "AggregateFunction",
+		WindowFunction:    "WindowFunction",
+	}
+
+	if s, ok := names[kind]; ok {
+		return s
+	}
+	return ""
+}
+
+// AcceptsArgs returns true if the function may be called with n
+// arguments
+func (f FunctionInfo) AcceptsArgs(n int) bool {
+	return n >= f.MinArgs && (f.MaxArgs == VariadicArgs || n <= f.MaxArgs)
+}
+
+// lookup returns the catalog entry for the supplied function name.
+// Names are matched without regard to case.
+func (d functionCatalog) lookup(s string) (f FunctionInfo, ok bool) {
+	f, ok = d[strings.ToUpper(s)]
+	return f, ok
+}
@@ -232,6 +232,199 @@ var mssqlOperators = map[string]bool{
 	"+=": true,
 }
 
+// the infix, prefix, and postfix MSSQL operators and their precedence
+var mssqlOperatorPrecedence = operatorTable{
+	infix: map[string]Operator{
+		"+=":      {1, RightAssociative},
+		"-=":      {1, RightAssociative},
+		"*=":      {1, RightAssociative},
+		"/=":      {1, RightAssociative},
+		"%=":      {1, RightAssociative},
+		"&=":      {1, RightAssociative},
+		"|=":      {1, RightAssociative},
+		"^=":      {1, RightAssociative},
+		"OR":      {2, LeftAssociative},
+		"AND":     {3, LeftAssociative},
+		"=":       {5, NonAssociative},
+		">":       {5, NonAssociative},
+		"<":       {5, NonAssociative},
+		">=":      {5, NonAssociative},
+		"<=":      {5, NonAssociative},
+		"<>":      {5, NonAssociative},
+		"!=":      {5, NonAssociative},
+		"!>":      {5, NonAssociative},
+		"!<":      {5, NonAssociative},
+		"IS":      {5, NonAssociative},
+		"BETWEEN": {5, NonAssociative},
+		"IN":      {5, NonAssociative},
+		"LIKE":    {5, NonAssociative},
+		"+":       {6, LeftAssociative},
+		"-":       {6, LeftAssociative},
+		"&":       {6, LeftAssociative},
+		"^":       {6, LeftAssociative},
+		"|":       {6, LeftAssociative},
+		"*":       {7, LeftAssociative},
+		"/":       {7, LeftAssociative},
+		"%":       {7, LeftAssociative},
+		"COLLATE": {10, LeftAssociative},
+		"::":      {11, LeftAssociative},
+	},
+	prefix: map[string]Operator{
+		"NOT": {4, RightAssociative},
+		"+":   {8, RightAssociative},
+		"-":   {8, RightAssociative},
+		"~":   {9, RightAssociative},
+	},
+	postfix: map[string]Operator{},
+}
+
+// the MSSQL data types
+var mssqlDataTypes = typeCatalog{
+	types: map[string]TypeInfo{
+		"BIGINT":           {NumericDataType, "BIGINT"},
+		"BINARY":           {BinaryDataType, "BINARY"},
+		"BIT":              {BooleanDataType, "BOOLEAN"},
+		"CHAR":             {StringDataType, "CHAR"},
+		"CHARACTER":        {StringDataType, "CHAR"},
+		"CURSOR":           {OtherDataType, ""},
+		"DATE":             {DateTimeDataType, "DATE"},
+		"DATETIME":         {DateTimeDataType, "TIMESTAMP"},
+		"DATETIME2":        {DateTimeDataType, "TIMESTAMP"},
+		"DATETIMEOFFSET":   {DateTimeDataType, "TIMESTAMPTZ"},
+		"DEC":              {NumericDataType, "DECIMAL"},
+		"DECIMAL":          {NumericDataType, "DECIMAL"},
+		"FLOAT":            {NumericDataType, "DOUBLE"},
+		"GEOGRAPHY":        {OtherDataType, ""},
+		"GEOMETRY":         {OtherDataType, ""},
+		"HIERARCHYID":      {OtherDataType, ""},
+		"IMAGE":            {BinaryDataType, "BLOB"},
+		"INT":              {NumericDataType, "INTEGER"},
+		"INTEGER":          {NumericDataType, "INTEGER"},
+		"MONEY":            {NumericDataType, "DECIMAL"},
+		"NCHAR":            {StringDataType, "NCHAR"},
+		"NTEXT":            {StringDataType, "TEXT"},
+		"NUMERIC":          {NumericDataType, "DECIMAL"},
+		"NVARCHAR":         {StringDataType, "NVARCHAR"},
+		"REAL":             {NumericDataType, "REAL"},
+		"ROWVERSION":       {BinaryDataType, "BINARY"},
+		"SMALLDATETIME":    {DateTimeDataType, "TIMESTAMP"},
+		"SMALLINT":         {NumericDataType, "SMALLINT"},
+		"SMALLMONEY":       {NumericDataType, "DECIMAL"},
+		"SQL_VARIANT":      {OtherDataType, ""},
+		"TEXT":             {StringDataType, "TEXT"},
+		"TIME":             {DateTimeDataType, "TIME"},
+		"TIMESTAMP":        {BinaryDataType, "BINARY"},
+		"TINYINT":          {NumericDataType, "TINYINT"},
+		"UNIQUEIDENTIFIER": {OtherDataType, "UUID"},
+		"VARBINARY":        {BinaryDataType, "VARBINARY"},
+		"VARCHAR":          {StringDataType, "VARCHAR"},
+		"XML":              {DocumentDataType, "XML"},
+	},
+	names: map[string]string{
+		"BIGINT":      "BIGINT",
+		"BINARY":      "BINARY",
+		"BLOB":        "VARBINARY(MAX)",
+		"BOOLEAN":     "BIT",
+		"CHAR":        "CHAR",
+		"DATE":        "DATE",
+		"DECIMAL":     "DECIMAL",
+		"DOUBLE":      "FLOAT",
+		"INTEGER":     "INT",
+		"JSON":        "NVARCHAR(MAX)",
+		"NCHAR":       "NCHAR",
+		"NVARCHAR":    "NVARCHAR",
+		"REAL":        "REAL",
+		"SMALLINT":    "SMALLINT",
+		"TEXT":        "NVARCHAR(MAX)",
+		"TIME":        "TIME",
+		"TIMESTAMP":   "DATETIME2",
+		"TIMESTAMPTZ": "DATETIMEOFFSET",
+		"TINYINT":     "TINYINT",
+		"UUID":        "UNIQUEIDENTIFIER",
+		"VARBINARY":   "VARBINARY",
+		"VARCHAR":     "VARCHAR",
+		"XML":         "XML",
+	},
+}
+
+// the MSSQL built-in functions
+var mssqlFunctions = functionCatalog{
+	"ABS":            {ScalarFunction, 1, 1},
+	"AVG":            {AggregateFunction, 1, 1},
+	"CEIL":           {ScalarFunction, 1, 1},
+	"CEILING":        {ScalarFunction, 1, 1},
+	"CHARINDEX":      {ScalarFunction, 2, 3},
+	"CHECKSUM_AGG":   {AggregateFunction, 1, 1},
+	"CHOOSE":         {ScalarFunction, 2, VariadicArgs},
+	"COALESCE":       {ScalarFunction, 1, VariadicArgs},
+	"CONCAT":         {ScalarFunction, 2, VariadicArgs},
+	"CONCAT_WS":      {ScalarFunction, 3, VariadicArgs},
+	"COUNT":          {AggregateFunction, 1, 1},
+	"COUNT_BIG":      {AggregateFunction, 1, 1},
+	"CUME_DIST":      {WindowFunction, 0, 0},
+	"DATALENGTH":     {ScalarFunction, 1, 1},
+	"DATEADD":        {ScalarFunction, 3, 3},
+	"DATEDIFF":       {ScalarFunction, 3, 3},
+	"DATEDIFF_BIG":   {ScalarFunction, 3, 3},
+	"DATENAME":       {ScalarFunction, 2, 2},
+	"DATEPART":       {ScalarFunction, 2, 2},
+	"DENSE_RANK":     {WindowFunction, 0, 0},
+	"EOMONTH":        {ScalarFunction, 1, 2},
+	"EXP":            {ScalarFunction, 1, 1},
+	"FIRST_VALUE":    {WindowFunction, 1, 1},
+	"FLOOR":          {ScalarFunction, 1, 1},
+	"FORMAT":         {ScalarFunction, 2, 3},
+	"GETDATE":        {ScalarFunction, 0, 0},
+	"GETUTCDATE":     {ScalarFunction, 0, 0},
+	"GREATEST":       {ScalarFunction, 1, VariadicArgs},
+	"IIF":            {ScalarFunction, 3, 3},
+	"ISJSON":         {ScalarFunction, 1, 2},
+	"ISNULL":         {ScalarFunction, 2, 2},
+	"JSON_MODIFY":    {ScalarFunction, 3, 3},
+	"JSON_QUERY":     {ScalarFunction, 1, 2},
+	"JSON_VALUE":     {ScalarFunction, 2, 2},
+	"LAG":            {WindowFunction, 1, 3},
+	"LAST_VALUE":     {WindowFunction, 1, 1},
+	"LEAD":           {WindowFunction, 1, 3},
+	"LEAST":          {ScalarFunction, 1, VariadicArgs},
+	"LEFT":           {ScalarFunction, 2, 2},
+	"LEN":            {ScalarFunction, 1, 1},
+	"LN":             {ScalarFunction, 1, 1},
+	"LOWER":          {ScalarFunction, 1, 1},
+	"LTRIM":          {ScalarFunction, 1, 2},
+	"MAX":            {AggregateFunction, 1, 1},
+	"MIN":            {AggregateFunction, 1, 1},
+	"MOD":            {ScalarFunction, 2, 2},
+	"NEWID":          {ScalarFunction, 0, 0},
+	"NTH_VALUE":      {WindowFunction, 2, 2},
+	"NTILE":          {WindowFunction, 1, 1},
+	"NULLIF":         {ScalarFunction, 2, 2},
+	"OBJECT_ID":      {ScalarFunction, 1, 2},
+	"PATINDEX":       {ScalarFunction, 2, 2},
+	"PERCENT_RANK":   {WindowFunction, 0, 0},
+	"POWER":          {ScalarFunction, 2, 2},
+	"RANK":           {WindowFunction, 0, 0},
+	"REPLACE":        {ScalarFunction, 3, 3},
+	"REPLICATE":      {ScalarFunction, 2, 2},
+	"RIGHT":          {ScalarFunction, 2, 2},
+	"ROUND":          {ScalarFunction, 1, 2},
+	"ROW_NUMBER":     {WindowFunction, 0, 0},
+	"RTRIM":          {ScalarFunction, 1, 2},
+	"SCOPE_IDENTITY": {ScalarFunction, 0, 0},
+	"SQRT":           {ScalarFunction, 1, 1},
+	"STDEV":          {AggregateFunction, 1, 1},
+	"STDEVP":         {AggregateFunction, 1, 1},
+	"STRING_AGG":     {AggregateFunction, 2, 2},
+	"STRING_SPLIT":   {ScalarFunction, 2, 3},
+	"STUFF":          {ScalarFunction, 4, 4},
+	"SUBSTRING":      {ScalarFunction, 3, 3},
+	"SUM":            {AggregateFunction, 1, 1},
+	"SYSDATETIME":    {ScalarFunction, 0, 0},
+	"UPPER":          {ScalarFunction, 1, 1},
+	"VAR":            {AggregateFunction, 1, 1},
+	"VARP":           {AggregateFunction, 1, 1},
+}
+
 // IsMSSQLKeyword returns a boolean indicating if the supplied string
 // is considered to be a keyword in MS-SQL
 func IsMSSQLKeyword(s string) bool {
@@ -258,13 +451,37 @@ func IsMSSQLOperator(s string) bool {
 	return ok
 }
 
+// MSSQLOperatorPrecedence returns the precedence and associativity of
+// the supplied operator in MSSQL
+func MSSQLOperatorPrecedence(s string, fixity int) (op Operator, ok bool) {
+	return mssqlOperatorPrecedence.lookup(s, fixity)
+}
+
+// MSSQLDataType returns the catalog entry for the supplied data type in
+// MSSQL
+func MSSQLDataType(s string) (t TypeInfo, ok bool) {
+	return mssqlDataTypes.lookup(s)
+}
+
+// MSSQLTypeName returns the name of the MSSQL data type to use for
+// the supplied generic data type
+func MSSQLTypeName(generic string) (s string, ok bool) {
+	return mssqlDataTypes.typeName(generic)
+}
+
+// MSSQLFunction returns the catalog entry for the supplied built-in function
+// in MSSQL
+func MSSQLFunction(s string) (f FunctionInfo, ok bool) {
+	return mssqlFunctions.lookup(s)
+}
+
 // IsMSSQLLabel returns a boolean indicating if the supplied string
 // is considered to be a label in MSSQL
 func IsMSSQLLabel(s string) bool {
 	if len(s) < 2 {
 		return false
 	}
-	if string(s[len(s)]) == ":" && IsMSSQLIdentifier(s[0:len(s)-1]) {
+	if string(s[len(s)-1]) == ":" && IsMSSQLIdentifier(s[0:len(s)-1]) {
 		return true
 	}
 	return false
@@ -664,6 +664,215 @@ var mysqlOperators = map[string]bool{
 	"+":   true,
 }
 
+// the infix, prefix, and postfix MySQL operators and their precedence
+var mysqlOperatorPrecedence = operatorTable{
+	infix: map[string]Operator{
+		":=":      {1, RightAssociative},
+		"OR":      {2, LeftAssociative},
+		"||":      {2, LeftAssociative},
+		"XOR":     {3, LeftAssociative},
+		"AND":     {4, LeftAssociative},
+		"&&":      {4, LeftAssociative},
+		"BETWEEN": {6, NonAssociative},
+		"=":       {7, NonAssociative},
+		"<=>":     {7, NonAssociative},
+		">=":      {7, NonAssociative},
+		">":       {7, NonAssociative},
+		"<=":      {7, NonAssociative},
+		"<":       {7, NonAssociative},
+		"<>":      {7, NonAssociative},
+		"!=":      {7, NonAssociative},
+		"IS":      {7, NonAssociative},
+		"LIKE":    {7, NonAssociative},
+		"REGEXP":  {7, NonAssociative},
+		"RLIKE":   {7, NonAssociative},
+		"IN":      {7, NonAssociative},
+		"SOUNDS":  {7, NonAssociative},
+		"|":       {8, LeftAssociative},
+		"&":       {9, LeftAssociative},
+		"<<":      {10, LeftAssociative},
+		">>":      {10, LeftAssociative},
+		"+":       {11, LeftAssociative},
+		"-":       {11, LeftAssociative},
+		"*":       {12, LeftAssociative},
+		"/":       {12, LeftAssociative},
+		"%":       {12, LeftAssociative},
+		"DIV":     {12, LeftAssociative},
+		"MOD":     {12, LeftAssociative},
+		"^":       {13, LeftAssociative},
+		"COLLATE": {16, LeftAssociative},
+		"->":      {17, LeftAssociative},
+		"->>":     {17, LeftAssociative},
+	},
+	prefix: map[string]Operator{
+		"NOT":    {5, RightAssociative},
+		"-":      {14, RightAssociative},
+		"+":      {14, RightAssociative},
+		"~":      {14, RightAssociative},
+		"!":      {15, RightAssociative},
+		"BINARY": {16, RightAssociative},
+	},
+	postfix: map[string]Operator{},
+}
+
+// the MySQL data types
+var mysqlDataTypes = typeCatalog{
+	types: map[string]TypeInfo{
+		"BIGINT":            {NumericDataType, "BIGINT"},
+		"BINARY":            {BinaryDataType, "BINARY"},
+		"BIT":               {BinaryDataType, "BINARY"},
+		"BLOB":              {BinaryDataType, "BLOB"},
+		"BOOL":              {BooleanDataType, "BOOLEAN"},
+		"BOOLEAN":           {BooleanDataType, "BOOLEAN"},
+		"CHAR":              {StringDataType, "CHAR"},
+		"CHARACTER":         {StringDataType, "CHAR"},
+		"CHARACTER VARYING": {StringDataType, "VARCHAR"},
+		"DATE":              {DateTimeDataType, "DATE"},
+		"DATETIME":          {DateTimeDataType, "TIMESTAMP"},
+		"DEC":               {NumericDataType, "DECIMAL"},
+		"DECIMAL":           {NumericDataType, "DECIMAL"},
+		"DOUBLE":            {NumericDataType, "DOUBLE"},
+		"DOUBLE PRECISION":  {NumericDataType, "DOUBLE"},
+		"ENUM":              {StringDataType, "VARCHAR"},
+		"FIXED":             {NumericDataType, "DECIMAL"},
+		"FLOAT":             {NumericDataType, "REAL"},
+		"GEOMETRY":          {OtherDataType, ""},
+		"INT":               {NumericDataType, "INTEGER"},
+		"INTEGER":           {NumericDataType, "INTEGER"},
+		"JSON":              {DocumentDataType, "JSON"},
+		"LINESTRING":        {OtherDataType, ""},
+		"LONGBLOB":          {BinaryDataType, "BLOB"},
+		"LONGTEXT":          {StringDataType, "TEXT"},
+		"MEDIUMBLOB":        {BinaryDataType, "BLOB"},
+		"MEDIUMINT":         {NumericDataType, "INTEGER"},
+		"MEDIUMTEXT":        {StringDataType, "TEXT"},
+		"NATIONAL CHAR":     {StringDataType, "NCHAR"},
+		"NATIONAL VARCHAR":  {StringDataType, "NVARCHAR"},
+		"NCHAR":             {StringDataType, "NCHAR"},
+		"NUMERIC":           {NumericDataType, "DECIMAL"},
+		"NVARCHAR":          {StringDataType, "NVARCHAR"},
+		"POINT":             {OtherDataType, ""},
+		"POLYGON":           {OtherDataType, ""},
+		"REAL":              {NumericDataType, "DOUBLE"},
+		"SET":               {StringDataType, "VARCHAR"},
+		"SMALLINT":          {NumericDataType, "SMALLINT"},
+		"TEXT":              {StringDataType, "TEXT"},
+		"TIME":              {DateTimeDataType, "TIME"},
+		"TIMESTAMP":         {DateTimeDataType, "TIMESTAMPTZ"},
+		"TINYBLOB":          {BinaryDataType, "BLOB"},
+		"TINYINT":           {NumericDataType, "TINYINT"},
+		"TINYTEXT":          {StringDataType, "TEXT"},
+		"VARBINARY":         {BinaryDataType, "VARBINARY"},
+		"VARCHAR":           {StringDataType, "VARCHAR"},
+		"YEAR":              {DateTimeDataType, "SMALLINT"},
+	},
+	names: map[string]string{
+		"BIGINT":      "BIGINT",
+		"BINARY":      "BINARY",
+		"BLOB":        "LONGBLOB",
+		"BOOLEAN":     "BOOLEAN",
+		"CHAR":        "CHAR",
+		"DATE":        "DATE",
+		"DECIMAL":     "DECIMAL",
+		"DOUBLE":      "DOUBLE",
+		"INTEGER":     "INT",
+		"JSON":        "JSON",
+		"NCHAR":       "NCHAR",
+		"NVARCHAR":    "NVARCHAR",
+		"REAL":        "FLOAT",
+		"SMALLINT":    "SMALLINT",
+		"TEXT":        "LONGTEXT",
+		"TIME":        "TIME",
+		"TIMESTAMP":   "DATETIME",
+		"TIMESTAMPTZ": "TIMESTAMP",
+		"TINYINT":     "TINYINT",
+		"UUID":        "CHAR(36)",
+		"VARBINARY":   "VARBINARY",
+		"VARCHAR":     "VARCHAR",
+		"XML":         "LONGTEXT",
+	},
+}
+
+// the MySQL built-in functions
+var mysqlFunctions = functionCatalog{
+	"ABS":             {ScalarFunction, 1, 1},
+	"AVG":             {AggregateFunction, 1, 1},
+	"BIT_AND":         {AggregateFunction, 1, 1},
+	"BIT_OR":          {AggregateFunction, 1, 1},
+	"CEIL":            {ScalarFunction, 1, 1},
+	"CEILING":         {ScalarFunction, 1, 1},
+	"CHAR_LENGTH":     {ScalarFunction, 1, 1},
+	"COALESCE":        {ScalarFunction, 1, VariadicArgs},
+	"CONCAT":          {ScalarFunction, 1, VariadicArgs},
+	"CONCAT_WS":       {ScalarFunction, 2, VariadicArgs},
+	"COUNT":           {AggregateFunction, 1, 1},
+	"CUME_DIST":       {WindowFunction, 0, 0},
+	"CURDATE":         {ScalarFunction, 0, 0},
+	"DATEDIFF":        {ScalarFunction, 2, 2},
+	"DATE_ADD":        {ScalarFunction, 2, 2},
+	"DATE_FORMAT":     {ScalarFunction, 2, 3},
+	"DATE_SUB":        {ScalarFunction, 2, 2},
+	"DENSE_RANK":      {WindowFunction, 0, 0},
+	"EXP":             {ScalarFunction, 1, 1},
+	"FIND_IN_SET":     {ScalarFunction, 2, 2},
+	"FIRST_VALUE":     {WindowFunction, 1, 1},
+	"FLOOR":           {ScalarFunction, 1, 1},
+	"FROM_UNIXTIME":   {ScalarFunction, 1, 2},
+	"GREATEST":        {ScalarFunction, 2, VariadicArgs},
+	"GROUP_CONCAT":    {AggregateFunction, 1, VariadicArgs},
+	"IF":              {ScalarFunction, 3, 3},
+	"IFNULL":          {ScalarFunction, 2, 2},
+	"INSTR":           {ScalarFunction, 2, 2},
+	"JSON_ARRAY":      {ScalarFunction, 0, VariadicArgs},
+	"JSON_ARRAYAGG":   {AggregateFunction, 1, 1},
+	"JSON_CONTAINS":   {ScalarFunction, 2, 3},
+	"JSON_EXTRACT":    {ScalarFunction, 2, VariadicArgs},
+	"JSON_OBJECT":     {ScalarFunction, 0, VariadicArgs},
+	"JSON_OBJECTAGG":  {AggregateFunction, 2, 2},
+	"JSON_SET":        {ScalarFunction, 3, VariadicArgs},
+	"JSON_UNQUOTE":    {ScalarFunction, 1, 1},
+	"LAG":             {WindowFunction, 1, 3},
+	"LAST_INSERT_ID":  {ScalarFunction, 0, 1},
+	"LAST_VALUE":      {WindowFunction, 1, 1},
+	"LEAD":            {WindowFunction, 1, 3},
+	"LEAST":           {ScalarFunction, 2, VariadicArgs},
+	"LEFT":            {ScalarFunction, 2, 2},
+	"LENGTH":          {ScalarFunction, 1, 1},
+	"LN":              {ScalarFunction, 1, 1},
+	"LOCATE":          {ScalarFunction, 2, 3},
+	"LOWER":           {ScalarFunction, 1, 1},
+	"LPAD":            {ScalarFunction, 3, 3},
+	"MAX":             {AggregateFunction, 1, 1},
+	"MIN":             {AggregateFunction, 1, 1},
+	"MOD":             {ScalarFunction, 2, 2},
+	"NOW":             {ScalarFunction, 0, 1},
+	"NTH_VALUE":       {WindowFunction, 2, 2},
+	"NTILE":           {WindowFunction, 1, 1},
+	"NULLIF":          {ScalarFunction, 2, 2},
+	"PERCENT_RANK":    {WindowFunction, 0, 0},
+	"POWER":           {ScalarFunction, 2, 2},
+	"RAND":            {ScalarFunction, 0, 1},
+	"RANK":            {WindowFunction, 0, 0},
+	"REPLACE":         {ScalarFunction, 3, 3},
+	"RIGHT":           {ScalarFunction, 2, 2},
+	"ROUND":           {ScalarFunction, 1, 2},
+	"ROW_NUMBER":      {WindowFunction, 0, 0},
+	"RPAD":            {ScalarFunction, 3, 3},
+	"SQRT":            {ScalarFunction, 1, 1},
+	"STD":             {AggregateFunction, 1, 1},
+	"STDDEV":          {AggregateFunction, 1, 1},
+	"STR_TO_DATE":     {ScalarFunction, 2, 2},
+	"SUBSTR":          {ScalarFunction, 2, 3},
+	"SUBSTRING":       {ScalarFunction, 2, 3},
+	"SUBSTRING_INDEX": {ScalarFunction, 3, 3},
+	"SUM":             {AggregateFunction, 1, 1},
+	"TRUNCATE":        {ScalarFunction, 2, 2},
+	"UNIX_TIMESTAMP":  {ScalarFunction, 0, 1},
+	"UPPER":           {ScalarFunction, 1, 1},
+	"UUID":            {ScalarFunction, 0, 0},
+	"VARIANCE":        {AggregateFunction, 1, 1},
+}
+
 // IsMySQLKeyword returns a boolean indicating if the supplied string
 // is considered to be a keyword in MySQL
 func IsMySQLKeyword(s string) bool {
@@ -690,13 +899,37 @@ func IsMySQLOperator(s string) bool {
 	return ok
 }
 
+// MySQLOperatorPrecedence returns the precedence and associativity of
+// the supplied operator in MySQL
+func MySQLOperatorPrecedence(s string, fixity int) (op Operator, ok bool) {
+	return mysqlOperatorPrecedence.lookup(s, fixity)
+}
+
+// MySQLDataType returns the catalog entry for the supplied data type in
+// MySQL
+func MySQLDataType(s string) (t TypeInfo, ok bool) {
+	return mysqlDataTypes.lookup(s)
+}
+
+// MySQLTypeName returns the name of the MySQL data type to use for
+// the supplied generic data type
+func MySQLTypeName(generic string) (s string, ok bool) {
+	return mysqlDataTypes.typeName(generic)
+}
+
+// MySQLFunction returns the catalog entry for the supplied built-in function
+// in MySQL
+func MySQLFunction(s string) (f FunctionInfo, ok bool) {
+	return mysqlFunctions.lookup(s)
+}
+
 // IsMySQLLabel returns a boolean indicating if the supplied string
 // is considered to be a label in MySQL
 func IsMySQLLabel(s string) bool {
 	if len(s) < 2 {
 		return false
 	}
-	if string(s[len(s)]) == ":" && IsMySQLIdentifier(s[0:len(s)-1]) {
+	if string(s[len(s)-1]) == ":" && IsMySQLIdentifier(s[0:len(s)-1]) {
 		return true
 	}
 	return false
@@ -0,0 +1,63 @@
+package dialects
+
+import "strings"
+
+/*
+Operator precedence and associativity
+
+Each dialect provides maps of the infix, prefix, and postfix operators
+(including the keyword operators such as AND, LIKE, and IS) and their
+precedence. Operators with a higher precedence bind more tightly.
+
+*/
+
+// Operator fixity
+const (
+	// InfixOperator is an operator that appears between its operands
+	InfixOperator = iota
+	// PrefixOperator is an operator that appears before its operand
+	PrefixOperator
+	// PostfixOperator is an operator that appears after its operand
+	PostfixOperator
+)
+
+// Operator associativity
+const (
+	// LeftAssociative operators group from left to right
+	LeftAssociative = iota
+	// RightAssociative operators group from right to left
+	RightAssociative
+	// NonAssociative operators do not group with operators of the same precedence
+	NonAssociative
+)
+
+// Operator provides the precedence and associativity of an operator
+type Operator struct {
+	Precedence    int // the binding strength of the operator
+	Associativity int // LeftAssociative, RightAssociative, or NonAssociative
+}
+
+// operatorTable provides the operators of a dialect by fixity
+type operatorTable struct {
+	infix   map[string]Operator
+	prefix  map[string]Operator
+	postfix map[string]Operator
+}
+
+// lookup returns the operator for the supplied string and fixity.
+// Keyword operators are matched without regard to case.
+func (d operatorTable) lookup(s string, fixity int) (op Operator, ok bool) {
+
+	var m map[string]Operator
+	switch fixity {
+	case InfixOperator:
+		m = d.infix
+	case PrefixOperator:
+		m = d.prefix
+	case PostfixOperator:
+		m = d.postfix
+	}
+
+	op, ok = m[strings.ToUpper(s)]
+	return op, ok
+}
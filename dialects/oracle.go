@@ -20,6 +20,12 @@ SELECT  CASE
             END,
             keyword ;
 
+The words that are listed as reserved in the Oracle SQL Reserved Words
+appendix of the SQL Language Reference, but that v$reserved_words does
+not flag as reserved (COMMENT, LEVEL, ROWNUM, USER, ...), are also
+marked as reserved.
+
+https://docs.oracle.com/en/database/oracle/oracle-database/19/sqlrf/Oracle-SQL-Reserved-Words.html
 
 PL/SQL Reserved Words and Keywords
 
@@ -43,7 +49,7 @@ var oracleSQLKeywords = map[string]bool{
 	"ABORT":                          false,
 	"ABS":                            false,
 	"ABSENT":                         false,
-	"ACCESS":                         false,
+	"ACCESS":                         true,
 	"ACCESSED":                       false,
 	"ACCOUNT":                        false,
 	"ACL":                            false,
@@ -59,7 +65,7 @@ var oracleSQLKeywords = map[string]bool{
 	"ACTIVE_TAG":                     false,
 	"ACTIVITY":                       false,
 	"ADAPTIVE_PLAN":                  false,
-	"ADD":                            false,
+	"ADD":                            true,
 	"ADD_COLUMN":                     false,
 	"ADD_GROUP":                      false,
 	"ADD_MONTHS":                     false,
@@ -135,7 +141,7 @@ var oracleSQLKeywords = map[string]bool{
 	"ATAN2":                          false,
 	"ATTRIBUTE":                      false,
 	"ATTRIBUTES":                     false,
-	"AUDIT":                          false,
+	"AUDIT":                          true,
 	"AUTHENTICATED":                  false,
 	"AUTHENTICATION":                 false,
 	"AUTHID":                         false,
@@ -277,14 +283,14 @@ var oracleSQLKeywords = map[string]bool{
 	"COLLATE":                        false,
 	"COLLATION":                      false,
 	"COLLECT":                        false,
-	"COLUMN":                         false,
+	"COLUMN":                         true,
 	"COLUMNAR":                       false,
 	"COLUMNS":                        false,
 	"COLUMN_AUTHORIZATION_INDICATOR": false,
 	"COLUMN_AUTH_INDICATOR":          false,
 	"COLUMN_STATS":                   false,
 	"COLUMN_VALUE":                   false,
-	"COMMENT":                        false,
+	"COMMENT":                        true,
 	"COMMIT":                         false,
 	"COMMITTED":                      false,
 	"COMMON":                         false,
@@ -383,7 +389,7 @@ var oracleSQLKeywords = map[string]bool{
 	"CUBE_SJ":                        false,
 	"CUME_DIST":                      false,
 	"CUME_DISTM":                     false,
-	"CURRENT":                        false,
+	"CURRENT":                        true,
 	"CURRENTV":                       false,
 	"CURRENT_DATE":                   false,
 	"CURRENT_INSTANCE":               false,
@@ -615,7 +621,7 @@ var oracleSQLKeywords = map[string]bool{
 	"FEATURE_VALUE":                  false,
 	"FEDERATION":                     false,
 	"FETCH":                          false,
-	"FILE":                           false,
+	"FILE":                           true,
 	"FILEGROUP":                      false,
 	"FILESYSTEM_LIKE_LOGGING":        false,
 	"FILE_NAME_CONVERT":              false,
@@ -736,7 +742,7 @@ var oracleSQLKeywords = map[string]bool{
 	"IGNORE_ROW_ON_DUPKEY_INDEX":     false,
 	"IGNORE_WHERE_CLAUSE":            false,
 	"ILM":                            false,
-	"IMMEDIATE":                      false,
+	"IMMEDIATE":                      true,
 	"IMPACT":                         false,
 	"IMPORT":                         false,
 	"IN":                             true,
@@ -747,7 +753,7 @@ var oracleSQLKeywords = map[string]bool{
 	"INCLUDE_VERSION":                false,
 	"INCLUDING":                      false,
 	"INCR":                           false,
-	"INCREMENT":                      false,
+	"INCREMENT":                      true,
 	"INCREMENTAL":                    false,
 	"INDENT":                         false,
 	"INDEX":                          true,
@@ -778,7 +784,7 @@ var oracleSQLKeywords = map[string]bool{
 	"INFORMATIONAL":                  false,
 	"INHERIT":                        false,
 	"INITCAP":                        false,
-	"INITIAL":                        false,
+	"INITIAL":                        true,
 	"INITIALIZED":                    false,
 	"INITIALLY":                      false,
 	"INITRANS":                       false,
@@ -891,7 +897,7 @@ var oracleSQLKeywords = map[string]bool{
 	"LENGTHB":                        false,
 	"LENGTHC":                        false,
 	"LESS":                           false,
-	"LEVEL":                          false,
+	"LEVEL":                          true,
 	"LEVELS":                         false,
 	"LIBRARY":                        false,
 	"LIFE":                           false,
@@ -960,7 +966,7 @@ var oracleSQLKeywords = map[string]bool{
 	"MAX":                            false,
 	"MAXARCHLOGS":                    false,
 	"MAXDATAFILES":                   false,
-	"MAXEXTENTS":                     false,
+	"MAXEXTENTS":                     true,
 	"MAXIMIZE":                       false,
 	"MAXINSTANCES":                   false,
 	"MAXLOGFILES":                    false,
@@ -1007,7 +1013,7 @@ var oracleSQLKeywords = map[string]bool{
 	"MIRROR":                         false,
 	"MIRRORCOLD":                     false,
 	"MIRRORHOT":                      false,
-	"MLSLABEL":                       false,
+	"MLSLABEL":                       true,
 	"MOD":                            false,
 	"MODE":                           true,
 	"MODEL":                          false,
@@ -1021,7 +1027,7 @@ var oracleSQLKeywords = map[string]bool{
 	"MODEL_PUSH_REF":                 false,
 	"MODEL_SV":                       false,
 	"MODIFICATION":                   false,
-	"MODIFY":                         false,
+	"MODIFY":                         true,
 	"MODIFY_COLUMN_TYPE":             false,
 	"MODULE":                         false,
 	"MONITOR":                        false,
@@ -1097,7 +1103,7 @@ var oracleSQLKeywords = map[string]bool{
 	"NO":                             false,
 	"NOAPPEND":                       false,
 	"NOARCHIVELOG":                   false,
-	"NOAUDIT":                        false,
+	"NOAUDIT":                        true,
 	"NOCACHE":                        false,
 	"NOCOMPRESS":                     true,
 	"NOCOPY":                         false,
@@ -1294,7 +1300,7 @@ var oracleSQLKeywords = map[string]bool{
 	"OCCURRENCES":                    false,
 	"OF":                             true,
 	"OFF":                            false,
-	"OFFLINE":                        false,
+	"OFFLINE":                        true,
 	"OFFSET":                         false,
 	"OID":                            false,
 	"OIDINDEX":                       false,
@@ -1306,7 +1312,7 @@ var oracleSQLKeywords = map[string]bool{
 	"OMIT":                           false,
 	"ON":                             true,
 	"ONE":                            false,
-	"ONLINE":                         false,
+	"ONLINE":                         true,
 	"ONLY":                           false,
 	"OPAQUE":                         false,
 	"OPAQUE_TRANSFORM":               false,
@@ -1642,14 +1648,14 @@ var oracleSQLKeywords = map[string]bool{
 	"ROOT":                           false,
 	"ROUND":                          false,
 	"ROUND_TIES_TO_EVEN":             false,
-	"ROW":                            false,
+	"ROW":                            true,
 	"ROWDEPENDENCIES":                false,
-	"ROWID":                          false,
+	"ROWID":                          true,
 	"ROWIDTOCHAR":                    false,
 	"ROWIDTONCHAR":                   false,
 	"ROWID_MAPPING_TABLE":            false,
-	"ROWNUM":                         false,
-	"ROWS":                           false,
+	"ROWNUM":                         true,
+	"ROWS":                           true,
 	"ROW_LENGTH":                     false,
 	"ROW_NUMBER":                     false,
 	"RPAD":                           false,
@@ -1706,7 +1712,7 @@ var oracleSQLKeywords = map[string]bool{
 	"SERVICE":                        false,
 	"SERVICES":                       false,
 	"SERVICE_NAME_CONVERT":           false,
-	"SESSION":                        false,
+	"SESSION":                        true,
 	"SESSIONS_PER_USER":              false,
 	"SESSIONTIMEZONE":                false,
 	"SESSIONTZNAME":                  false,
@@ -1827,7 +1833,7 @@ var oracleSQLKeywords = map[string]bool{
 	"SUBSTRB":                        false,
 	"SUBSTRC":                        false,
 	"SUCCESS":                        false,
-	"SUCCESSFUL":                     false,
+	"SUCCESSFUL":                     true,
 	"SUM":                            false,
 	"SUMMARY":                        false,
 	"SUPPLEMENTAL":                   false,
@@ -1842,7 +1848,7 @@ var oracleSQLKeywords = map[string]bool{
 	"SYSASM":                         false,
 	"SYSAUX":                         false,
 	"SYSBACKUP":                      false,
-	"SYSDATE":                        false,
+	"SYSDATE":                        true,
 	"SYSDBA":                         false,
 	"SYSDG":                          false,
 	"SYSGUID":                        false,
@@ -2223,7 +2229,7 @@ var oracleSQLKeywords = map[string]bool{
 	"UB2":                            false,
 	"UBA":                            false,
 	"UCS2":                           false,
-	"UID":                            false,
+	"UID":                            true,
 	"UNARCHIVED":                     false,
 	"UNBOUND":                        false,
 	"UNBOUNDED":                      false,
@@ -2270,7 +2276,7 @@ var oracleSQLKeywords = map[string]bool{
 	"USABLE":                         false,
 	"USAGE":                          false,
 	"USE":                            false,
-	"USER":                           false,
+	"USER":                           true,
 	"USERENV":                        false,
 	"USERGROUP":                      false,
 	"USERS":                          false,
@@ -2309,7 +2315,7 @@ var oracleSQLKeywords = map[string]bool{
 	"UTF8":                           false,
 	"V1":                             false,
 	"V2":                             false,
-	"VALIDATE":                       false,
+	"VALIDATE":                       true,
 	"VALIDATE_CONVERSION":            false,
 	"VALIDATION":                     false,
 	"VALID_TIME_END":                 false,
@@ -2354,7 +2360,7 @@ var oracleSQLKeywords = map[string]bool{
 	"WEEKS":                          false,
 	"WELLFORMED":                     false,
 	"WHEN":                           false,
-	"WHENEVER":                       false,
+	"WHENEVER":                       true,
 	"WHERE":                          true,
 	"WHITESPACE":                     false,
 	"WIDTH_BUCKET":                   false,
@@ -2772,6 +2778,185 @@ var oracleOperators = map[string]bool{
 	"+":   true,
 }
 
+// the infix, prefix, and postfix Oracle operators and their precedence
+var oracleOperatorPrecedence = operatorTable{
+	infix: map[string]Operator{
+		":=":      {1, RightAssociative},
+		"OR":      {2, LeftAssociative},
+		"AND":     {3, LeftAssociative},
+		"=":       {5, NonAssociative},
+		"!=":      {5, NonAssociative},
+		"^=":      {5, NonAssociative},
+		"¬=":      {5, NonAssociative},
+		"<>":      {5, NonAssociative},
+		"<":       {5, NonAssociative},
+		">":       {5, NonAssociative},
+		"<=":      {5, NonAssociative},
+		">=":      {5, NonAssociative},
+		"IS":      {5, NonAssociative},
+		"LIKE":    {5, NonAssociative},
+		"LIKEC":   {5, NonAssociative},
+		"LIKE2":   {5, NonAssociative},
+		"LIKE4":   {5, NonAssociative},
+		"BETWEEN": {5, NonAssociative},
+		"IN":      {5, NonAssociative},
+		"+":       {6, LeftAssociative},
+		"-":       {6, LeftAssociative},
+		"||":      {6, LeftAssociative},
+		"*":       {7, LeftAssociative},
+		"/":       {7, LeftAssociative},
+		"COLLATE": {9, LeftAssociative},
+	},
+	prefix: map[string]Operator{
+		"NOT":             {4, RightAssociative},
+		"+":               {8, RightAssociative},
+		"-":               {8, RightAssociative},
+		"PRIOR":           {8, RightAssociative},
+		"CONNECT_BY_ROOT": {8, RightAssociative},
+	},
+	postfix: map[string]Operator{
+		"(+)": {10, LeftAssociative},
+	},
+}
+
+// the Oracle data types
+var oracleDataTypes = typeCatalog{
+	types: map[string]TypeInfo{
+		"BFILE":                          {BinaryDataType, "BLOB"},
+		"BINARY_DOUBLE":                  {NumericDataType, "DOUBLE"},
+		"BINARY_FLOAT":                   {NumericDataType, "REAL"},
+		"BLOB":                           {BinaryDataType, "BLOB"},
+		"CHAR":                           {StringDataType, "CHAR"},
+		"CHARACTER":                      {StringDataType, "CHAR"},
+		"CLOB":                           {StringDataType, "TEXT"},
+		"DATE":                           {DateTimeDataType, "TIMESTAMP"},
+		"DECIMAL":                        {NumericDataType, "DECIMAL"},
+		"DOUBLE PRECISION":               {NumericDataType, "DOUBLE"},
+		"FLOAT":                          {NumericDataType, "DOUBLE"},
+		"INT":                            {NumericDataType, "INTEGER"},
+		"INTEGER":                        {NumericDataType, "INTEGER"},
+		"INTERVAL DAY TO SECOND":         {DateTimeDataType, "INTERVAL"},
+		"INTERVAL YEAR TO MONTH":         {DateTimeDataType, "INTERVAL"},
+		"JSON":                           {DocumentDataType, "JSON"},
+		"LONG":                           {StringDataType, "TEXT"},
+		"LONG RAW":                       {BinaryDataType, "BLOB"},
+		"NCHAR":                          {StringDataType, "NCHAR"},
+		"NCLOB":                          {StringDataType, "TEXT"},
+		"NUMBER":                         {NumericDataType, "DECIMAL"},
+		"NUMERIC":                        {NumericDataType, "DECIMAL"},
+		"NVARCHAR2":                      {StringDataType, "NVARCHAR"},
+		"RAW":                            {BinaryDataType, "VARBINARY"},
+		"REAL":                           {NumericDataType, "DOUBLE"},
+		"ROWID":                          {OtherDataType, ""},
+		"SMALLINT":                       {NumericDataType, "INTEGER"},
+		"TIMESTAMP":                      {DateTimeDataType, "TIMESTAMP"},
+		"TIMESTAMP WITH LOCAL TIME ZONE": {DateTimeDataType, "TIMESTAMPTZ"},
+		"TIMESTAMP WITH TIME ZONE":       {DateTimeDataType, "TIMESTAMPTZ"},
+		"UROWID":                         {OtherDataType, ""},
+		"VARCHAR":                        {StringDataType, "VARCHAR"},
+		"VARCHAR2":                       {StringDataType, "VARCHAR"},
+		"XMLTYPE":                        {DocumentDataType, "XML"},
+	},
+	names: map[string]string{
+		"BIGINT":      "NUMBER(19)",
+		"BINARY":      "RAW",
+		"BLOB":        "BLOB",
+		"BOOLEAN":     "NUMBER(1)",
+		"CHAR":        "CHAR",
+		"DATE":        "DATE",
+		"DECIMAL":     "NUMBER",
+		"DOUBLE":      "BINARY_DOUBLE",
+		"INTEGER":     "NUMBER(10)",
+		"INTERVAL":    "INTERVAL DAY TO SECOND",
+		"JSON":        "CLOB",
+		"NCHAR":       "NCHAR",
+		"NVARCHAR":    "NVARCHAR2",
+		"REAL":        "BINARY_FLOAT",
+		"SMALLINT":    "NUMBER(5)",
+		"TEXT":        "CLOB",
+		"TIME":        "DATE",
+		"TIMESTAMP":   "TIMESTAMP",
+		"TIMESTAMPTZ": "TIMESTAMP WITH TIME ZONE",
+		"TINYINT":     "NUMBER(3)",
+		"UUID":        "RAW(16)",
+		"VARBINARY":   "RAW",
+		"VARCHAR":     "VARCHAR2",
+		"XML":         "XMLTYPE",
+	},
+}
+
+// the Oracle built-in functions
+var oracleFunctions = functionCatalog{
+	"ABS":             {ScalarFunction, 1, 1},
+	"ADD_MONTHS":      {ScalarFunction, 2, 2},
+	"ASCII":           {ScalarFunction, 1, 1},
+	"AVG":             {AggregateFunction, 1, 1},
+	"CEIL":            {ScalarFunction, 1, 1},
+	"CEILING":         {ScalarFunction, 1, 1},
+	"CHR":             {ScalarFunction, 1, 1},
+	"COALESCE":        {ScalarFunction, 1, VariadicArgs},
+	"CONCAT":          {ScalarFunction, 2, 2},
+	"COUNT":           {AggregateFunction, 1, 1},
+	"CUME_DIST":       {WindowFunction, 0, 0},
+	"DECODE":          {ScalarFunction, 3, VariadicArgs},
+	"DENSE_RANK":      {WindowFunction, 0, 0},
+	"EXP":             {ScalarFunction, 1, 1},
+	"FIRST_VALUE":     {WindowFunction, 1, 1},
+	"FLOOR":           {ScalarFunction, 1, 1},
+	"GREATEST":        {ScalarFunction, 1, VariadicArgs},
+	"INITCAP":         {ScalarFunction, 1, 1},
+	"INSTR":           {ScalarFunction, 2, 4},
+	"JSON_QUERY":      {ScalarFunction, 2, 2},
+	"JSON_VALUE":      {ScalarFunction, 2, 2},
+	"LAG":             {WindowFunction, 1, 3},
+	"LAST_DAY":        {ScalarFunction, 1, 1},
+	"LAST_VALUE":      {WindowFunction, 1, 1},
+	"LEAD":            {WindowFunction, 1, 3},
+	"LEAST":           {ScalarFunction, 1, VariadicArgs},
+	"LENGTH":          {ScalarFunction, 1, 1},
+	"LISTAGG":         {AggregateFunction, 1, 2},
+	"LN":              {ScalarFunction, 1, 1},
+	"LOWER":           {ScalarFunction, 1, 1},
+	"LPAD":            {ScalarFunction, 2, 3},
+	"LTRIM":           {ScalarFunction, 1, 2},
+	"MAX":             {AggregateFunction, 1, 1},
+	"MEDIAN":          {AggregateFunction, 1, 1},
+	"MIN":             {AggregateFunction, 1, 1},
+	"MOD":             {ScalarFunction, 2, 2},
+	"MONTHS_BETWEEN":  {ScalarFunction, 2, 2},
+	"NTH_VALUE":       {WindowFunction, 2, 2},
+	"NTILE":           {WindowFunction, 1, 1},
+	"NULLIF":          {ScalarFunction, 2, 2},
+	"NVL":             {ScalarFunction, 2, 2},
+	"NVL2":            {ScalarFunction, 3, 3},
+	"PERCENT_RANK":    {WindowFunction, 0, 0},
+	"POWER":           {ScalarFunction, 2, 2},
+	"RANK":            {WindowFunction, 0, 0},
+	"RATIO_TO_REPORT": {WindowFunction, 1, 1},
+	"REGEXP_INSTR":    {ScalarFunction, 2, 7},
+	"REGEXP_LIKE":     {ScalarFunction, 2, 3},
+	"REGEXP_REPLACE":  {ScalarFunction, 2, 6},
+	"REGEXP_SUBSTR":   {ScalarFunction, 2, 6},
+	"REPLACE":         {ScalarFunction, 2, 3},
+	"ROUND":           {ScalarFunction, 1, 2},
+	"ROW_NUMBER":      {WindowFunction, 0, 0},
+	"RPAD":            {ScalarFunction, 2, 3},
+	"RTRIM":           {ScalarFunction, 1, 2},
+	"SQRT":            {ScalarFunction, 1, 1},
+	"STDDEV":          {AggregateFunction, 1, 1},
+	"SUBSTR":          {ScalarFunction, 2, 3},
+	"SUM":             {AggregateFunction, 1, 1},
+	"SYS_GUID":        {ScalarFunction, 0, 0},
+	"TO_CHAR":         {ScalarFunction, 1, 3},
+	"TO_DATE":         {ScalarFunction, 1, 3},
+	"TO_NUMBER":       {ScalarFunction, 1, 3},
+	"TO_TIMESTAMP":    {ScalarFunction, 1, 3},
+	"TRUNC":           {ScalarFunction, 1, 2},
+	"UPPER":           {ScalarFunction, 1, 1},
+	"VARIANCE":        {AggregateFunction, 1, 1},
+	"XMLAGG":          {AggregateFunction, 1, 1},
+}
+
 // // "!": true,
 // "!=": false,
 // // "$": false,
@@ -2837,6 +3022,30 @@ func IsOracleOperator(s string) bool {
 	return ok
 }
 
+// OracleOperatorPrecedence returns the precedence and associativity of
+// the supplied operator in Oracle
+func OracleOperatorPrecedence(s string, fixity int) (op Operator, ok bool) {
+	return oracleOperatorPrecedence.lookup(s, fixity)
+}
+
+// OracleDataType returns the catalog entry for the supplied data type in
+// Oracle
+func OracleDataType(s string) (t TypeInfo, ok bool) {
+	return oracleDataTypes.lookup(s)
+}
+
+// OracleTypeName returns the name of the Oracle data type to use for
+// the supplied generic data type
+func OracleTypeName(generic string) (s string, ok bool) {
+	return oracleDataTypes.typeName(generic)
+}
+
+// OracleFunction returns the catalog entry for the supplied built-in function
+// in Oracle
+func OracleFunction(s string) (f FunctionInfo, ok bool) {
+	return oracleFunctions.lookup(s)
+}
+
 // IsOracleLabel returns a boolean indicating if the supplied string
 // is considered to be a label in Oracle
 func IsOracleLabel(s string) bool {
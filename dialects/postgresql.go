@@ -457,6 +457,14 @@ var pgKeywords = map[string]bool{
 
 var pgOperators = map[string]bool{
 	"^":   true,
+	"->":  true,
+	"->>": true,
+	"#>":  true,
+	"#>>": true,
+	"@>":  true,
+	"<@":  true,
+	"&&":  true,
+	"@@":  true,
 	"~":   true,
 	"~*":  true,
 	"<<":  true,
@@ -488,6 +496,254 @@ var pgOperators = map[string]bool{
 	"+":   true,
 }
 
+// the infix, prefix, and postfix PostgreSQL operators and their precedence
+var pgOperatorPrecedence = operatorTable{
+	infix: map[string]Operator{
+		":=":      {1, RightAssociative},
+		"OR":      {2, LeftAssociative},
+		"AND":     {3, LeftAssociative},
+		"IS":      {5, NonAssociative},
+		"<":       {6, NonAssociative},
+		">":       {6, NonAssociative},
+		"=":       {6, NonAssociative},
+		"<=":      {6, NonAssociative},
+		">=":      {6, NonAssociative},
+		"<>":      {6, NonAssociative},
+		"!=":      {6, NonAssociative},
+		"BETWEEN": {7, NonAssociative},
+		"IN":      {7, NonAssociative},
+		"LIKE":    {7, NonAssociative},
+		"ILIKE":   {7, NonAssociative},
+		"SIMILAR": {7, NonAssociative},
+		"~":       {8, LeftAssociative},
+		"~*":      {8, LeftAssociative},
+		"!~":      {8, LeftAssociative},
+		"!~*":     {8, LeftAssociative},
+		"<<":      {8, LeftAssociative},
+		">>":      {8, LeftAssociative},
+		"|":       {8, LeftAssociative},
+		"&":       {8, LeftAssociative},
+		"#":       {8, LeftAssociative},
+		"||":      {8, LeftAssociative},
+		"->":      {8, LeftAssociative},
+		"->>":     {8, LeftAssociative},
+		"#>":      {8, LeftAssociative},
+		"#>>":     {8, LeftAssociative},
+		"@>":      {8, LeftAssociative},
+		"<@":      {8, LeftAssociative},
+		"&&":      {8, LeftAssociative},
+		"@@":      {8, LeftAssociative},
+		"+":       {9, LeftAssociative},
+		"-":       {9, LeftAssociative},
+		"*":       {10, LeftAssociative},
+		"/":       {10, LeftAssociative},
+		"%":       {10, LeftAssociative},
+		"^":       {11, LeftAssociative},
+		"AT":      {12, LeftAssociative},
+		"COLLATE": {13, LeftAssociative},
+		"::":      {15, LeftAssociative},
+	},
+	prefix: map[string]Operator{
+		"NOT": {4, RightAssociative},
+		"~":   {8, RightAssociative},
+		"|/":  {8, RightAssociative},
+		"||/": {8, RightAssociative},
+		"@":   {8, RightAssociative},
+		"!!":  {8, RightAssociative},
+		"+":   {14, RightAssociative},
+		"-":   {14, RightAssociative},
+	},
+	postfix: map[string]Operator{
+		"ISNULL":  {5, NonAssociative},
+		"NOTNULL": {5, NonAssociative},
+	},
+}
+
+// the PostgreSQL data types
+var pgDataTypes = typeCatalog{
+	types: map[string]TypeInfo{
+		"BIGINT":                      {NumericDataType, "BIGINT"},
+		"BIGSERIAL":                   {NumericDataType, "BIGINT"},
+		"BIT":                         {BinaryDataType, "BINARY"},
+		"BIT VARYING":                 {BinaryDataType, "VARBINARY"},
+		"BOOL":                        {BooleanDataType, "BOOLEAN"},
+		"BOOLEAN":                     {BooleanDataType, "BOOLEAN"},
+		"BOX":                         {OtherDataType, ""},
+		"BPCHAR":                      {StringDataType, "CHAR"},
+		"BYTEA":                       {BinaryDataType, "BLOB"},
+		"CHAR":                        {StringDataType, "CHAR"},
+		"CHARACTER":                   {StringDataType, "CHAR"},
+		"CHARACTER VARYING":           {StringDataType, "VARCHAR"},
+		"CIDR":                        {OtherDataType, ""},
+		"CIRCLE":                      {OtherDataType, ""},
+		"DATE":                        {DateTimeDataType, "DATE"},
+		"DECIMAL":                     {NumericDataType, "DECIMAL"},
+		"DOUBLE PRECISION":            {NumericDataType, "DOUBLE"},
+		"FLOAT":                       {NumericDataType, "DOUBLE"},
+		"FLOAT4":                      {NumericDataType, "REAL"},
+		"FLOAT8":                      {NumericDataType, "DOUBLE"},
+		"INET":                        {OtherDataType, ""},
+		"INT":                         {NumericDataType, "INTEGER"},
+		"INT2":                        {NumericDataType, "SMALLINT"},
+		"INT4":                        {NumericDataType, "INTEGER"},
+		"INT8":                        {NumericDataType, "BIGINT"},
+		"INTEGER":                     {NumericDataType, "INTEGER"},
+		"INTERVAL":                    {DateTimeDataType, "INTERVAL"},
+		"JSON":                        {DocumentDataType, "JSON"},
+		"JSONB":                       {DocumentDataType, "JSON"},
+		"LINE":                        {OtherDataType, ""},
+		"LSEG":                        {OtherDataType, ""},
+		"MACADDR":                     {OtherDataType, ""},
+		"MACADDR8":                    {OtherDataType, ""},
+		"MONEY":                       {NumericDataType, "DECIMAL"},
+		"NAME":                        {StringDataType, "VARCHAR"},
+		"NUMERIC":                     {NumericDataType, "DECIMAL"},
+		"OID":                         {NumericDataType, "INTEGER"},
+		"PATH":                        {OtherDataType, ""},
+		"POINT":                       {OtherDataType, ""},
+		"POLYGON":                     {OtherDataType, ""},
+		"REAL":                        {NumericDataType, "REAL"},
+		"SERIAL":                      {NumericDataType, "INTEGER"},
+		"SERIAL2":                     {NumericDataType, "SMALLINT"},
+		"SERIAL4":                     {NumericDataType, "INTEGER"},
+		"SERIAL8":                     {NumericDataType, "BIGINT"},
+		"SMALLINT":                    {NumericDataType, "SMALLINT"},
+		"SMALLSERIAL":                 {NumericDataType, "SMALLINT"},
+		"TEXT":                        {StringDataType, "TEXT"},
+		"TIME":                        {DateTimeDataType, "TIME"},
+		"TIME WITH TIME ZONE":         {DateTimeDataType, "TIME"},
+		"TIME WITHOUT TIME ZONE":      {DateTimeDataType, "TIME"},
+		"TIMESTAMP":                   {DateTimeDataType, "TIMESTAMP"},
+		"TIMESTAMP WITH TIME ZONE":    {DateTimeDataType, "TIMESTAMPTZ"},
+		"TIMESTAMP WITHOUT TIME ZONE": {DateTimeDataType, "TIMESTAMP"},
+		"TIMESTAMPTZ":                 {DateTimeDataType, "TIMESTAMPTZ"},
+		"TIMETZ":                      {DateTimeDataType, "TIME"},
+		"TSQUERY":                     {OtherDataType, ""},
+		"TSVECTOR":                    {OtherDataType, ""},
+		"UUID":                        {OtherDataType, "UUID"},
+		"VARBIT":                      {BinaryDataType, "VARBINARY"},
+		"VARCHAR":                     {StringDataType, "VARCHAR"},
+		"XML":                         {DocumentDataType, "XML"},
+	},
+	names: map[string]string{
+		"BIGINT":      "BIGINT",
+		"BINARY":      "BYTEA",
+		"BLOB":        "BYTEA",
+		"BOOLEAN":     "BOOLEAN",
+		"CHAR":        "CHAR",
+		"DATE":        "DATE",
+		"DECIMAL":     "NUMERIC",
+		"DOUBLE":      "DOUBLE PRECISION",
+		"INTEGER":     "INTEGER",
+		"INTERVAL":    "INTERVAL",
+		"JSON":        "JSONB",
+		"NCHAR":       "CHAR",
+		"NVARCHAR":    "VARCHAR",
+		"REAL":        "REAL",
+		"SMALLINT":    "SMALLINT",
+		"TEXT":        "TEXT",
+		"TIME":        "TIME",
+		"TIMESTAMP":   "TIMESTAMP",
+		"TIMESTAMPTZ": "TIMESTAMP WITH TIME ZONE",
+		"TINYINT":     "SMALLINT",
+		"UUID":        "UUID",
+		"VARBINARY":   "BYTEA",
+		"VARCHAR":     "VARCHAR",
+		"XML":         "XML",
+	},
+}
+
+// the PostgreSQL built-in functions
+var pgFunctions = functionCatalog{
+	"ABS":                    {ScalarFunction, 1, 1},
+	"AGE":                    {ScalarFunction, 1, 2},
+	"ARRAY_AGG":              {AggregateFunction, 1, 1},
+	"ARRAY_LENGTH":           {ScalarFunction, 2, 2},
+	"ARRAY_TO_STRING":        {ScalarFunction, 2, 3},
+	"AVG":                    {AggregateFunction, 1, 1},
+	"BIT_AND":                {AggregateFunction, 1, 1},
+	"BIT_OR":                 {AggregateFunction, 1, 1},
+	"BOOL_AND":               {AggregateFunction, 1, 1},
+	"BOOL_OR":                {AggregateFunction, 1, 1},
+	"CEIL":                   {ScalarFunction, 1, 1},
+	"CEILING":                {ScalarFunction, 1, 1},
+	"CHAR_LENGTH":            {ScalarFunction, 1, 1},
+	"COALESCE":               {ScalarFunction, 1, VariadicArgs},
+	"CONCAT":                 {ScalarFunction, 1, VariadicArgs},
+	"CONCAT_WS":              {ScalarFunction, 2, VariadicArgs},
+	"COUNT":                  {AggregateFunction, 1, 1},
+	"CUME_DIST":              {WindowFunction, 0, 0},
+	"CURRVAL":                {ScalarFunction, 1, 1},
+	"DATE_PART":              {ScalarFunction, 2, 2},
+	"DATE_TRUNC":             {ScalarFunction, 2, 3},
+	"DENSE_RANK":             {WindowFunction, 0, 0},
+	"EVERY":                  {AggregateFunction, 1, 1},
+	"EXP":                    {ScalarFunction, 1, 1},
+	"FIRST_VALUE":            {WindowFunction, 1, 1},
+	"FLOOR":                  {ScalarFunction, 1, 1},
+	"FORMAT":                 {ScalarFunction, 1, VariadicArgs},
+	"GENERATE_SERIES":        {ScalarFunction, 2, 3},
+	"GREATEST":               {ScalarFunction, 1, VariadicArgs},
+	"INITCAP":                {ScalarFunction, 1, 1},
+	"JSONB_AGG":              {AggregateFunction, 1, 1},
+	"JSONB_BUILD_OBJECT":     {ScalarFunction, 0, VariadicArgs},
+	"JSONB_EXTRACT_PATH":     {ScalarFunction, 2, VariadicArgs},
+	"JSONB_SET":              {ScalarFunction, 3, 4},
+	"JSON_AGG":               {AggregateFunction, 1, 1},
+	"JSON_BUILD_ARRAY":       {ScalarFunction, 0, VariadicArgs},
+	"JSON_BUILD_OBJECT":      {ScalarFunction, 0, VariadicArgs},
+	"JSON_EXTRACT_PATH":      {ScalarFunction, 2, VariadicArgs},
+	"JSON_EXTRACT_PATH_TEXT": {ScalarFunction, 2, VariadicArgs},
+	"JSON_OBJECT_AGG":        {AggregateFunction, 2, 2},
+	"LAG":                    {WindowFunction, 1, 3},
+	"LAST_VALUE":             {WindowFunction, 1, 1},
+	"LEAD":                   {WindowFunction, 1, 3},
+	"LEAST":                  {ScalarFunction, 1, VariadicArgs},
+	"LEFT":                   {ScalarFunction, 2, 2},
+	"LENGTH":                 {ScalarFunction, 1, 2},
+	"LN":                     {ScalarFunction, 1, 1},
+	"LOWER":                  {ScalarFunction, 1, 1},
+	"LPAD":                   {ScalarFunction, 2, 3},
+	"LTRIM":                  {ScalarFunction, 1, 2},
+	"MAX":                    {AggregateFunction, 1, 1},
+	"MD5":                    {ScalarFunction, 1, 1},
+	"MIN":                    {AggregateFunction, 1, 1},
+	"MOD":                    {ScalarFunction, 2, 2},
+	"NEXTVAL":                {ScalarFunction, 1, 1},
+	"NOW":                    {ScalarFunction, 0, 0},
+	"NTH_VALUE":              {WindowFunction, 2, 2},
+	"NTILE":                  {WindowFunction, 1, 1},
+	"NULLIF":                 {ScalarFunction, 2, 2},
+	"PERCENT_RANK":           {WindowFunction, 0, 0},
+	"POWER":                  {ScalarFunction, 2, 2},
+	"RANDOM":                 {ScalarFunction, 0, 0},
+	"RANK":                   {WindowFunction, 0, 0},
+	"REGEXP_MATCHES":         {ScalarFunction, 2, 3},
+	"REGEXP_REPLACE":         {ScalarFunction, 3, 6},
+	"REPLACE":                {ScalarFunction, 3, 3},
+	"RIGHT":                  {ScalarFunction, 2, 2},
+	"ROUND":                  {ScalarFunction, 1, 2},
+	"ROW_NUMBER":             {WindowFunction, 0, 0},
+	"RPAD":                   {ScalarFunction, 2, 3},
+	"RTRIM":                  {ScalarFunction, 1, 2},
+	"SETVAL":                 {ScalarFunction, 2, 3},
+	"SPLIT_PART":             {ScalarFunction, 3, 3},
+	"SQRT":                   {ScalarFunction, 1, 1},
+	"STDDEV":                 {AggregateFunction, 1, 1},
+	"STRING_AGG":             {AggregateFunction, 2, 2},
+	"STRPOS":                 {ScalarFunction, 2, 2},
+	"SUBSTR":                 {ScalarFunction, 2, 3},
+	"SUBSTRING":              {ScalarFunction, 2, 3},
+	"SUM":                    {AggregateFunction, 1, 1},
+	"TO_CHAR":                {ScalarFunction, 2, 2},
+	"TO_DATE":                {ScalarFunction, 2, 2},
+	"TO_NUMBER":              {ScalarFunction, 2, 2},
+	"TO_TIMESTAMP":           {ScalarFunction, 1, 2},
+	"UNNEST":                 {ScalarFunction, 1, VariadicArgs},
+	"UPPER":                  {ScalarFunction, 1, 1},
+	"VARIANCE":               {AggregateFunction, 1, 1},
+}
+
 // IsPostgreSQLKeyword returns a boolean indicating if the supplied string
 // is considered to be a keyword in PostgreSQL
 func IsPostgreSQLKeyword(s string) bool {
@@ -514,6 +770,30 @@ func IsPostgreSQLOperator(s string) bool {
 	return ok
 }
 
+// PostgreSQLOperatorPrecedence returns the precedence and associativity of
+// the supplied operator in PostgreSQL
+func PostgreSQLOperatorPrecedence(s string, fixity int) (op Operator, ok bool) {
+	return pgOperatorPrecedence.lookup(s, fixity)
+}
+
+// PostgreSQLDataType returns the catalog entry for the supplied data type in
+// PostgreSQL
+func PostgreSQLDataType(s string) (t TypeInfo, ok bool) {
+	return pgDataTypes.lookup(s)
+}
+
+// PostgreSQLTypeName returns the name of the PostgreSQL data type to use for
+// the supplied generic data type
+func PostgreSQLTypeName(generic string) (s string, ok bool) {
+	return pgDataTypes.typeName(generic)
+}
+
+// PostgreSQLFunction returns the catalog entry for the supplied built-in function
+// in PostgreSQL
+func PostgreSQLFunction(s string) (f FunctionInfo, ok bool) {
+	return pgFunctions.lookup(s)
+}
+
 // IsPostgreSQLLabel returns a boolean indicating if the supplied string
 // is considered to be a label in PostgreSQL
 func IsPostgreSQLLabel(s string) bool {
@@ -192,25 +192,196 @@ var sqliteKeywords = map[string]bool{
 }
 
 var sqliteOperators = map[string]bool{
-	"~":  true,
-	"<":  true,
-	"<<": true,
-	"<=": true,
-	"<>": true,
-	"=":  true,
-	"==": true,
-	">":  true,
-	">=": true,
-	">>": true,
-	"|":  true,
-	"||": true,
-	"-":  true,
-	"!=": true,
-	"/":  true,
-	"*":  true,
-	"&":  true,
-	"%":  true,
-	"+":  true,
+	"->":  true,
+	"->>": true,
+	"~":   true,
+	"<":   true,
+	"<<":  true,
+	"<=":  true,
+	"<>":  true,
+	"=":   true,
+	"==":  true,
+	">":   true,
+	">=":  true,
+	">>":  true,
+	"|":   true,
+	"||":  true,
+	"-":   true,
+	"!=":  true,
+	"/":   true,
+	"*":   true,
+	"&":   true,
+	"%":   true,
+	"+":   true,
+}
+
+// the infix, prefix, and postfix SQLite operators and their precedence
+var sqliteOperatorPrecedence = operatorTable{
+	infix: map[string]Operator{
+		"OR":      {1, LeftAssociative},
+		"AND":     {2, LeftAssociative},
+		"=":       {4, NonAssociative},
+		"==":      {4, NonAssociative},
+		"<>":      {4, NonAssociative},
+		"!=":      {4, NonAssociative},
+		"IS":      {4, NonAssociative},
+		"IN":      {4, NonAssociative},
+		"LIKE":    {4, NonAssociative},
+		"GLOB":    {4, NonAssociative},
+		"MATCH":   {4, NonAssociative},
+		"REGEXP":  {4, NonAssociative},
+		"BETWEEN": {4, NonAssociative},
+		"<":       {5, NonAssociative},
+		">":       {5, NonAssociative},
+		"<=":      {5, NonAssociative},
+		">=":      {5, NonAssociative},
+		"&":       {6, LeftAssociative},
+		"|":       {6, LeftAssociative},
+		"<<":      {6, LeftAssociative},
+		">>":      {6, LeftAssociative},
+		"+":       {7, LeftAssociative},
+		"-":       {7, LeftAssociative},
+		"*":       {8, LeftAssociative},
+		"/":       {8, LeftAssociative},
+		"%":       {8, LeftAssociative},
+		"||":      {9, LeftAssociative},
+		"->":      {9, LeftAssociative},
+		"->>":     {9, LeftAssociative},
+		"COLLATE": {10, LeftAssociative},
+	},
+	prefix: map[string]Operator{
+		"NOT": {3, RightAssociative},
+		"~":   {11, RightAssociative},
+		"+":   {11, RightAssociative},
+		"-":   {11, RightAssociative},
+	},
+	postfix: map[string]Operator{
+		"ISNULL":  {4, NonAssociative},
+		"NOTNULL": {4, NonAssociative},
+	},
+}
+
+// the SQLite data types
+var sqliteDataTypes = typeCatalog{
+	types: map[string]TypeInfo{
+		"ANY":               {OtherDataType, ""},
+		"BIGINT":            {NumericDataType, "BIGINT"},
+		"BLOB":              {BinaryDataType, "BLOB"},
+		"BOOLEAN":           {BooleanDataType, "BOOLEAN"},
+		"CHARACTER":         {StringDataType, "CHAR"},
+		"CLOB":              {StringDataType, "TEXT"},
+		"DATE":              {DateTimeDataType, "DATE"},
+		"DATETIME":          {DateTimeDataType, "TIMESTAMP"},
+		"DECIMAL":           {NumericDataType, "DECIMAL"},
+		"DOUBLE":            {NumericDataType, "DOUBLE"},
+		"DOUBLE PRECISION":  {NumericDataType, "DOUBLE"},
+		"FLOAT":             {NumericDataType, "DOUBLE"},
+		"INT":               {NumericDataType, "INTEGER"},
+		"INTEGER":           {NumericDataType, "INTEGER"},
+		"MEDIUMINT":         {NumericDataType, "INTEGER"},
+		"NATIVE CHARACTER":  {StringDataType, "NCHAR"},
+		"NCHAR":             {StringDataType, "NCHAR"},
+		"NUMERIC":           {NumericDataType, "DECIMAL"},
+		"NVARCHAR":          {StringDataType, "NVARCHAR"},
+		"REAL":              {NumericDataType, "DOUBLE"},
+		"SMALLINT":          {NumericDataType, "SMALLINT"},
+		"TEXT":              {StringDataType, "TEXT"},
+		"TINYINT":           {NumericDataType, "TINYINT"},
+		"VARCHAR":           {StringDataType, "VARCHAR"},
+		"VARYING CHARACTER": {StringDataType, "VARCHAR"},
+	},
+	names: map[string]string{
+		"BIGINT":      "INTEGER",
+		"BINARY":      "BLOB",
+		"BLOB":        "BLOB",
+		"BOOLEAN":     "INTEGER",
+		"CHAR":        "TEXT",
+		"DATE":        "TEXT",
+		"DECIMAL":     "NUMERIC",
+		"DOUBLE":      "REAL",
+		"INTEGER":     "INTEGER",
+		"INTERVAL":    "TEXT",
+		"JSON":        "TEXT",
+		"NCHAR":       "TEXT",
+		"NVARCHAR":    "TEXT",
+		"REAL":        "REAL",
+		"SMALLINT":    "INTEGER",
+		"TEXT":        "TEXT",
+		"TIME":        "TEXT",
+		"TIMESTAMP":   "TEXT",
+		"TIMESTAMPTZ": "TEXT",
+		"TINYINT":     "INTEGER",
+		"UUID":        "TEXT",
+		"VARBINARY":   "BLOB",
+		"VARCHAR":     "TEXT",
+		"XML":         "TEXT",
+	},
+}
+
+// the SQLite built-in functions
+var sqliteFunctions = functionCatalog{
+	"ABS":               {ScalarFunction, 1, 1},
+	"AVG":               {AggregateFunction, 1, 1},
+	"CEIL":              {ScalarFunction, 1, 1},
+	"CEILING":           {ScalarFunction, 1, 1},
+	"CHANGES":           {ScalarFunction, 0, 0},
+	"COALESCE":          {ScalarFunction, 1, VariadicArgs},
+	"COUNT":             {AggregateFunction, 1, 1},
+	"CUME_DIST":         {WindowFunction, 0, 0},
+	"DATE":              {ScalarFunction, 0, VariadicArgs},
+	"DATETIME":          {ScalarFunction, 0, VariadicArgs},
+	"DENSE_RANK":        {WindowFunction, 0, 0},
+	"EXP":               {ScalarFunction, 1, 1},
+	"FIRST_VALUE":       {WindowFunction, 1, 1},
+	"FLOOR":             {ScalarFunction, 1, 1},
+	"FORMAT":            {ScalarFunction, 1, VariadicArgs},
+	"GROUP_CONCAT":      {AggregateFunction, 1, 2},
+	"HEX":               {ScalarFunction, 1, 1},
+	"IFNULL":            {ScalarFunction, 2, 2},
+	"IIF":               {ScalarFunction, 3, 3},
+	"INSTR":             {ScalarFunction, 2, 2},
+	"JSON":              {ScalarFunction, 1, 1},
+	"JSON_ARRAY":        {ScalarFunction, 0, VariadicArgs},
+	"JSON_EXTRACT":      {ScalarFunction, 2, VariadicArgs},
+	"JSON_GROUP_ARRAY":  {AggregateFunction, 1, 1},
+	"JSON_GROUP_OBJECT": {AggregateFunction, 2, 2},
+	"JSON_OBJECT":       {ScalarFunction, 0, VariadicArgs},
+	"JULIANDAY":         {ScalarFunction, 1, VariadicArgs},
+	"LAG":               {WindowFunction, 1, 3},
+	"LAST_INSERT_ROWID": {ScalarFunction, 0, 0},
+	"LAST_VALUE":        {WindowFunction, 1, 1},
+	"LEAD":              {WindowFunction, 1, 3},
+	"LENGTH":            {ScalarFunction, 1, 1},
+	"LN":                {ScalarFunction, 1, 1},
+	"LOWER":             {ScalarFunction, 1, 1},
+	"LTRIM":             {ScalarFunction, 1, 2},
+	"MAX":               {AggregateFunction, 1, VariadicArgs},
+	"MIN":               {AggregateFunction, 1, VariadicArgs},
+	"MOD":               {ScalarFunction, 2, 2},
+	"NTH_VALUE":         {WindowFunction, 2, 2},
+	"NTILE":             {WindowFunction, 1, 1},
+	"NULLIF":            {ScalarFunction, 2, 2},
+	"PERCENT_RANK":      {WindowFunction, 0, 0},
+	"POWER":             {ScalarFunction, 2, 2},
+	"PRINTF":            {ScalarFunction, 1, VariadicArgs},
+	"QUOTE":             {ScalarFunction, 1, 1},
+	"RANDOM":            {ScalarFunction, 0, 0},
+	"RANK":              {WindowFunction, 0, 0},
+	"REPLACE":           {ScalarFunction, 3, 3},
+	"ROUND":             {ScalarFunction, 1, 2},
+	"ROW_NUMBER":        {WindowFunction, 0, 0},
+	"RTRIM":             {ScalarFunction, 1, 2},
+	"SQRT":              {ScalarFunction, 1, 1},
+	"STRFTIME":          {ScalarFunction, 1, VariadicArgs},
+	"SUBSTR":            {ScalarFunction, 2, 3},
+	"SUBSTRING":         {ScalarFunction, 2, 3},
+	"SUM":               {AggregateFunction, 1, 1},
+	"TIME":              {ScalarFunction, 0, VariadicArgs},
+	"TOTAL":             {AggregateFunction, 1, 1},
+	"TRIM":              {ScalarFunction, 1, 2},
+	"TYPEOF":            {ScalarFunction, 1, 1},
+	"UNIXEPOCH":         {ScalarFunction, 0, VariadicArgs},
+	"UPPER":             {ScalarFunction, 1, 1},
 }
 
 // IsSQLiteKeyword returns a boolean indicating if the supplied string
@@ -239,6 +410,30 @@ func IsSQLiteOperator(s string) bool {
 	return ok
 }
 
+// SQLiteOperatorPrecedence returns the precedence and associativity of
+// the supplied operator in SQLite
+func SQLiteOperatorPrecedence(s string, fixity int) (op Operator, ok bool) {
+	return sqliteOperatorPrecedence.lookup(s, fixity)
+}
+
+// SQLiteDataType returns the catalog entry for the supplied data type in
+// SQLite
+func SQLiteDataType(s string) (t TypeInfo, ok bool) {
+	return sqliteDataTypes.lookup(s)
+}
+
+// SQLiteTypeName returns the name of the SQLite data type to use for
+// the supplied generic data type
+func SQLiteTypeName(generic string) (s string, ok bool) {
+	return sqliteDataTypes.typeName(generic)
+}
+
+// SQLiteFunction returns the catalog entry for the supplied built-in function
+// in SQLite
+func SQLiteFunction(s string) (f FunctionInfo, ok bool) {
+	return sqliteFunctions.lookup(s)
+}
+
 // IsSQLiteLabel returns a boolean indicating if the supplied string
 // is considered to be a label in SQLite
 func IsSQLiteLabel(s string) bool {
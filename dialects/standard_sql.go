@@ -726,6 +726,168 @@ var sqlStandardOperators = map[string]bool{
 	"!>": true,
 }
 
+// the infix, prefix, and postfix Standard operators and their precedence
+var sqlStandardOperatorPrecedence = operatorTable{
+	infix: map[string]Operator{
+		"OR":      {1, LeftAssociative},
+		"AND":     {2, LeftAssociative},
+		"IS":      {4, NonAssociative},
+		"=":       {5, NonAssociative},
+		"!=":      {5, NonAssociative},
+		"<>":      {5, NonAssociative},
+		">":       {5, NonAssociative},
+		"<":       {5, NonAssociative},
+		">=":      {5, NonAssociative},
+		"<=":      {5, NonAssociative},
+		"!<":      {5, NonAssociative},
+		"!>":      {5, NonAssociative},
+		"BETWEEN": {6, NonAssociative},
+		"IN":      {6, NonAssociative},
+		"LIKE":    {6, NonAssociative},
+		"SIMILAR": {6, NonAssociative},
+		"+":       {7, LeftAssociative},
+		"-":       {7, LeftAssociative},
+		"*":       {8, LeftAssociative},
+		"/":       {8, LeftAssociative},
+		"%":       {8, LeftAssociative},
+		"COLLATE": {10, LeftAssociative},
+	},
+	prefix: map[string]Operator{
+		"NOT": {3, RightAssociative},
+		"+":   {9, RightAssociative},
+		"-":   {9, RightAssociative},
+	},
+	postfix: map[string]Operator{},
+}
+
+// the Standard data types
+var sqlStandardDataTypes = typeCatalog{
+	types: map[string]TypeInfo{
+		"BIGINT":                      {NumericDataType, "BIGINT"},
+		"BINARY":                      {BinaryDataType, "BINARY"},
+		"BINARY LARGE OBJECT":         {BinaryDataType, "BLOB"},
+		"BINARY VARYING":              {BinaryDataType, "VARBINARY"},
+		"BLOB":                        {BinaryDataType, "BLOB"},
+		"BOOLEAN":                     {BooleanDataType, "BOOLEAN"},
+		"CHAR":                        {StringDataType, "CHAR"},
+		"CHAR VARYING":                {StringDataType, "VARCHAR"},
+		"CHARACTER":                   {StringDataType, "CHAR"},
+		"CHARACTER LARGE OBJECT":      {StringDataType, "TEXT"},
+		"CHARACTER VARYING":           {StringDataType, "VARCHAR"},
+		"CLOB":                        {StringDataType, "TEXT"},
+		"DATE":                        {DateTimeDataType, "DATE"},
+		"DEC":                         {NumericDataType, "DECIMAL"},
+		"DECFLOAT":                    {NumericDataType, "DOUBLE"},
+		"DECIMAL":                     {NumericDataType, "DECIMAL"},
+		"DOUBLE PRECISION":            {NumericDataType, "DOUBLE"},
+		"FLOAT":                       {NumericDataType, "DOUBLE"},
+		"INT":                         {NumericDataType, "INTEGER"},
+		"INTEGER":                     {NumericDataType, "INTEGER"},
+		"INTERVAL":                    {DateTimeDataType, "INTERVAL"},
+		"JSON":                        {DocumentDataType, "JSON"},
+		"NATIONAL CHAR":               {StringDataType, "NCHAR"},
+		"NATIONAL CHARACTER":          {StringDataType, "NCHAR"},
+		"NATIONAL CHARACTER VARYING":  {StringDataType, "NVARCHAR"},
+		"NCHAR":                       {StringDataType, "NCHAR"},
+		"NCHAR VARYING":               {StringDataType, "NVARCHAR"},
+		"NCLOB":                       {StringDataType, "TEXT"},
+		"NUMERIC":                     {NumericDataType, "DECIMAL"},
+		"REAL":                        {NumericDataType, "REAL"},
+		"SMALLINT":                    {NumericDataType, "SMALLINT"},
+		"TIME":                        {DateTimeDataType, "TIME"},
+		"TIME WITH TIME ZONE":         {DateTimeDataType, "TIME"},
+		"TIME WITHOUT TIME ZONE":      {DateTimeDataType, "TIME"},
+		"TIMESTAMP":                   {DateTimeDataType, "TIMESTAMP"},
+		"TIMESTAMP WITH TIME ZONE":    {DateTimeDataType, "TIMESTAMPTZ"},
+		"TIMESTAMP WITHOUT TIME ZONE": {DateTimeDataType, "TIMESTAMP"},
+		"VARBINARY":                   {BinaryDataType, "VARBINARY"},
+		"VARCHAR":                     {StringDataType, "VARCHAR"},
+		"XML":                         {DocumentDataType, "XML"},
+	},
+	names: map[string]string{
+		"BIGINT":      "BIGINT",
+		"BINARY":      "BINARY",
+		"BLOB":        "BLOB",
+		"BOOLEAN":     "BOOLEAN",
+		"CHAR":        "CHAR",
+		"DATE":        "DATE",
+		"DECIMAL":     "DECIMAL",
+		"DOUBLE":      "DOUBLE PRECISION",
+		"INTEGER":     "INTEGER",
+		"INTERVAL":    "INTERVAL",
+		"JSON":        "JSON",
+		"NCHAR":       "NCHAR",
+		"NVARCHAR":    "NCHAR VARYING",
+		"REAL":        "REAL",
+		"SMALLINT":    "SMALLINT",
+		"TEXT":        "CLOB",
+		"TIME":        "TIME",
+		"TIMESTAMP":   "TIMESTAMP",
+		"TIMESTAMPTZ": "TIMESTAMP WITH TIME ZONE",
+		"TINYINT":     "SMALLINT",
+		"UUID":        "CHAR(36)",
+		"VARBINARY":   "VARBINARY",
+		"VARCHAR":     "VARCHAR",
+		"XML":         "XML",
+	},
+}
+
+// the SQL Standard built-in functions
+var sqlStandardFunctions = functionCatalog{
+	"ABS":              {ScalarFunction, 1, 1},
+	"ARRAY_AGG":        {AggregateFunction, 1, 1},
+	"AVG":              {AggregateFunction, 1, 1},
+	"CEIL":             {ScalarFunction, 1, 1},
+	"CEILING":          {ScalarFunction, 1, 1},
+	"CHARACTER_LENGTH": {ScalarFunction, 1, 1},
+	"CHAR_LENGTH":      {ScalarFunction, 1, 1},
+	"COALESCE":         {ScalarFunction, 1, VariadicArgs},
+	"COS":              {ScalarFunction, 1, 1},
+	"COUNT":            {AggregateFunction, 1, 1},
+	"CUME_DIST":        {WindowFunction, 0, 0},
+	"DENSE_RANK":       {WindowFunction, 0, 0},
+	"EVERY":            {AggregateFunction, 1, 1},
+	"EXP":              {ScalarFunction, 1, 1},
+	"FIRST_VALUE":      {WindowFunction, 1, 1},
+	"FLOOR":            {ScalarFunction, 1, 1},
+	"JSON_ARRAY":       {ScalarFunction, 0, VariadicArgs},
+	"JSON_ARRAYAGG":    {AggregateFunction, 1, 1},
+	"JSON_OBJECT":      {ScalarFunction, 0, VariadicArgs},
+	"JSON_OBJECTAGG":   {AggregateFunction, 2, 2},
+	"JSON_QUERY":       {ScalarFunction, 2, 2},
+	"JSON_VALUE":       {ScalarFunction, 2, 2},
+	"LAG":              {WindowFunction, 1, 3},
+	"LAST_VALUE":       {WindowFunction, 1, 1},
+	"LEAD":             {WindowFunction, 1, 3},
+	"LISTAGG":          {AggregateFunction, 1, 2},
+	"LN":               {ScalarFunction, 1, 1},
+	"LOG":              {ScalarFunction, 2, 2},
+	"LOG10":            {ScalarFunction, 1, 1},
+	"LOWER":            {ScalarFunction, 1, 1},
+	"MAX":              {AggregateFunction, 1, 1},
+	"MIN":              {AggregateFunction, 1, 1},
+	"MOD":              {ScalarFunction, 2, 2},
+	"NTH_VALUE":        {WindowFunction, 2, 2},
+	"NTILE":            {WindowFunction, 1, 1},
+	"NULLIF":           {ScalarFunction, 2, 2},
+	"OCTET_LENGTH":     {ScalarFunction, 1, 1},
+	"PERCENT_RANK":     {WindowFunction, 0, 0},
+	"POWER":            {ScalarFunction, 2, 2},
+	"RANK":             {WindowFunction, 0, 0},
+	"ROUND":            {ScalarFunction, 1, 2},
+	"ROW_NUMBER":       {WindowFunction, 0, 0},
+	"SIN":              {ScalarFunction, 1, 1},
+	"SQRT":             {ScalarFunction, 1, 1},
+	"STDDEV_POP":       {AggregateFunction, 1, 1},
+	"STDDEV_SAMP":      {AggregateFunction, 1, 1},
+	"SUBSTRING":        {ScalarFunction, 2, 3},
+	"SUM":              {AggregateFunction, 1, 1},
+	"TAN":              {ScalarFunction, 1, 1},
+	"UPPER":            {ScalarFunction, 1, 1},
+	"VAR_POP":          {AggregateFunction, 1, 1},
+	"VAR_SAMP":         {AggregateFunction, 1, 1},
+}
+
 // IsStandardKeyword returns a boolean indicating if the supplied string
 // is considered to be a keyword in ISO standared SQL
 func IsStandardKeyword(s string) bool {
@@ -752,6 +914,30 @@ func IsStandardOperator(s string) bool {
 	return ok
 }
 
+// StandardOperatorPrecedence returns the precedence and associativity of
+// the supplied operator in standard SQL
+func StandardOperatorPrecedence(s string, fixity int) (op Operator, ok bool) {
+	return sqlStandardOperatorPrecedence.lookup(s, fixity)
+}
+
+// StandardDataType returns the catalog entry for the supplied data type in
+// standard SQL
+func StandardDataType(s string) (t TypeInfo, ok bool) {
+	return sqlStandardDataTypes.lookup(s)
+}
+
+// StandardTypeName returns the name of the standard SQL data type to use for
+// the supplied generic data type
+func StandardTypeName(generic string) (s string, ok bool) {
+	return sqlStandardDataTypes.typeName(generic)
+}
+
+// StandardFunction returns the catalog entry for the supplied built-in function
+// in SQL Standard
+func StandardFunction(s string) (f FunctionInfo, ok bool) {
+	return sqlStandardFunctions.lookup(s)
+}
+
 // IsStandardLabel returns a boolean indicating if the supplied string
 // is considered to be a label in ISO standard SQL
 func IsStandardLabel(s string) bool {
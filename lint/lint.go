@@ -0,0 +1,252 @@
+package lint
+
+/*
+
+lint.go provides the rule engine for checking SQL against a set of
+(house) rules.
+
+*/
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/gsiems/sql-parse/sqlparse"
+)
+
+// Severities
+const (
+	// NullSeverity indicates an undefined severity, rules with a null
+	//  severity are disabled
+	NullSeverity = iota
+	// InfoSeverity is for stylistic issues
+	InfoSeverity
+	// WarningSeverity is for issues that are likely to cause problems
+	WarningSeverity
+	// ErrorSeverity is for issues that must be fixed
+	ErrorSeverity
+)
+
+// the comment directive for suppressing rules
+const ignoreDirective = "sqlparse:ignore"
+
+// Rule is implemented by all lint rules
+type Rule interface {
+	Name() string                   // the name of the rule (used for suppressing the rule)
+	Description() string            // a short description of the rule
+	Check(s *Statement) []Violation // checks the statement against the rule
+}
+
+// Statement provides a single statement to the rules
+type Statement struct {
+	Tokens  sqlparse.Tokens             // the tokens of the statement, including any preceding comments
+	Dialect int                         // the SQL dialect of the statement
+	Query   *sqlparse.SelectStatement   // the parsed query (nil if the statement is not a query or could not be parsed)
+	Queries []*sqlparse.SelectStatement // the queries that are embedded in a statement that is not a query (see sqlparse.EmbeddedQueries)
+}
+
+// AllQueries returns the parsed query of the statement or, if the
+// statement is not a query, the queries that are embedded in the
+// statement
+func (s *Statement) AllQueries() []*sqlparse.SelectStatement {
+	if s.Query != nil {
+		return []*sqlparse.SelectStatement{s.Query}
+	}
+	return s.Queries
+}
+
+// Violation provides a single violation of a rule as reported by the
+// rule
+type Violation struct {
+	Index int    // the index of the offending token in the statement token list
+	Msg   string // the description of the violation
+}
+
+// Diagnostic provides a violation of a rule, with the severity of the
+// rule and the position of the offending token
+type Diagnostic struct {
+	Rule     string // the name of the rule
+	Severity int    // the severity of the rule
+	Index    int    // the index of the offending token in the token list
+	Line     int    // the line number of the token
+	Col      int    // the column number of the token
+	Msg      string // the description of the violation
+}
+
+// Linter provides the rules, and the severities of those rules, to
+// check SQL against
+type Linter struct {
+	Dialect int // the SQL dialect of the SQL to check
+	rules   []Rule
+	levels  map[string]int
+}
+
+// SeverityName returns the string representation of the severity
+func SeverityName(severity int) (s string) {
+
+	var names = map[int]string{
+		NullSeverity:    "NullSeverity",
+		InfoSeverity:    "InfoSeverity",
+		WarningSeverity: "WarningSeverity",
+		ErrorSeverity:   "ErrorSeverity",
+	}
+
+	if s, ok := names[severity]; ok {
+		return s
+	}
+	return ""
+}
+
+// String returns the diagnostic as a message
+func (d Diagnostic) String() string {
+
+	var levels = map[int]string{
+		InfoSeverity:    "info",
+		WarningSeverity: "warning",
+		ErrorSeverity:   "error",
+	}
+
+	return fmt.Sprintf("line %d, column %d: %s: %s (%s)", d.Line, d.Col, levels[d.Severity], d.Msg, d.Rule)
+}
+
+// NewLinter creates a linter for the dialect with the built-in rules at
+// their default severities
+func NewLinter(dialect int) *Linter {
+
+	l := &Linter{
+		Dialect: dialect,
+		levels:  make(map[string]int),
+	}
+	for _, b := range builtinRules {
+		l.AddRule(b.rule, b.severity)
+	}
+	return l
+}
+
+// AddRule adds the rule to the linter with the severity. A rule with
+// the same name as an existing rule replaces that rule.
+func (l *Linter) AddRule(r Rule, severity int) {
+
+	for i, x := range l.rules {
+		if x.Name() == r.Name() {
+			l.rules[i] = r
+			l.levels[r.Name()] = severity
+			return
+		}
+	}
+	l.rules = append(l.rules, r)
+	l.levels[r.Name()] = severity
+}
+
+// SetSeverity sets the severity of the named rule, a NullSeverity
+// disables the rule. False is returned if there is no such rule.
+func (l *Linter) SetSeverity(name string, severity int) bool {
+	if _, ok := l.levels[name]; !ok {
+		return false
+	}
+	l.levels[name] = severity
+	return true
+}
+
+// Rules returns the rules of the linter
+func (l *Linter) Rules() []Rule {
+	return append([]Rule(nil), l.rules...)
+}
+
+// LintString checks the SQL against the rules of the linter
+func (l *Linter) LintString(sql string) []Diagnostic {
+	return l.Lint(sqlparse.ParseStatements(sql, l.Dialect))
+}
+
+// Lint checks each of the statements in the token list against the
+// rules of the linter. Rules may be suppressed for a statement by a
+// comment, within or preceding the statement, of the form
+// "-- sqlparse:ignore rule-name[, rule-name ...]" where no rule names
+// suppresses all of the rules. A comment following the end of a
+// statement on the same line applies to that statement.
+func (l *Linter) Lint(tl sqlparse.Tokens) (diags []Diagnostic) {
+
+	ranges := tl.StatementRanges(l.Dialect)
+	ignored := suppressions(&tl, ranges)
+
+	for k, r := range ranges {
+		s := &Statement{
+			Tokens:  tl.Slice(r.Start, r.End),
+			Dialect: l.Dialect,
+		}
+		if q, err := sqlparse.ParseSelect(s.Tokens, l.Dialect); err == nil {
+			s.Query = q
+		} else {
+			s.Queries = sqlparse.EmbeddedQueries(s.Tokens, l.Dialect)
+		}
+
+		var sd []Diagnostic
+		for _, rule := range l.rules {
+			name := rule.Name()
+			severity := l.levels[name]
+			if severity == NullSeverity || ignored[k][name] || ignored[k][""] {
+				continue
+			}
+			for _, v := range rule.Check(s) {
+				i := r.Start + v.Index
+				line, col := tl.Position(i)
+				sd = append(sd, Diagnostic{
+					Rule:     name,
+					Severity: severity,
+					Index:    i,
+					Line:     line,
+					Col:      col,
+					Msg:      v.Msg,
+				})
+			}
+		}
+		sort.SliceStable(sd, func(i, j int) bool { return sd[i].Index < sd[j].Index })
+		diags = append(diags, sd...)
+	}
+	return diags
+}
+
+// suppressions returns, for each of the statement ranges, the names of
+// the rules that are suppressed for the statement. An empty name
+// indicates that all rules are suppressed.
+func suppressions(tl *sqlparse.Tokens, ranges []sqlparse.StatementRange) []map[string]bool {
+
+	ignored := make([]map[string]bool, len(ranges))
+	for k := range ranges {
+		ignored[k] = make(map[string]bool)
+	}
+
+	for _, c := range tl.Comments() {
+		s := c.Text()
+		if !strings.HasPrefix(s, ignoreDirective) {
+			continue
+		}
+		s = s[len(ignoreDirective):]
+		if s != "" && s[0] != ' ' && s[0] != '\t' {
+			// sqlparse:ignored, sqlparse:ignore-file, ...
+			continue
+		}
+
+		// trailing comments apply to the token that they follow
+		i := c.Index
+		if c.Placement == sqlparse.TrailingComment {
+			i = c.Target
+		}
+		for k, r := range ranges {
+			if i < r.Start || i >= r.End {
+				continue
+			}
+			names := strings.FieldsFunc(s, func(r rune) bool {
+				return r == ',' || r == ' ' || r == '\t'
+			})
+			if len(names) == 0 {
+				ignored[k][""] = true
+			}
+			for _, name := range names {
+				ignored[k][name] = true
+			}
+		}
+	}
+	return ignored
+}
@@ -0,0 +1,162 @@
+package lint
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gsiems/sql-parse/sqlparse"
+)
+
+func TestLint(t *testing.T) {
+
+	cases := []struct {
+		name     string
+		dialect  int
+		sql      string
+		expected []string
+	}{
+		{
+			name:    "select star and cross join",
+			dialect: sqlparse.PostgreSQL,
+			sql:     "SELECT * FROM t, u WHERE t.a = u.a;",
+			expected: []string{
+				"line 1, column 8: warning: SELECT * is used, list the columns instead (select-star)",
+				"line 1, column 18: warning: implicit cross join, use an explicit JOIN (implicit-cross-join)",
+			},
+		},
+		{
+			name:    "exists subquery",
+			dialect: sqlparse.PostgreSQL,
+			sql:     "SELECT t.a FROM t WHERE EXISTS (SELECT * FROM u WHERE u.a = t.a);",
+		},
+		{
+			name:    "unqualified columns",
+			dialect: sqlparse.PostgreSQL,
+			sql: `SELECT a.x, y, CURRENT_DATE, EXTRACT(YEAR FROM a.d)
+  FROM a
+  JOIN b ON a.id = b.id
+ WHERE z = 1;`,
+			expected: []string{
+				"line 1, column 13: warning: column y is not qualified (qualify-columns)",
+				"line 4, column 8: warning: column z is not qualified (qualify-columns)",
+			},
+		},
+		{
+			name:    "join without condition",
+			dialect: sqlparse.MySQL,
+			sql:     "SELECT a.x FROM a JOIN b CROSS JOIN c;",
+			expected: []string{
+				"line 1, column 24: warning: JOIN without a join condition (implicit-cross-join)",
+			},
+		},
+		{
+			name:    "keyword case",
+			dialect: sqlparse.PostgreSQL,
+			sql:     "select a from t Where a = 1;",
+			expected: []string{
+				"line 1, column 1: info: keyword select is not upper case (keyword-case)",
+				"line 1, column 10: info: keyword from is not upper case (keyword-case)",
+				"line 1, column 17: info: keyword Where is not upper case (keyword-case)",
+			},
+		},
+		{
+			name:    "reserved identifiers",
+			dialect: sqlparse.PostgreSQL,
+			sql:     `SELECT "order", t.x AS "user" FROM t AS "group";`,
+			expected: []string{
+				"line 1, column 8: warning: reserved keyword order is used as an identifier (reserved-identifier)",
+				"line 1, column 24: warning: reserved keyword user is used as an identifier (reserved-identifier)",
+				"line 1, column 41: warning: reserved keyword group is used as an identifier (reserved-identifier)",
+			},
+		},
+		{
+			name:    "reserved identifiers MySQL",
+			dialect: sqlparse.MySQL,
+			sql:     "CREATE TABLE `table` (`key` INT, name VARCHAR(10), note TEXT DEFAULT \"select\");",
+			expected: []string{
+				"line 1, column 14: warning: reserved keyword table is used as an identifier (reserved-identifier)",
+				"line 1, column 23: warning: reserved keyword key is used as an identifier (reserved-identifier)",
+			},
+		},
+		{
+			name:    "embedded queries",
+			dialect: sqlparse.PostgreSQL,
+			sql: `UPDATE t SET a = 1 WHERE y IN (SELECT * FROM u);
+INSERT INTO t SELECT * FROM u, v;
+CREATE VIEW w AS SELECT a FROM u JOIN v ON u.id = v.id;`,
+			expected: []string{
+				"line 1, column 39: warning: SELECT * is used, list the columns instead (select-star)",
+				"line 2, column 22: warning: SELECT * is used, list the columns instead (select-star)",
+				"line 2, column 32: warning: implicit cross join, use an explicit JOIN (implicit-cross-join)",
+				"line 3, column 25: warning: column a is not qualified (qualify-columns)",
+			},
+		},
+		{
+			name:    "suppression",
+			dialect: sqlparse.PostgreSQL,
+			sql: `-- sqlparse:ignore
+select * from t;
+select * from u; -- sqlparse:ignore select-star
+SELECT * FROM v, w /* sqlparse:ignore implicit-cross-join, select-star */;
+SELECT * FROM x;`,
+			expected: []string{
+				"line 3, column 1: info: keyword select is not upper case (keyword-case)",
+				"line 3, column 10: info: keyword from is not upper case (keyword-case)",
+				"line 5, column 8: warning: SELECT * is used, list the columns instead (select-star)",
+			},
+		},
+	}
+
+	for _, c := range cases {
+		var got []string
+		for _, d := range NewLinter(c.dialect).LintString(c.sql) {
+			got = append(got, d.String())
+		}
+		if strings.Join(got, "\n") != strings.Join(c.expected, "\n") {
+			t.Errorf("%s: expected:\n%s\ngot:\n%s", c.name, strings.Join(c.expected, "\n"), strings.Join(got, "\n"))
+		}
+	}
+}
+
+// noDeleteRule is a custom rule that reports DELETE statements
+type noDeleteRule struct{}
+
+func (noDeleteRule) Name() string        { return "no-delete" }
+func (noDeleteRule) Description() string { return "do not delete" }
+func (noDeleteRule) Check(s *Statement) (v []Violation) {
+	for i := 0; i < s.Tokens.Len(); i++ {
+		t := s.Tokens.At(i)
+		if strings.EqualFold(t.Value(), "DELETE") {
+			v = append(v, Violation{Index: i, Msg: "DELETE is not allowed"})
+		}
+	}
+	return v
+}
+
+func TestLinterRules(t *testing.T) {
+
+	l := NewLinter(sqlparse.PostgreSQL)
+	l.AddRule(noDeleteRule{}, ErrorSeverity)
+
+	if !l.SetSeverity("keyword-case", NullSeverity) {
+		t.Errorf("expected keyword-case to be a rule")
+	}
+	if l.SetSeverity("no-such-rule", InfoSeverity) {
+		t.Errorf("expected no-such-rule to not be a rule")
+	}
+	if n := len(l.Rules()); n != len(builtinRules)+1 {
+		t.Errorf("expected %d rules, got %d", len(builtinRules)+1, n)
+	}
+
+	diags := l.LintString("SELECT 1;\n\ndelete from t;")
+	if len(diags) != 1 {
+		t.Fatalf("expected 1 diagnostic, got %d: %v", len(diags), diags)
+	}
+	d := diags[0]
+	if d.Rule != "no-delete" || d.Severity != ErrorSeverity || d.Line != 3 || d.Col != 1 || d.Index != 3 {
+		t.Errorf("unexpected diagnostic %+v", d)
+	}
+	if SeverityName(d.Severity) != "ErrorSeverity" {
+		t.Errorf("expected ErrorSeverity, got %q", SeverityName(d.Severity))
+	}
+}
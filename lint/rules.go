@@ -0,0 +1,310 @@
+package lint
+
+/*
+
+rules.go provides the built-in lint rules.
+
+*/
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gsiems/sql-parse/sqlparse"
+)
+
+// the built-in rules and their default severities
+var builtinRules = []struct {
+	rule     Rule
+	severity int
+}{
+	{selectStarRule{}, WarningSeverity},
+	{qualifyColumnsRule{}, WarningSeverity},
+	{implicitCrossJoinRule{}, WarningSeverity},
+	{keywordCaseRule{}, InfoSeverity},
+	{reservedIdentifierRule{}, WarningSeverity},
+}
+
+// selectStarRule reports SELECT * (and SELECT t.*) other than in
+// EXISTS subqueries
+type selectStarRule struct{}
+
+// qualifyColumnsRule reports unqualified column references in queries
+// that select from more than one table
+type qualifyColumnsRule struct{}
+
+// implicitCrossJoinRule reports comma separated FROM lists and joins
+// that have no join condition
+type implicitCrossJoinRule struct{}
+
+// keywordCaseRule reports reserved keywords that are not upper case
+type keywordCaseRule struct{}
+
+// reservedIdentifierRule reports reserved keywords that are used as
+// identifiers, whether quoted or not
+type reservedIdentifierRule struct{}
+
+// visitFunc adapts a function to the sqlparse.Visitor interface, the
+// children of a node are visited when the function returns true
+type visitFunc func(n sqlparse.Node) bool
+
+// Visit implements the sqlparse.Visitor interface
+func (f visitFunc) Visit(n sqlparse.Node) sqlparse.Visitor {
+	if n == nil || !f(n) {
+		return nil
+	}
+	return f
+}
+
+// Name returns the name of the rule
+func (selectStarRule) Name() string { return "select-star" }
+
+// Description returns the description of the rule
+func (selectStarRule) Description() string { return "list the columns rather than using SELECT *" }
+
+// Check checks the statement against the rule
+func (selectStarRule) Check(s *Statement) (v []Violation) {
+
+	f := visitFunc(func(n sqlparse.Node) bool {
+		switch n := n.(type) {
+		case *sqlparse.SubqueryExpr:
+			// EXISTS (SELECT * ...) is fine
+			return n.Op != "EXISTS"
+		case *sqlparse.Select:
+			for _, c := range n.Columns {
+				if star, ok := c.Expr.(*sqlparse.StarExpr); ok {
+					name := "*"
+					if star.Table != "" {
+						name = star.Table + ".*"
+					}
+					v = append(v, Violation{Index: c.Pos(), Msg: fmt.Sprintf("SELECT %s is used, list the columns instead", name)})
+				}
+			}
+		}
+		return true
+	})
+	for _, q := range s.AllQueries() {
+		sqlparse.Walk(f, q)
+	}
+
+	return v
+}
+
+// Name returns the name of the rule
+func (qualifyColumnsRule) Name() string { return "qualify-columns" }
+
+// Description returns the description of the rule
+func (qualifyColumnsRule) Description() string {
+	return "qualify the column references in queries that select from more than one table"
+}
+
+// Check checks the statement against the rule
+func (qualifyColumnsRule) Check(s *Statement) (v []Violation) {
+
+	f := visitFunc(func(n sqlparse.Node) bool {
+		sel, ok := n.(*sqlparse.Select)
+		if !ok {
+			return true
+		}
+		count := 0
+		for _, f := range sel.From {
+			count += tableCount(f)
+		}
+		if count < 2 {
+			return true
+		}
+
+		var nodes []sqlparse.Node
+		for _, c := range sel.Columns {
+			nodes = append(nodes, c)
+		}
+		for _, f := range sel.From {
+			nodes = append(nodes, f)
+		}
+		for _, e := range append([]sqlparse.Expr{sel.Where, sel.Having}, sel.GroupBy...) {
+			if e != nil {
+				nodes = append(nodes, e)
+			}
+		}
+		for _, node := range nodes {
+			v = append(v, unqualifiedColumns(s, node)...)
+		}
+		return true
+	})
+	for _, q := range s.AllQueries() {
+		sqlparse.Walk(f, q)
+	}
+
+	return v
+}
+
+// tableCount returns the number of tables in the table expression
+func tableCount(t sqlparse.TableExpr) int {
+	switch t := t.(type) {
+	case *sqlparse.Join:
+		return tableCount(t.Left) + tableCount(t.Right)
+	case *sqlparse.ParenTable:
+		return tableCount(t.Table)
+	}
+	return 1
+}
+
+// unqualifiedColumns returns the unqualified column references within
+// the node, ignoring those that are in subqueries (as the subqueries
+// are checked on their own)
+func unqualifiedColumns(s *Statement, node sqlparse.Node) (v []Violation) {
+
+	var f visitFunc
+	f = func(n sqlparse.Node) bool {
+		switch n := n.(type) {
+		case *sqlparse.SelectStatement:
+			return false
+		case *sqlparse.FuncCall:
+			if strings.EqualFold(n.Name, "EXTRACT") && len(n.Args) > 0 {
+				// the field of EXTRACT(YEAR FROM d) is not a column
+				for _, a := range n.Args[1:] {
+					sqlparse.Walk(f, a)
+				}
+				return false
+			}
+		case *sqlparse.Ident:
+			if isColumnRef(s, n) {
+				v = append(v, Violation{Index: n.Pos(), Msg: fmt.Sprintf("column %s is not qualified", n.Name)})
+			}
+		}
+		return true
+	}
+	sqlparse.Walk(f, node)
+
+	return v
+}
+
+// isColumnRef determines whether or not the identifier is an
+// unqualified column reference rather than a qualified column, a
+// variable, or a niladic function (CURRENT_DATE)
+func isColumnRef(s *Statement, n *sqlparse.Ident) bool {
+
+	if strings.Contains(n.Name, ".") || strings.HasPrefix(n.Name, "@") || strings.HasPrefix(n.Name, ":") {
+		return false
+	}
+	t := s.Tokens.At(n.Pos())
+	return !(t.Type() == sqlparse.KeywordToken && sqlparse.IsReservedKeyword(n.Name, s.Dialect))
+}
+
+// Name returns the name of the rule
+func (implicitCrossJoinRule) Name() string { return "implicit-cross-join" }
+
+// Description returns the description of the rule
+func (implicitCrossJoinRule) Description() string {
+	return "use explicit joins, with join conditions, rather than comma separated tables"
+}
+
+// Check checks the statement against the rule
+func (implicitCrossJoinRule) Check(s *Statement) (v []Violation) {
+
+	f := visitFunc(func(n sqlparse.Node) bool {
+		switch n := n.(type) {
+		case *sqlparse.Select:
+			for k, f := range n.From {
+				if k > 0 {
+					v = append(v, Violation{Index: f.Pos(), Msg: "implicit cross join, use an explicit JOIN"})
+				}
+			}
+		case *sqlparse.Join:
+			w := strings.ToUpper(n.Type)
+			if n.On == nil && len(n.Using) == 0 && !strings.Contains(w, "CROSS") && !strings.Contains(w, "NATURAL") && !strings.Contains(w, "APPLY") {
+				v = append(v, Violation{Index: n.Right.Pos(), Msg: fmt.Sprintf("%s without a join condition", w)})
+			}
+		}
+		return true
+	})
+	for _, q := range s.AllQueries() {
+		sqlparse.Walk(f, q)
+	}
+
+	return v
+}
+
+// Name returns the name of the rule
+func (keywordCaseRule) Name() string { return "keyword-case" }
+
+// Description returns the description of the rule
+func (keywordCaseRule) Description() string { return "write reserved keywords in upper case" }
+
+// Check checks the statement against the rule
+func (keywordCaseRule) Check(s *Statement) (v []Violation) {
+
+	for i := 0; i < s.Tokens.Len(); i++ {
+		t := s.Tokens.At(i)
+		w := t.Value()
+		if t.Type() == sqlparse.KeywordToken && w != strings.ToUpper(w) && sqlparse.IsReservedKeyword(w, s.Dialect) {
+			v = append(v, Violation{Index: i, Msg: fmt.Sprintf("keyword %s is not upper case", w)})
+		}
+	}
+	return v
+}
+
+// Name returns the name of the rule
+func (reservedIdentifierRule) Name() string { return "reserved-identifier" }
+
+// Description returns the description of the rule
+func (reservedIdentifierRule) Description() string {
+	return "do not use reserved keywords as identifiers"
+}
+
+// Check checks the statement against the rule. Quoted identifiers are
+// checked for all statements while unquoted identifiers are only
+// checked for the aliases and qualified names of queries (as reserved
+// keywords can not otherwise be told apart from their use as keywords).
+func (reservedIdentifierRule) Check(s *Statement) (v []Violation) {
+
+	for i := 0; i < s.Tokens.Len(); i++ {
+		t := s.Tokens.At(i)
+		switch t.Type() {
+		case sqlparse.DoubleQuotedToken:
+			if s.Dialect == sqlparse.MySQL || s.Dialect == sqlparse.MariaDB {
+				// double quoted strings are string literals
+				continue
+			}
+		case sqlparse.BacktickQuotedToken, sqlparse.BracketQuotedToken:
+		default:
+			continue
+		}
+		if name := sqlparse.UnquoteIdent(t); sqlparse.IsReservedKeyword(name, s.Dialect) {
+			v = append(v, Violation{Index: i, Msg: fmt.Sprintf("reserved keyword %s is used as an identifier", name)})
+		}
+	}
+
+	// flag the reserved keyword at index i
+	check := func(i int) {
+		t := s.Tokens.At(i)
+		if t.Type() == sqlparse.KeywordToken && sqlparse.IsReservedKeyword(t.Value(), s.Dialect) {
+			v = append(v, Violation{Index: i, Msg: fmt.Sprintf("reserved keyword %s is used as an identifier", t.Value())})
+		}
+	}
+
+	f := visitFunc(func(n sqlparse.Node) bool {
+		switch n := n.(type) {
+		case *sqlparse.SelectItem:
+			if n.Alias != "" {
+				check(n.End() - 1)
+			}
+		case *sqlparse.TableName:
+			if n.Alias != "" {
+				check(n.End() - 1)
+			}
+		case *sqlparse.Ident:
+			if strings.Contains(n.Name, ".") {
+				for i := n.Pos(); i < n.End(); i++ {
+					check(i)
+				}
+			}
+		}
+		return true
+	})
+	for _, q := range s.AllQueries() {
+		sqlparse.Walk(f, q)
+	}
+
+	return v
+}
@@ -0,0 +1,347 @@
+package schemadiff
+
+/*
+
+diff.go provides the functionality for comparing two schemas and
+determining the changes that migrate the one to the other.
+
+*/
+
+import (
+	"strings"
+
+	"github.com/gsiems/sql-parse/sqlparse"
+)
+
+// Change actions
+const (
+	// NullAction indicates an undetermined action
+	NullAction = iota
+	// CreateAction indicates an object that was added
+	CreateAction
+	// DropAction indicates an object that was removed
+	DropAction
+	// AlterAction indicates an object whose definition was changed
+	AlterAction
+)
+
+// Object types
+const (
+	// NullObject indicates an undetermined object type
+	NullObject = iota
+	// TableObject is a table
+	TableObject
+	// ColumnObject is a column of a table
+	ColumnObject
+	// ConstraintObject is a table or column constraint
+	ConstraintObject
+	// IndexObject is an index
+	IndexObject
+)
+
+// Change provides a single difference between two schemas and the
+// statements that apply it
+type Change struct {
+	Action     int      // the kind of change
+	Object     int      // the type of object that was changed
+	Table      string   // the name of the table
+	Name       string   // the name of the column, constraint, or index
+	Statements []string // the statements (without terminators) that apply the change, comments ("-- ...") for changes that the dialect can not express
+}
+
+// ActionName returns the string representation of the change action
+func ActionName(action int) (s string) {
+
+	var names = map[int]string{
+		NullAction:   "NullAction",
+		CreateAction: "CreateAction",
+		DropAction:   "DropAction",
+		AlterAction:  "AlterAction",
+	}
+
+	if s, ok := names[action]; ok {
+		return s
+	}
+	return ""
+}
+
+// ObjectName returns the string representation of the object type
+func ObjectName(object int) (s string) {
+
+	var names = map[int]string{
+		NullObject:       "NullObject",
+		TableObject:      "TableObject",
+		ColumnObject:     "ColumnObject",
+		ConstraintObject: "ConstraintObject",
+		IndexObject:      "IndexObject",
+	}
+
+	if s, ok := names[object]; ok {
+		return s
+	}
+	return ""
+}
+
+// tableConstraint provides a constraint and the column, if any, that
+// the constraint was defined on
+type tableConstraint struct {
+	*sqlparse.Constraint
+	column string // the name of the column for column constraints
+}
+
+// Diff returns the changes that migrate the from schema to the to
+// schema. The statements are generated for the dialect of the to
+// schema and are ordered so that they may be applied in sequence:
+// constraints and indexes are dropped, tables are dropped and created,
+// columns are added, altered, and dropped, and then constraints and
+// indexes are added. Names are compared per the dialect of the to schema
+// (see sqlparse.CanonicalName).
+func Diff(from, to *Schema) (changes []Change) {
+
+	g := generator{dialect: to.Dialect}
+
+	fromTables := g.tableMap(from.Tables)
+	toTables := g.tableMap(to.Tables)
+	fromIndexes := g.indexMap(from.Indexes)
+	toIndexes := g.indexMap(to.Indexes)
+
+	var dropConstraints, addConstraints, dropIndexes, addIndexes []Change
+	var dropTables, addTables, columns []Change
+
+	for _, t := range from.Tables {
+		if _, ok := toTables[g.nameKey(t.Name)]; !ok {
+			dropTables = append(dropTables, g.dropTable(t))
+		}
+	}
+
+	for _, t := range to.Tables {
+		old, ok := fromTables[g.nameKey(t.Name)]
+		if !ok {
+			addTables = append(addTables, g.createTable(t))
+			continue
+		}
+
+		added := make(map[string]bool)
+		dropped := make(map[string]bool)
+		columns = append(columns, g.diffColumns(old, t, added, dropped)...)
+
+		oldCons := g.constraintMap(old.Table, dropped)
+		newCons := g.constraintMap(t.Table, added)
+		for _, c := range g.orderedConstraints(old.Table, dropped) {
+			if n, ok := newCons[g.constraintKey(c)]; !ok || g.constraintSignature(n) != g.constraintSignature(c) {
+				dropConstraints = append(dropConstraints, g.dropConstraint(t.Name, c))
+			}
+		}
+		for _, c := range g.orderedConstraints(t.Table, added) {
+			if o, ok := oldCons[g.constraintKey(c)]; !ok || g.constraintSignature(o) != g.constraintSignature(c) {
+				addConstraints = append(addConstraints, g.addConstraint(t.Name, c))
+			}
+		}
+	}
+
+	for _, idx := range from.Indexes {
+		if _, ok := toTables[g.nameKey(idx.Table)]; !ok {
+			// dropped with the table
+			continue
+		}
+		if n, ok := toIndexes[g.indexKey(idx)]; !ok || g.indexSignature(n) != g.indexSignature(idx) {
+			dropIndexes = append(dropIndexes, g.dropIndex(idx))
+		}
+	}
+	for _, idx := range to.Indexes {
+		if _, ok := fromTables[g.nameKey(idx.Table)]; !ok && idx.Inline {
+			// created with the table
+			continue
+		}
+		if o, ok := fromIndexes[g.indexKey(idx)]; !ok || g.indexSignature(o) != g.indexSignature(idx) {
+			addIndexes = append(addIndexes, g.createIndex(idx))
+		}
+	}
+
+	changes = append(changes, dropConstraints...)
+	changes = append(changes, dropIndexes...)
+	changes = append(changes, dropTables...)
+	changes = append(changes, addTables...)
+	changes = append(changes, columns...)
+	changes = append(changes, addConstraints...)
+	changes = append(changes, addIndexes...)
+	return changes
+}
+
+// Script returns the statements of the changes as a single script
+func Script(changes []Change) string {
+
+	var b strings.Builder
+	for _, c := range changes {
+		for _, s := range c.Statements {
+			b.WriteString(s)
+			if !strings.HasPrefix(s, "--") {
+				b.WriteString(";")
+			}
+			b.WriteString("\n")
+		}
+	}
+	return b.String()
+}
+
+// diffColumns returns the changes for the columns of a table that is in
+// both schemas. The names of the added and dropped columns are recorded
+// in the supplied maps.
+func (g generator) diffColumns(old, t *Table, added, dropped map[string]bool) (changes []Change) {
+
+	oldCols := make(map[string]*sqlparse.Column)
+	for _, c := range old.Columns {
+		oldCols[g.nameKey(c.Name)] = c
+	}
+	newCols := make(map[string]*sqlparse.Column)
+	for _, c := range t.Columns {
+		newCols[g.nameKey(c.Name)] = c
+	}
+
+	for _, c := range t.Columns {
+		o, ok := oldCols[g.nameKey(c.Name)]
+		switch {
+		case !ok:
+			added[g.nameKey(c.Name)] = true
+			changes = append(changes, g.addColumn(t.Name, c))
+		case columnSignature(o) != columnSignature(c):
+			changes = append(changes, g.alterColumn(t.Name, o, c))
+		}
+	}
+	for _, c := range old.Columns {
+		if _, ok := newCols[g.nameKey(c.Name)]; !ok {
+			dropped[g.nameKey(c.Name)] = true
+			changes = append(changes, g.dropColumn(t.Name, c))
+		}
+	}
+	return changes
+}
+
+// tableMap returns the tables keyed by name
+func (g generator) tableMap(tables []*Table) map[string]*Table {
+	m := make(map[string]*Table)
+	for _, t := range tables {
+		m[g.nameKey(t.Name)] = t
+	}
+	return m
+}
+
+// indexMap returns the indexes keyed by table and name
+func (g generator) indexMap(indexes []*Index) map[string]*Index {
+	m := make(map[string]*Index)
+	for _, idx := range indexes {
+		m[g.indexKey(idx)] = idx
+	}
+	return m
+}
+
+// indexKey returns the key for matching an index between schemas.
+// Unnamed indexes are matched by their columns.
+func (g generator) indexKey(idx *Index) string {
+	if idx.Name == "" {
+		return g.nameKey(idx.Table) + " (" + g.nameList(idx.Columns) + ")"
+	}
+	return g.nameKey(idx.Table) + " " + g.nameKey(idx.Name)
+}
+
+// indexSignature returns the definition of an index for comparison
+func (g generator) indexSignature(idx *Index) string {
+	s := g.nameList(idx.Columns)
+	if idx.Unique {
+		s = "UNIQUE " + s
+	}
+	return s
+}
+
+// orderedConstraints returns the table constraints and the column
+// constraints of a table. The constraints of the columns that are in
+// the skip map are excluded as those are added or dropped with the
+// column.
+func (g generator) orderedConstraints(t *sqlparse.Table, skip map[string]bool) (l []tableConstraint) {
+	for _, c := range t.Columns {
+		if skip[g.nameKey(c.Name)] {
+			continue
+		}
+		for _, con := range c.Constraints {
+			l = append(l, tableConstraint{Constraint: con, column: c.Name})
+		}
+	}
+	for _, con := range t.Constraints {
+		l = append(l, tableConstraint{Constraint: con})
+	}
+	return l
+}
+
+// constraintMap returns the constraints of a table keyed by
+// constraintKey
+func (g generator) constraintMap(t *sqlparse.Table, skip map[string]bool) map[string]tableConstraint {
+	m := make(map[string]tableConstraint)
+	for _, c := range g.orderedConstraints(t, skip) {
+		m[g.constraintKey(c)] = c
+	}
+	return m
+}
+
+// constraintKey returns the key for matching a constraint between
+// schemas. Named constraints are matched by name, unnamed constraints
+// by their definition.
+func (g generator) constraintKey(c tableConstraint) string {
+	if c.Name != "" {
+		return g.nameKey(c.Name)
+	}
+	return g.constraintSignature(c)
+}
+
+// constraintSignature returns the definition of a constraint for
+// comparison
+func (g generator) constraintSignature(c tableConstraint) string {
+	s := []string{
+		sqlparse.ConstraintTypeName(c.Type),
+		g.nameList(c.Columns),
+		normalizeText(c.Check),
+	}
+	if c.Type == sqlparse.ForeignKeyConstraint {
+		s = append(s, g.nameKey(c.RefTable), g.nameList(c.RefColumns), c.OnDelete, c.OnUpdate)
+	}
+	return strings.Join(s, "|")
+}
+
+// columnSignature returns the definition of a column, excluding its
+// constraints, for comparison
+func columnSignature(c *sqlparse.Column) string {
+	s := []string{
+		strings.ToUpper(normalizeText(c.Type)),
+		normalizeText(c.Default),
+		c.Identity,
+		normalizeText(c.Generated),
+		c.Collation,
+		c.CharacterSet,
+		c.OnUpdate,
+		c.Comment,
+	}
+	if c.NotNull {
+		s = append(s, "NOT NULL")
+	}
+	return strings.Join(s, "|")
+}
+
+// nameKey returns the key for comparing object names in the dialect of
+// the generator
+func (g generator) nameKey(name string) string {
+	return sqlparse.CanonicalObjectName(name, g.dialect)
+}
+
+// nameList returns the comparison keys of a list of names
+func (g generator) nameList(names []string) string {
+	var keys []string
+	for _, n := range names {
+		keys = append(keys, g.nameKey(n))
+	}
+	return strings.Join(keys, ",")
+}
+
+// normalizeText returns the text with the white space removed for
+// comparing types and expressions
+func normalizeText(s string) string {
+	return strings.Join(strings.Fields(s), "")
+}
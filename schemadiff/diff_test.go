@@ -0,0 +1,159 @@
+package schemadiff
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gsiems/sql-parse/sqlparse"
+)
+
+func TestDiff(t *testing.T) {
+
+	cases := []struct {
+		name     string
+		dialect  int
+		from     string
+		to       string
+		expected string
+	}{
+		{
+			name:    "PostgreSQL",
+			dialect: sqlparse.PostgreSQL,
+			from: `
+-- the original schema
+CREATE TABLE app.customers (
+    id integer PRIMARY KEY,
+    name varchar(50) NOT NULL,
+    fax text
+);
+CREATE TABLE app.legacy (id integer);
+CREATE INDEX customers_name_ix ON app.customers (name);
+CREATE VIEW app.v AS SELECT 1;`,
+			to: `
+CREATE TABLE app.customers (
+    id integer PRIMARY KEY,
+    name varchar(100),
+    email text NOT NULL DEFAULT '' UNIQUE,
+    status text DEFAULT 'new',
+    CONSTRAINT customers_name_ck CHECK (name <> '')
+);
+CREATE TABLE app.orders (
+    id bigserial PRIMARY KEY,
+    customer_id integer REFERENCES app.customers (id)
+);
+CREATE INDEX customers_name_ix ON app.customers (lower(name));`,
+			expected: `DROP INDEX app.customers_name_ix;
+DROP TABLE app.legacy;
+CREATE TABLE app.orders (
+    id bigserial PRIMARY KEY,
+    customer_id integer REFERENCES app.customers (id)
+);
+ALTER TABLE app.customers ALTER COLUMN name TYPE varchar(100);
+ALTER TABLE app.customers ALTER COLUMN name DROP NOT NULL;
+ALTER TABLE app.customers ADD COLUMN email text DEFAULT '' NOT NULL UNIQUE;
+ALTER TABLE app.customers ADD COLUMN status text DEFAULT 'new';
+ALTER TABLE app.customers DROP COLUMN fax;
+ALTER TABLE app.customers ADD CONSTRAINT customers_name_ck CHECK (name <> '');
+CREATE INDEX customers_name_ix ON app.customers (lower(name));
+`,
+		},
+		{
+			name:    "MySQL",
+			dialect: sqlparse.MySQL,
+			from: "CREATE TABLE `users` (\n" +
+				"  `id` int NOT NULL AUTO_INCREMENT,\n" +
+				"  `org_id` int,\n" +
+				"  `email` varchar(100),\n" +
+				"  PRIMARY KEY (`id`),\n" +
+				"  KEY `email_idx` (`email`),\n" +
+				"  CONSTRAINT `fk_org` FOREIGN KEY (`org_id`) REFERENCES `orgs` (`id`)\n" +
+				") ENGINE=InnoDB;",
+			to: "CREATE TABLE `users` (\n" +
+				"  `id` int NOT NULL AUTO_INCREMENT,\n" +
+				"  `org_id` int,\n" +
+				"  `email` varchar(255) NOT NULL,\n" +
+				"  PRIMARY KEY (`id`),\n" +
+				"  UNIQUE KEY `email_uq` (`email`),\n" +
+				"  CONSTRAINT `fk_org` FOREIGN KEY (`org_id`) REFERENCES `orgs` (`id`) ON DELETE CASCADE\n" +
+				") ENGINE=InnoDB;",
+			expected: "ALTER TABLE `users` DROP FOREIGN KEY `fk_org`;\n" +
+				"DROP INDEX `email_idx` ON `users`;\n" +
+				"ALTER TABLE `users` MODIFY COLUMN `email` varchar(255) NOT NULL;\n" +
+				"ALTER TABLE `users` ADD CONSTRAINT `email_uq` UNIQUE (`email`);\n" +
+				"ALTER TABLE `users` ADD CONSTRAINT `fk_org` FOREIGN KEY (`org_id`) REFERENCES `orgs` (`id`) ON DELETE CASCADE;\n",
+		},
+		{
+			name:     "MSSQL",
+			dialect:  sqlparse.MSSQL,
+			from:     "CREATE TABLE dbo.t (id int NOT NULL, amt money DEFAULT 0)\nGO\n",
+			to:       "CREATE TABLE dbo.t (id int NOT NULL, amt decimal(10,2) NOT NULL DEFAULT 1, note nvarchar(max))\nGO\n",
+			expected: "ALTER TABLE dbo.t ALTER COLUMN amt decimal(10, 2) NOT NULL;\n-- the default constraint on column amt of dbo.t must be dropped by name\nALTER TABLE dbo.t ADD DEFAULT 1 FOR amt;\nALTER TABLE dbo.t ADD note nvarchar(max);\n",
+		},
+		{
+			name:     "Oracle",
+			dialect:  sqlparse.Oracle,
+			from:     "CREATE TABLE emp (id NUMBER PRIMARY KEY, name VARCHAR2(50))\n/\n",
+			to:       "CREATE TABLE emp (id NUMBER, name VARCHAR2(50) NOT NULL, hired DATE DEFAULT SYSDATE)\n/\n",
+			expected: "ALTER TABLE emp DROP PRIMARY KEY;\nALTER TABLE emp MODIFY (name NOT NULL);\nALTER TABLE emp ADD (hired DATE DEFAULT SYSDATE);\n",
+		},
+		{
+			name:     "Oracle quoted names",
+			dialect:  sqlparse.Oracle,
+			from:     "CREATE TABLE \"EMP\" (\"ID\" NUMBER, Name VARCHAR2(50))\n/\n",
+			to:       "CREATE TABLE emp (id NUMBER, \"NAME\" VARCHAR2(50), hired DATE)\n/\n",
+			expected: "ALTER TABLE emp ADD (hired DATE);\n",
+		},
+		{
+			name:     "SQLite",
+			dialect:  sqlparse.SQLite,
+			from:     "CREATE TABLE kv (k TEXT PRIMARY KEY, v TEXT);",
+			to:       "CREATE TABLE kv (k TEXT PRIMARY KEY, v BLOB, n INTEGER);",
+			expected: "-- SQLite can not alter column v of kv, the table must be rebuilt\nALTER TABLE kv ADD COLUMN n INTEGER;\n",
+		},
+	}
+
+	for _, c := range cases {
+		from, err := ParseSchema(c.from, c.dialect)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", c.name, err)
+			continue
+		}
+		to, err := ParseSchema(c.to, c.dialect)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", c.name, err)
+			continue
+		}
+		if got := Script(Diff(from, to)); got != c.expected {
+			t.Errorf("%s:\n  expected\n%s\n  got\n%s", c.name, c.expected, got)
+		}
+	}
+}
+
+func TestDiffChanges(t *testing.T) {
+
+	from, _ := ParseSchema("CREATE TABLE t (a int, b int);", sqlparse.PostgreSQL)
+	to, _ := ParseSchema("CREATE TABLE t (a bigint, c int);", sqlparse.PostgreSQL)
+
+	var got []string
+	for _, c := range Diff(from, to) {
+		got = append(got, ActionName(c.Action)+" "+ObjectName(c.Object)+" "+c.Table+"."+c.Name)
+	}
+
+	expected := "AlterAction ColumnObject t.a, CreateAction ColumnObject t.c, DropAction ColumnObject t.b"
+	if strings.Join(got, ", ") != expected {
+		t.Errorf("expected %s, got %s", expected, strings.Join(got, ", "))
+	}
+}
+
+func TestParseSchemaErrors(t *testing.T) {
+
+	script := "CREATE TABLE a (id int);\n\nCREATE TABLE b (id int,);"
+	_, err := ParseSchema(script, sqlparse.PostgreSQL)
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	expected := `line 3, column 24: expected column name, found ")"`
+	if err.Error() != expected {
+		t.Errorf("expected %s, got %s", expected, err)
+	}
+}
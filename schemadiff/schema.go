@@ -0,0 +1,115 @@
+package schemadiff
+
+/*
+
+schema.go provides the functionality for reading the tables and indexes
+of a schema from a DDL script.
+
+*/
+
+import (
+	"strings"
+
+	"github.com/gsiems/sql-parse/sqlparse"
+)
+
+// Schema provides the tables and indexes that are defined by a DDL
+// script
+type Schema struct {
+	Dialect int      // the SQL dialect of the script
+	Tables  []*Table // the tables, in the order that they were defined
+	Indexes []*Index // the indexes, in the order that they were defined
+}
+
+// Table provides a table definition and the statement that defined it
+type Table struct {
+	*sqlparse.Table
+	SQL string // the CREATE TABLE statement, without comments or the terminating semi-colon
+}
+
+// Index provides an index definition
+type Index struct {
+	*sqlparse.Index
+	Inline bool // the index was defined within the CREATE TABLE statement (MySQL KEY, INDEX)
+}
+
+// ParseSchema reads the CREATE TABLE and CREATE INDEX statements of a
+// DDL script. All other statements are ignored. The positions of any
+// parse errors are relative to the start of the script.
+func ParseSchema(script string, dialect int) (s *Schema, err error) {
+
+	s = &Schema{Dialect: dialect}
+	tl := sqlparse.ParseStatements(script, dialect)
+
+	for _, r := range tl.StatementRanges(dialect) {
+		stmt := tl.Slice(r.Start, r.End)
+		c := sqlparse.Classify(stmt, dialect)
+		if c.Verb != "CREATE" {
+			continue
+		}
+
+		switch c.Object {
+		case "TABLE":
+			var t *sqlparse.Table
+			if t, err = sqlparse.ParseCreateTable(stmt, dialect); err != nil {
+				return nil, offsetError(err, &tl, r.Start)
+			}
+			s.Tables = append(s.Tables, &Table{Table: t, SQL: statementText(stmt, dialect)})
+			for _, idx := range t.Indexes {
+				s.Indexes = append(s.Indexes, &Index{Index: idx, Inline: true})
+			}
+
+		case "INDEX":
+			var idx *sqlparse.Index
+			if idx, err = sqlparse.ParseCreateIndex(stmt, dialect); err != nil {
+				return nil, offsetError(err, &tl, r.Start)
+			}
+			s.Indexes = append(s.Indexes, &Index{Index: idx})
+		}
+	}
+	return s, nil
+}
+
+// offsetError adjusts the position of a parse error for a statement so
+// that it is relative to the start of the script
+func offsetError(err error, tl *sqlparse.Tokens, start int) error {
+	if e, ok := err.(*sqlparse.ParseError); ok {
+		e.Index += start
+		e.Line, e.Col = tl.Position(e.Index)
+	}
+	return err
+}
+
+// statementText returns the text of a statement from the first to the
+// last significant token, excluding the statement terminator
+func statementText(stmt sqlparse.Tokens, dialect int) string {
+
+	var sig []int
+	for i := 0; i < stmt.Len(); i++ {
+		if sqlparse.SignificantTokens(stmt.At(i)) {
+			sig = append(sig, i)
+		}
+	}
+
+	for len(sig) > 0 {
+		t := stmt.At(sig[len(sig)-1])
+		v := t.Value()
+		if v != ";" && !(dialect == sqlparse.MSSQL && strings.EqualFold(v, "GO")) && !(dialect == sqlparse.Oracle && v == "/") {
+			break
+		}
+		sig = sig[:len(sig)-1]
+	}
+	if len(sig) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	for i := sig[0]; i <= sig[len(sig)-1]; i++ {
+		t := stmt.At(i)
+		if i > sig[0] {
+			b.WriteString(t.WhiteSpace())
+		}
+		b.WriteString(t.Value())
+	}
+	return b.String()
+}
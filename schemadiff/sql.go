@@ -0,0 +1,406 @@
+package schemadiff
+
+/*
+
+sql.go provides the generation of the dialect specific statements for
+the schema changes.
+
+*/
+
+import (
+	"strings"
+
+	"github.com/gsiems/sql-parse/sqlparse"
+)
+
+// generator generates the statements for a dialect
+type generator struct {
+	dialect int // the SQL dialect of the generated statements
+}
+
+// isMySQL indicates that the dialect is MySQL or MariaDB
+func (g generator) isMySQL() bool {
+	return g.dialect == sqlparse.MySQL || g.dialect == sqlparse.MariaDB
+}
+
+// createTable returns the change for a table that was added
+func (g generator) createTable(t *Table) Change {
+	return Change{
+		Action:     CreateAction,
+		Object:     TableObject,
+		Table:      t.Name,
+		Statements: []string{t.SQL},
+	}
+}
+
+// dropTable returns the change for a table that was removed
+func (g generator) dropTable(t *Table) Change {
+	return Change{
+		Action:     DropAction,
+		Object:     TableObject,
+		Table:      t.Name,
+		Statements: []string{"DROP TABLE " + t.Name},
+	}
+}
+
+// addColumn returns the change for a column that was added
+func (g generator) addColumn(table string, c *sqlparse.Column) Change {
+
+	def := g.columnDefinition(c, true)
+
+	var stmt string
+	switch {
+	case g.dialect == sqlparse.MSSQL:
+		stmt = "ALTER TABLE " + table + " ADD " + def
+	case g.dialect == sqlparse.Oracle:
+		stmt = "ALTER TABLE " + table + " ADD (" + def + ")"
+	default:
+		stmt = "ALTER TABLE " + table + " ADD COLUMN " + def
+	}
+
+	return Change{
+		Action:     CreateAction,
+		Object:     ColumnObject,
+		Table:      table,
+		Name:       c.Name,
+		Statements: []string{stmt},
+	}
+}
+
+// dropColumn returns the change for a column that was removed
+func (g generator) dropColumn(table string, c *sqlparse.Column) Change {
+	return Change{
+		Action:     DropAction,
+		Object:     ColumnObject,
+		Table:      table,
+		Name:       c.Name,
+		Statements: []string{"ALTER TABLE " + table + " DROP COLUMN " + c.Name},
+	}
+}
+
+// alterColumn returns the change for a column whose definition was
+// changed
+func (g generator) alterColumn(table string, old, c *sqlparse.Column) Change {
+
+	ch := Change{
+		Action: AlterAction,
+		Object: ColumnObject,
+		Table:  table,
+		Name:   c.Name,
+	}
+	add := func(s string) {
+		ch.Statements = append(ch.Statements, s)
+	}
+
+	typeChanged := strings.ToUpper(normalizeText(old.Type)) != strings.ToUpper(normalizeText(c.Type)) || old.Collation != c.Collation
+	defaultChanged := normalizeText(old.Default) != normalizeText(c.Default)
+	nullChanged := old.NotNull != c.NotNull
+	alter := "ALTER TABLE " + table + " ALTER COLUMN " + c.Name
+
+	switch {
+	case g.isMySQL():
+		// the complete definition replaces the existing one
+		add("ALTER TABLE " + table + " MODIFY COLUMN " + g.columnDefinition(c, false))
+		return ch
+
+	case g.dialect == sqlparse.SQLite:
+		add("-- SQLite can not alter column " + c.Name + " of " + table + ", the table must be rebuilt")
+		return ch
+
+	case g.dialect == sqlparse.MSSQL:
+		if typeChanged || nullChanged {
+			s := alter + " " + c.Type
+			if c.Collation != "" {
+				s += " COLLATE " + c.Collation
+			}
+			if c.NotNull {
+				s += " NOT NULL"
+			} else {
+				s += " NULL"
+			}
+			add(s)
+		}
+		if defaultChanged {
+			if old.Default != "" {
+				add("-- the default constraint on column " + c.Name + " of " + table + " must be dropped by name")
+			}
+			if c.Default != "" {
+				add("ALTER TABLE " + table + " ADD DEFAULT " + c.Default + " FOR " + c.Name)
+			}
+		}
+
+	case g.dialect == sqlparse.Oracle:
+		var s []string
+		if typeChanged {
+			s = append(s, c.Type)
+		}
+		if defaultChanged {
+			if c.Default == "" {
+				s = append(s, "DEFAULT NULL")
+			} else {
+				s = append(s, "DEFAULT "+c.Default)
+			}
+		}
+		if nullChanged {
+			if c.NotNull {
+				s = append(s, "NOT NULL")
+			} else {
+				s = append(s, "NULL")
+			}
+		}
+		if len(s) > 0 {
+			add("ALTER TABLE " + table + " MODIFY (" + c.Name + " " + strings.Join(s, " ") + ")")
+		}
+
+	default:
+		if typeChanged {
+			s := alter + " SET DATA TYPE " + c.Type
+			if g.dialect == sqlparse.PostgreSQL {
+				s = alter + " TYPE " + c.Type
+			}
+			if c.Collation != "" {
+				s += " COLLATE " + c.Collation
+			}
+			add(s)
+		}
+		if defaultChanged {
+			if c.Default == "" {
+				add(alter + " DROP DEFAULT")
+			} else {
+				add(alter + " SET DEFAULT " + c.Default)
+			}
+		}
+		if nullChanged {
+			if c.NotNull {
+				add(alter + " SET NOT NULL")
+			} else {
+				add(alter + " DROP NOT NULL")
+			}
+		}
+	}
+
+	if old.Identity != c.Identity || normalizeText(old.Generated) != normalizeText(c.Generated) {
+		add("-- the identity or generated expression of column " + c.Name + " of " + table + " was changed and is not migrated")
+	}
+	return ch
+}
+
+// addConstraint returns the change for a constraint that was added
+func (g generator) addConstraint(table string, c tableConstraint) Change {
+
+	ch := Change{
+		Action: CreateAction,
+		Object: ConstraintObject,
+		Table:  table,
+		Name:   c.Name,
+	}
+
+	if g.dialect == sqlparse.SQLite {
+		ch.Statements = []string{"-- SQLite can not add a constraint to " + table + ", the table must be rebuilt"}
+		return ch
+	}
+
+	ch.Statements = []string{"ALTER TABLE " + table + " ADD " + g.constraintDefinition(c.Constraint, false)}
+	return ch
+}
+
+// dropConstraint returns the change for a constraint that was removed
+func (g generator) dropConstraint(table string, c tableConstraint) Change {
+
+	ch := Change{
+		Action: DropAction,
+		Object: ConstraintObject,
+		Table:  table,
+		Name:   c.Name,
+	}
+	alter := "ALTER TABLE " + table + " DROP "
+
+	var stmt string
+	switch {
+	case g.dialect == sqlparse.SQLite:
+		stmt = "-- SQLite can not drop a constraint from " + table + ", the table must be rebuilt"
+	case g.isMySQL() && c.Type == sqlparse.PrimaryKeyConstraint:
+		stmt = alter + "PRIMARY KEY"
+	case g.dialect == sqlparse.Oracle && c.Type == sqlparse.PrimaryKeyConstraint && c.Name == "":
+		stmt = alter + "PRIMARY KEY"
+	case c.Name == "":
+		stmt = "-- the unnamed " + constraintWords(c.Type) + " constraint on " + table + " must be dropped by name"
+	case g.isMySQL() && c.Type == sqlparse.ForeignKeyConstraint:
+		stmt = alter + "FOREIGN KEY " + c.Name
+	case g.isMySQL() && c.Type == sqlparse.UniqueConstraint:
+		stmt = alter + "INDEX " + c.Name
+	case g.dialect == sqlparse.MySQL && c.Type == sqlparse.CheckConstraint:
+		stmt = alter + "CHECK " + c.Name
+	default:
+		stmt = alter + "CONSTRAINT " + c.Name
+	}
+
+	ch.Statements = []string{stmt}
+	return ch
+}
+
+// createIndex returns the change for an index that was added
+func (g generator) createIndex(idx *Index) Change {
+
+	var stmt string
+	switch {
+	case idx.Name == "" && g.isMySQL():
+		stmt = "ALTER TABLE " + idx.Table + " ADD " + indexWords(idx) + " (" + strings.Join(idx.Columns, ", ") + ")"
+	case idx.Name == "":
+		stmt = "-- the unnamed index on " + idx.Table + " (" + strings.Join(idx.Columns, ", ") + ") can not be created"
+	default:
+		stmt = "CREATE " + indexWords(idx) + " " + idx.Name + " ON " + idx.Table + " (" + strings.Join(idx.Columns, ", ") + ")"
+	}
+
+	return Change{
+		Action:     CreateAction,
+		Object:     IndexObject,
+		Table:      idx.Table,
+		Name:       idx.Name,
+		Statements: []string{stmt},
+	}
+}
+
+// dropIndex returns the change for an index that was removed
+func (g generator) dropIndex(idx *Index) Change {
+
+	var stmt string
+	switch {
+	case idx.Name == "":
+		stmt = "-- the unnamed index on " + idx.Table + " (" + strings.Join(idx.Columns, ", ") + ") must be dropped by name"
+	case g.isMySQL(), g.dialect == sqlparse.MSSQL:
+		stmt = "DROP INDEX " + idx.Name + " ON " + idx.Table
+	case g.dialect == sqlparse.PostgreSQL && !strings.Contains(idx.Name, ".") && strings.Contains(idx.Table, "."):
+		// indexes are created in the schema of the table
+		stmt = "DROP INDEX " + idx.Table[:strings.LastIndex(idx.Table, ".")+1] + idx.Name
+	default:
+		stmt = "DROP INDEX " + idx.Name
+	}
+
+	return Change{
+		Action:     DropAction,
+		Object:     IndexObject,
+		Table:      idx.Table,
+		Name:       idx.Name,
+		Statements: []string{stmt},
+	}
+}
+
+// columnDefinition returns the definition of a column. The column
+// constraints are included when withConstraints is set.
+func (g generator) columnDefinition(c *sqlparse.Column, withConstraints bool) string {
+
+	s := []string{c.Name}
+	if c.Type != "" {
+		s = append(s, c.Type)
+	}
+	if c.CharacterSet != "" {
+		s = append(s, "CHARACTER SET "+c.CharacterSet)
+	}
+	if c.Collation != "" {
+		s = append(s, "COLLATE "+c.Collation)
+	}
+
+	if c.Generated != "" {
+		switch {
+		case g.isMySQL(), g.dialect == sqlparse.MSSQL:
+			s = append(s, "AS ("+c.Generated+")")
+		case g.dialect == sqlparse.PostgreSQL:
+			s = append(s, "GENERATED ALWAYS AS ("+c.Generated+") STORED")
+		default:
+			s = append(s, "GENERATED ALWAYS AS ("+c.Generated+")")
+		}
+	}
+
+	switch c.Identity {
+	case "ALWAYS", "BY DEFAULT":
+		s = append(s, "GENERATED "+c.Identity+" AS IDENTITY")
+	case "AUTOINCREMENT", "SERIAL", "":
+		// SQLite AUTOINCREMENT follows PRIMARY KEY, serial types are
+		// identities on their own
+	default:
+		s = append(s, c.Identity)
+	}
+
+	if c.Default != "" {
+		s = append(s, "DEFAULT "+c.Default)
+	}
+	if c.NotNull {
+		s = append(s, "NOT NULL")
+	}
+	if c.OnUpdate != "" {
+		s = append(s, "ON UPDATE "+c.OnUpdate)
+	}
+	if c.Comment != "" {
+		s = append(s, "COMMENT "+c.Comment)
+	}
+
+	if withConstraints {
+		for _, con := range c.Constraints {
+			s = append(s, g.constraintDefinition(con, true))
+		}
+	}
+	if c.Identity == "AUTOINCREMENT" {
+		s = append(s, c.Identity)
+	}
+	return strings.Join(s, " ")
+}
+
+// constraintDefinition returns the definition of a constraint. Column
+// constraints omit the constrained columns.
+func (g generator) constraintDefinition(c *sqlparse.Constraint, column bool) string {
+
+	var s []string
+	if c.Name != "" {
+		s = append(s, "CONSTRAINT "+c.Name)
+	}
+
+	switch c.Type {
+	case sqlparse.PrimaryKeyConstraint, sqlparse.UniqueConstraint:
+		s = append(s, constraintWords(c.Type))
+		if !column {
+			s = append(s, "("+strings.Join(c.Columns, ", ")+")")
+		}
+	case sqlparse.CheckConstraint:
+		s = append(s, "CHECK ("+c.Check+")")
+	case sqlparse.ForeignKeyConstraint:
+		if !column {
+			s = append(s, "FOREIGN KEY ("+strings.Join(c.Columns, ", ")+")")
+		}
+		ref := "REFERENCES " + c.RefTable
+		if len(c.RefColumns) > 0 {
+			ref += " (" + strings.Join(c.RefColumns, ", ") + ")"
+		}
+		s = append(s, ref)
+		if c.OnDelete != "" {
+			s = append(s, "ON DELETE "+c.OnDelete)
+		}
+		if c.OnUpdate != "" {
+			s = append(s, "ON UPDATE "+c.OnUpdate)
+		}
+	}
+	return strings.Join(s, " ")
+}
+
+// constraintWords returns the keywords for a constraint type
+func constraintWords(constraintType int) string {
+	switch constraintType {
+	case sqlparse.PrimaryKeyConstraint:
+		return "PRIMARY KEY"
+	case sqlparse.ForeignKeyConstraint:
+		return "FOREIGN KEY"
+	case sqlparse.UniqueConstraint:
+		return "UNIQUE"
+	case sqlparse.CheckConstraint:
+		return "CHECK"
+	}
+	return ""
+}
+
+// indexWords returns the keywords for creating an index
+func indexWords(idx *Index) string {
+	if idx.Unique {
+		return "UNIQUE INDEX"
+	}
+	return "INDEX"
+}
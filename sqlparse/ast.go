@@ -0,0 +1,533 @@
+package sqlparse
+
+/*
+
+ast.go provides the syntax tree nodes for parsed statements and the
+functionality for walking the tree.
+
+*/
+
+// Node is implemented by all of the nodes in the syntax tree. The
+// positions of a node are the indexes of the tokens in the token list
+// that was parsed (see Tokens.Position for converting an index to a line
+// and column).
+type Node interface {
+	Pos() int // the index of the first token of the node
+	End() int // the index following the last token of the node
+}
+
+// Expr is implemented by all expression nodes
+type Expr interface {
+	Node
+	exprNode()
+}
+
+// QueryExpr is implemented by the nodes that produce a query result (a
+// SELECT, a set operation, or a parenthesized query)
+type QueryExpr interface {
+	Node
+	queryExprNode()
+}
+
+// TableExpr is implemented by the nodes that may appear in a FROM clause
+type TableExpr interface {
+	Node
+	tableExprNode()
+}
+
+// span provides the token positions for a node
+type span struct {
+	pos int
+	end int
+}
+
+// Pos returns the index of the first token of the node
+func (s span) Pos() int { return s.pos }
+
+// End returns the index following the last token of the node
+func (s span) End() int { return s.end }
+
+// SelectStatement is a complete query, with the optional WITH clause and
+// the ORDER BY and row limiting clauses that apply to the entire query
+type SelectStatement struct {
+	span
+	With    *WithClause  // the common table expressions (nil if there are none)
+	Body    QueryExpr    // the query
+	OrderBy []*OrderItem // the ORDER BY items
+	Limit   Expr         // the LIMIT, TOP or FETCH FIRST row count (nil if none)
+	Offset  Expr         // the OFFSET row count (nil if none)
+}
+
+// WithClause is the list of common table expressions for a query
+type WithClause struct {
+	span
+	Recursive bool
+	CTEs      []*CTE
+}
+
+// CTE is a single common table expression
+type CTE struct {
+	span
+	Name    string           // the name of the common table expression
+	Columns []string         // the column names, if specified
+	Query   *SelectStatement // the query that defines the common table expression
+}
+
+// Select is a single SELECT query block
+type Select struct {
+	span
+	Distinct   bool          // SELECT DISTINCT
+	DistinctOn []Expr        // the PostgreSQL DISTINCT ON expressions
+	Top        Expr          // the MSSQL TOP row count (nil if none)
+	Columns    []*SelectItem // the select list
+	Into       []string      // the SELECT ... INTO targets
+	From       []TableExpr   // the FROM list (joins are nested within the list items)
+	Where      Expr          // the WHERE condition (nil if none)
+	GroupBy    []Expr        // the GROUP BY expressions
+	Having     Expr          // the HAVING condition (nil if none)
+}
+
+// SelectItem is a single item in the select list
+type SelectItem struct {
+	span
+	Expr  Expr   // the selected expression
+	Alias string // the column alias, if any
+}
+
+// SetOperation is a UNION, INTERSECT, or EXCEPT (MINUS) of two queries
+type SetOperation struct {
+	span
+	Op    string    // UNION, INTERSECT, EXCEPT, or MINUS
+	All   bool      // the ALL modifier was specified
+	Left  QueryExpr // the left query
+	Right QueryExpr // the right query
+}
+
+// ParenQuery is a parenthesized query
+type ParenQuery struct {
+	span
+	Query *SelectStatement
+}
+
+// OrderItem is a single ORDER BY item
+type OrderItem struct {
+	span
+	Expr  Expr   // the expression to order by
+	Desc  bool   // descending order
+	Nulls string // FIRST, LAST, or empty if not specified
+}
+
+// TableName is a reference to a named table (or view)
+type TableName struct {
+	span
+	Name  string // the, possibly qualified, name of the table
+	Alias string // the alias for the table, if any
+}
+
+// DerivedTable is a subquery in the FROM clause
+type DerivedTable struct {
+	span
+	Lateral bool             // LATERAL was specified
+	Query   *SelectStatement // the subquery
+	Alias   string           // the alias for the subquery, if any
+	Columns []string         // the column aliases, if any
+}
+
+// TableFunction is a function call in the FROM clause
+type TableFunction struct {
+	span
+	Lateral bool     // LATERAL was specified
+	Name    string   // the name of the function
+	Args    []Expr   // the function arguments
+	Alias   string   // the alias for the function result, if any
+	Columns []string // the column aliases, if any
+}
+
+// Join is a join between two table expressions
+type Join struct {
+	span
+	Type  string    // the type of join (JOIN, LEFT JOIN, CROSS JOIN, NATURAL JOIN, CROSS APPLY, ...)
+	Left  TableExpr // the left side of the join
+	Right TableExpr // the right side of the join
+	On    Expr      // the ON condition (nil if none)
+	Using []string  // the USING columns
+}
+
+// ParenTable is a parenthesized table expression (such as a join)
+type ParenTable struct {
+	span
+	Table TableExpr
+}
+
+// StarExpr is a * or table.* select item
+type StarExpr struct {
+	span
+	Table string // the, possibly qualified, table name (empty for *)
+}
+
+// Ident is a, possibly qualified, column name, variable, or other name
+type Ident struct {
+	span
+	Name string
+}
+
+// Literal is a numeric, string, or keyword (NULL, TRUE, FALSE) literal
+type Literal struct {
+	span
+	Value string // the literal as written (including any quotes and prefix)
+	Type  string // the type name of a typed literal (DATE '2020-01-01'), if any
+}
+
+// Param is a bind parameter
+type Param struct {
+	span
+	Name string // the parameter as written ($1, :name, ?)
+}
+
+// BinaryExpr is an infix operator expression
+type BinaryExpr struct {
+	span
+	Op    string // the operator (keyword operators are upper-case)
+	Left  Expr
+	Right Expr
+}
+
+// UnaryExpr is a prefix or postfix operator expression
+type UnaryExpr struct {
+	span
+	Op      string // the operator (keyword operators are upper-case)
+	Postfix bool   // the operator follows the operand
+	Expr    Expr
+}
+
+// ParenExpr is a parenthesized expression
+type ParenExpr struct {
+	span
+	Expr Expr
+}
+
+// ListExpr is a parenthesized list of expressions (a row constructor)
+type ListExpr struct {
+	span
+	Exprs []Expr
+}
+
+// FuncCall is a function call
+type FuncCall struct {
+	span
+	Name        string       // the, possibly qualified, name of the function
+	Distinct    bool         // DISTINCT was specified for the arguments
+	Star        bool         // the argument is * (as in count(*))
+	Args        []Expr       // the arguments
+	OrderBy     []*OrderItem // the ORDER BY within the arguments (aggregates)
+	WithinGroup []*OrderItem // the WITHIN GROUP (ORDER BY ...) items
+	Filter      Expr         // the FILTER (WHERE ...) condition (nil if none)
+	Over        *WindowSpec  // the window for window functions (nil if none)
+}
+
+// WindowSpec is the window specification of a window function
+type WindowSpec struct {
+	span
+	Name        string       // the name of the window (or of the window that is refined)
+	PartitionBy []Expr       // the PARTITION BY expressions
+	OrderBy     []*OrderItem // the ORDER BY items
+	Frame       Expr         // the frame clause, as a RawExpr (nil if none)
+}
+
+// CaseExpr is a CASE expression
+type CaseExpr struct {
+	span
+	Operand Expr    // the operand of a simple CASE (nil for a searched CASE)
+	Whens   []*When // the WHEN clauses
+	Else    Expr    // the ELSE result (nil if none)
+}
+
+// When is a WHEN clause of a CASE expression
+type When struct {
+	span
+	Cond   Expr
+	Result Expr
+}
+
+// CastExpr is a type conversion, CAST(x AS type) or x::type
+type CastExpr struct {
+	span
+	Op       string   // CAST, TRY_CAST, SAFE_CAST, or ::
+	Expr     Expr     // the expression being converted
+	Type     string   // the data type, as written
+	DataType DataType // the parsed data type
+}
+
+// BetweenExpr is a [NOT] BETWEEN expression
+type BetweenExpr struct {
+	span
+	Not       bool
+	Symmetric bool
+	Expr      Expr
+	Low       Expr
+	High      Expr
+}
+
+// InExpr is a [NOT] IN expression with either a list or a subquery
+type InExpr struct {
+	span
+	Not   bool
+	Expr  Expr
+	List  []Expr           // the list of values
+	Query *SelectStatement // the subquery (nil if a list)
+}
+
+// LikeExpr is a pattern matching expression ([NOT] LIKE, ILIKE,
+// SIMILAR TO, GLOB, REGEXP, ...) with an optional ESCAPE
+type LikeExpr struct {
+	span
+	Not     bool
+	Op      string // LIKE, ILIKE, SIMILAR TO, GLOB, MATCH, REGEXP, RLIKE, SOUNDS LIKE, ...
+	Expr    Expr
+	Pattern Expr
+	Escape  Expr // the escape character (nil if none)
+}
+
+// IsExpr is an IS [NOT] NULL, TRUE, FALSE, UNKNOWN, or DISTINCT FROM
+// expression (ISNULL and NOTNULL are also IsExprs)
+type IsExpr struct {
+	span
+	Not   bool
+	Expr  Expr
+	Value string // NULL, TRUE, FALSE, UNKNOWN, or DISTINCT FROM
+	Right Expr   // the right operand of DISTINCT FROM
+}
+
+// SubqueryExpr is a subquery used as an expression, optionally with
+// EXISTS or a quantifier (ANY, SOME, ALL)
+type SubqueryExpr struct {
+	span
+	Op    string // EXISTS, ANY, SOME, ALL, or empty for a scalar subquery
+	Query *SelectStatement
+}
+
+// IntervalExpr is an INTERVAL literal or expression
+type IntervalExpr struct {
+	span
+	Value Expr   // the interval value
+	Unit  string // the interval unit(s) (DAY, HOUR TO MINUTE, DAY_SECOND, ...), if any
+}
+
+// RawExpr is a part of a statement that is represented only by its
+// range of tokens (such as the frame clause of a window)
+type RawExpr struct {
+	span
+}
+
+func (*SelectStatement) queryExprNode() {}
+func (*Select) queryExprNode()          {}
+func (*SetOperation) queryExprNode()    {}
+func (*ParenQuery) queryExprNode()      {}
+
+func (*TableName) tableExprNode()     {}
+func (*DerivedTable) tableExprNode()  {}
+func (*TableFunction) tableExprNode() {}
+func (*Join) tableExprNode()          {}
+func (*ParenTable) tableExprNode()    {}
+
+func (*Ident) exprNode()        {}
+func (*Literal) exprNode()      {}
+func (*Param) exprNode()        {}
+func (*BinaryExpr) exprNode()   {}
+func (*UnaryExpr) exprNode()    {}
+func (*ParenExpr) exprNode()    {}
+func (*ListExpr) exprNode()     {}
+func (*FuncCall) exprNode()     {}
+func (*CaseExpr) exprNode()     {}
+func (*CastExpr) exprNode()     {}
+func (*BetweenExpr) exprNode()  {}
+func (*InExpr) exprNode()       {}
+func (*LikeExpr) exprNode()     {}
+func (*IsExpr) exprNode()       {}
+func (*SubqueryExpr) exprNode() {}
+func (*IntervalExpr) exprNode() {}
+func (*StarExpr) exprNode()     {}
+func (*RawExpr) exprNode()      {}
+
+// Visitor has its Visit method called for each node encountered by
+// Walk. If the visitor returned by Visit is not nil then Walk visits
+// each of the children of the node with that visitor, followed by a
+// call of Visit(nil).
+type Visitor interface {
+	Visit(n Node) (w Visitor)
+}
+
+// Walk traverses the syntax tree in depth-first order
+func Walk(v Visitor, n Node) {
+
+	if v = v.Visit(n); v == nil {
+		return
+	}
+
+	switch n := n.(type) {
+	case *SelectStatement:
+		if n.With != nil {
+			Walk(v, n.With)
+		}
+		walkNode(v, n.Body)
+		for _, o := range n.OrderBy {
+			Walk(v, o)
+		}
+		walkNode(v, n.Limit)
+		walkNode(v, n.Offset)
+
+	case *WithClause:
+		for _, c := range n.CTEs {
+			Walk(v, c)
+		}
+
+	case *CTE:
+		Walk(v, n.Query)
+
+	case *Select:
+		walkExprs(v, n.DistinctOn)
+		walkNode(v, n.Top)
+		for _, c := range n.Columns {
+			Walk(v, c)
+		}
+		for _, f := range n.From {
+			Walk(v, f)
+		}
+		walkNode(v, n.Where)
+		walkExprs(v, n.GroupBy)
+		walkNode(v, n.Having)
+
+	case *SelectItem:
+		walkNode(v, n.Expr)
+
+	case *SetOperation:
+		walkNode(v, n.Left)
+		walkNode(v, n.Right)
+
+	case *ParenQuery:
+		Walk(v, n.Query)
+
+	case *OrderItem:
+		walkNode(v, n.Expr)
+
+	case *DerivedTable:
+		Walk(v, n.Query)
+
+	case *TableFunction:
+		walkExprs(v, n.Args)
+
+	case *Join:
+		walkNode(v, n.Left)
+		walkNode(v, n.Right)
+		walkNode(v, n.On)
+
+	case *ParenTable:
+		walkNode(v, n.Table)
+
+	case *BinaryExpr:
+		walkNode(v, n.Left)
+		walkNode(v, n.Right)
+
+	case *UnaryExpr:
+		walkNode(v, n.Expr)
+
+	case *ParenExpr:
+		walkNode(v, n.Expr)
+
+	case *ListExpr:
+		walkExprs(v, n.Exprs)
+
+	case *FuncCall:
+		walkExprs(v, n.Args)
+		for _, o := range n.OrderBy {
+			Walk(v, o)
+		}
+		for _, o := range n.WithinGroup {
+			Walk(v, o)
+		}
+		walkNode(v, n.Filter)
+		if n.Over != nil {
+			Walk(v, n.Over)
+		}
+
+	case *WindowSpec:
+		walkExprs(v, n.PartitionBy)
+		for _, o := range n.OrderBy {
+			Walk(v, o)
+		}
+		walkNode(v, n.Frame)
+
+	case *CaseExpr:
+		walkNode(v, n.Operand)
+		for _, w := range n.Whens {
+			Walk(v, w)
+		}
+		walkNode(v, n.Else)
+
+	case *When:
+		walkNode(v, n.Cond)
+		walkNode(v, n.Result)
+
+	case *CastExpr:
+		walkNode(v, n.Expr)
+
+	case *BetweenExpr:
+		walkNode(v, n.Expr)
+		walkNode(v, n.Low)
+		walkNode(v, n.High)
+
+	case *InExpr:
+		walkNode(v, n.Expr)
+		walkExprs(v, n.List)
+		if n.Query != nil {
+			Walk(v, n.Query)
+		}
+
+	case *LikeExpr:
+		walkNode(v, n.Expr)
+		walkNode(v, n.Pattern)
+		walkNode(v, n.Escape)
+
+	case *IsExpr:
+		walkNode(v, n.Expr)
+		walkNode(v, n.Right)
+
+	case *SubqueryExpr:
+		Walk(v, n.Query)
+
+	case *IntervalExpr:
+		walkNode(v, n.Value)
+	}
+
+	v.Visit(nil)
+}
+
+// walkNode walks the node if it is not nil
+func walkNode(v Visitor, n Node) {
+	if n != nil {
+		Walk(v, n)
+	}
+}
+
+// walkExprs walks the expressions in a list
+func walkExprs(v Visitor, l []Expr) {
+	for _, e := range l {
+		walkNode(v, e)
+	}
+}
+
+type inspector func(Node) bool
+
+func (f inspector) Visit(n Node) Visitor {
+	if f(n) {
+		return f
+	}
+	return nil
+}
+
+// Inspect traverses the syntax tree in depth-first order calling f for
+// each node. If f returns true then Inspect continues with the children
+// of the node, followed by a call of f(nil).
+func Inspect(n Node, f func(Node) bool) {
+	Walk(inspector(f), n)
+}
@@ -0,0 +1,388 @@
+package sqlparse
+
+/*
+
+classify.go provides the functionality for determining what kind of
+statement a token list contains.
+
+*/
+
+import (
+	"strings"
+)
+
+// Statement categories
+const (
+	// NullStatement indicates a statement that could not be classified
+	NullStatement = iota
+	// DDLStatement is a data definition statement (CREATE, ALTER, DROP, ...)
+	DDLStatement
+	// DMLStatement is a data manipulation (or query) statement (SELECT, INSERT, ...)
+	DMLStatement
+	// DCLStatement is a data control statement (GRANT, REVOKE, ...)
+	DCLStatement
+	// TCLStatement is a transaction control statement (COMMIT, ROLLBACK, ...)
+	TCLStatement
+	// UtilityStatement is any other statement (EXPLAIN, SET, procedural blocks, ...)
+	UtilityStatement
+)
+
+// Classification provides the kind of statement that a token list contains
+type Classification struct {
+	Category int    // the category of the statement
+	Verb     string // the canonical verb of the statement (SELECT, CREATE, GRANT, ...)
+	Object   string // the kind of object for DDL statements (TABLE, MATERIALIZED VIEW, ...), or the verb of the explained statement for EXPLAIN
+	ReadOnly bool   // indicates that the statement does not modify the database
+}
+
+// StatementCategoryName returns the string representation of the
+// statement category
+func StatementCategoryName(category int) (s string) {
+
+	var names = map[int]string{
+		NullStatement:    "NullStatement",
+		DDLStatement:     "DDLStatement",
+		DMLStatement:     "DMLStatement",
+		DCLStatement:     "DCLStatement",
+		TCLStatement:     "TCLStatement",
+		UtilityStatement: "UtilityStatement",
+	}
+
+	if s, ok := names[category]; ok {
+		return s
+	}
+	return ""
+}
+
+// map[verb]category for those verbs that determine the statement
+// category on their own
+var statementVerbs = map[string]int{
+	"ALTER":      DDLStatement,
+	"ANALYZE":    UtilityStatement,
+	"CALL":       UtilityStatement,
+	"CLUSTER":    UtilityStatement,
+	"COMMENT":    DDLStatement,
+	"COPY":       DMLStatement,
+	"CREATE":     DDLStatement,
+	"DEALLOCATE": UtilityStatement,
+	"DELETE":     DMLStatement,
+	"DENY":       DCLStatement,
+	"DESCRIBE":   UtilityStatement,
+	"DO":         UtilityStatement,
+	"DROP":       DDLStatement,
+	"EXECUTE":    UtilityStatement,
+	"GRANT":      DCLStatement,
+	"INSERT":     DMLStatement,
+	"LOAD":       DMLStatement,
+	"LOCK":       UtilityStatement,
+	"MERGE":      DMLStatement,
+	"PREPARE":    UtilityStatement,
+	"REINDEX":    UtilityStatement,
+	"RELEASE":    TCLStatement,
+	"RENAME":     DDLStatement,
+	"REPLACE":    DMLStatement,
+	"REVOKE":     DCLStatement,
+	"SAVEPOINT":  TCLStatement,
+	"SELECT":     DMLStatement,
+	"SHOW":       UtilityStatement,
+	"TABLE":      DMLStatement,
+	"TRUNCATE":   DDLStatement,
+	"UPDATE":     DMLStatement,
+	"UPSERT":     DMLStatement,
+	"USE":        UtilityStatement,
+	"VACUUM":     UtilityStatement,
+	"VALUES":     DMLStatement,
+}
+
+// map[verb]canonical verb
+var verbAliases = map[string]string{
+	"ABORT":   "ROLLBACK",
+	"ANALYSE": "ANALYZE",
+	"DESC":    "DESCRIBE",
+	"EXEC":    "EXECUTE",
+}
+
+// the kinds of objects for DDL statements, multi-word kinds are listed
+// before the single word kinds that they start with
+var objectKinds = []string{
+	"DATABASE LINK",
+	"EVENT TRIGGER",
+	"FOREIGN DATA WRAPPER",
+	"FOREIGN TABLE",
+	"MATERIALIZED VIEW LOG",
+	"MATERIALIZED VIEW",
+	"PACKAGE BODY",
+	"TEXT SEARCH CONFIGURATION",
+	"TEXT SEARCH DICTIONARY",
+	"TEXT SEARCH PARSER",
+	"TEXT SEARCH TEMPLATE",
+	"TYPE BODY",
+	"USER MAPPING",
+	"ACCESS METHOD",
+	"AGGREGATE",
+	"CAST",
+	"COLLATION",
+	"COLUMN",
+	"CONSTRAINT",
+	"CONVERSION",
+	"DATABASE",
+	"DIRECTORY",
+	"DOMAIN",
+	"EVENT",
+	"EXTENSION",
+	"FUNCTION",
+	"INDEX",
+	"LANGUAGE",
+	"LIBRARY",
+	"LOGIN",
+	"OPERATOR",
+	"PACKAGE",
+	"POLICY",
+	"PROCEDURE",
+	"PROFILE",
+	"PUBLICATION",
+	"ROLE",
+	"ROUTINE",
+	"RULE",
+	"SCHEMA",
+	"SEQUENCE",
+	"SERVER",
+	"STATISTICS",
+	"SUBSCRIPTION",
+	"SYNONYM",
+	"TABLE",
+	"TABLESPACE",
+	"TRIGGER",
+	"TYPE",
+	"USER",
+	"VIEW",
+}
+
+// the words that may follow BEGIN when BEGIN starts a transaction
+var transactionWords = map[string]bool{
+	"DEFERRED":    true,
+	"DISTRIBUTED": true,
+	"EXCLUSIVE":   true,
+	"IMMEDIATE":   true,
+	"ISOLATION":   true,
+	"READ":        true,
+	"TRAN":        true,
+	"TRANSACTION": true,
+	"WORK":        true,
+	"NOT":         true,
+	"DEFERRABLE":  true,
+}
+
+// Classify determines the category, verb, and object kind of the
+// statement in the token list. Leading comments are ignored, as are
+// common table expressions (WITH ...) that precede the main statement.
+func Classify(tl Tokens, dialect int) (c Classification) {
+
+	s := tl.Filter(SignificantTokens)
+	return classifyFrom(s, 0, dialect)
+}
+
+// classifyFrom classifies the statement that starts at index i of the
+// list of significant tokens
+func classifyFrom(s Tokens, i int, dialect int) (c Classification) {
+
+	// parenthesized queries, ((SELECT ...) UNION (SELECT ...))
+	for i < s.length && s.tokens[i].tokenString == "(" {
+		i++
+	}
+	if i >= s.length {
+		return c
+	}
+
+	verb := tokenWord(s.tokens[i])
+	if alias, ok := verbAliases[verb]; ok {
+		verb = alias
+	}
+
+	switch verb {
+	case "WITH":
+		if j := s.nextVerb(i+1, "SELECT", "INSERT", "UPDATE", "DELETE", "MERGE", "VALUES"); j >= 0 {
+			return classifyFrom(s, j, dialect)
+		}
+		return c
+
+	case "EXPLAIN":
+		c = Classification{Category: UtilityStatement, Verb: verb, ReadOnly: true}
+		analyze := false
+		for j := i + 1; j < s.length; j++ {
+			w := tokenWord(s.tokens[j])
+			if w == "ANALYZE" || w == "ANALYSE" {
+				analyze = true
+			}
+			if _, ok := statementVerbs[w]; ok && w != "ANALYZE" && w != "TABLE" {
+				e := classifyFrom(s, j, dialect)
+				c.Object = e.Verb
+				c.ReadOnly = e.ReadOnly || !analyze
+				break
+			}
+		}
+		return c
+
+	case "COMMIT", "ROLLBACK", "SAVEPOINT", "RELEASE":
+		return Classification{Category: TCLStatement, Verb: verb, Object: "TRANSACTION"}
+
+	case "END":
+		if isTransactionEnd(s, i, dialect) {
+			return Classification{Category: TCLStatement, Verb: "COMMIT", Object: "TRANSACTION"}
+		}
+		return Classification{Category: UtilityStatement, Verb: "END", Object: "BLOCK"}
+
+	case "START":
+		return Classification{Category: TCLStatement, Verb: "BEGIN", Object: "TRANSACTION"}
+
+	case "BEGIN":
+		if isTransactionBegin(s, i, dialect) {
+			return Classification{Category: TCLStatement, Verb: "BEGIN", Object: "TRANSACTION"}
+		}
+		return Classification{Category: UtilityStatement, Verb: "BEGIN", Object: "BLOCK"}
+
+	case "DECLARE":
+		if dialect == MSSQL {
+			return Classification{Category: UtilityStatement, Verb: "DECLARE"}
+		}
+		return Classification{Category: UtilityStatement, Verb: "DECLARE", Object: "BLOCK"}
+
+	case "SET":
+		if tokenWord(s.At(i+1)) == "TRANSACTION" {
+			return Classification{Category: TCLStatement, Verb: "SET", Object: "TRANSACTION"}
+		}
+		return Classification{Category: UtilityStatement, Verb: "SET"}
+	}
+
+	category, ok := statementVerbs[verb]
+	if !ok {
+		return c
+	}
+	c = Classification{Category: category, Verb: verb}
+
+	switch verb {
+	case "SELECT", "VALUES", "TABLE":
+		// SELECT ... INTO creates a table (or assigns variables)
+		c.ReadOnly = s.nextVerb(i+1, "INTO") < 0
+	case "SHOW", "DESCRIBE", "USE":
+		c.ReadOnly = true
+	case "COPY":
+		// COPY ... TO only reads from the database
+		c.ReadOnly = s.nextVerb(i+1, "TO") >= 0 && s.nextVerb(i+1, "FROM") < 0
+	case "CREATE", "ALTER", "DROP":
+		c.Object = s.objectKind(i + 1)
+	case "COMMENT":
+		if tokenWord(s.At(i+1)) == "ON" {
+			c.Object = s.objectKind(i + 2)
+		}
+	case "TRUNCATE", "RENAME":
+		c.Object = "TABLE"
+	}
+
+	return c
+}
+
+// isTransactionBegin determines whether the BEGIN at index i starts a
+// transaction (as opposed to a procedural block)
+func isTransactionBegin(s Tokens, i int, dialect int) bool {
+
+	next := s.At(i + 1)
+	if transactionWords[tokenWord(next)] {
+		return true
+	}
+
+	switch dialect {
+	case Oracle, MSSQL:
+		return false
+	}
+
+	// a bare BEGIN
+	return next.tokenString == "" || next.tokenString == ";"
+}
+
+// isTransactionEnd determines whether the END at index i commits a
+// transaction (PostgreSQL END [WORK | TRANSACTION]) as opposed to ending
+// a procedural block (as StatementRanges splits BEGIN ... END; blocks at
+// each semi-colon the END is then a statement on its own)
+func isTransactionEnd(s Tokens, i int, dialect int) bool {
+
+	if dialect != PostgreSQL {
+		return false
+	}
+
+	next := s.At(i + 1)
+	switch tokenWord(next) {
+	case "WORK", "TRANSACTION":
+		return true
+	}
+	return next.tokenString == "" || next.tokenString == ";"
+}
+
+// nextVerb returns the index of the first of the supplied words that
+// occurs, outside of any parentheses, at or following index i. If none
+// of the words are found then -1 is returned.
+func (d *Tokens) nextVerb(i int, words ...string) int {
+
+	depth := 0
+	for j := i; j < d.length; j++ {
+		switch d.tokens[j].tokenString {
+		case "(":
+			depth++
+			continue
+		case ")":
+			depth--
+			continue
+		case ";":
+			return -1
+		}
+		if depth != 0 {
+			continue
+		}
+
+		w := tokenWord(d.tokens[j])
+		for _, word := range words {
+			if w == word {
+				return j
+			}
+		}
+	}
+	return -1
+}
+
+// objectKind returns the kind of object that is the target of the DDL
+// statement whose object kind (or modifiers) begin at index i
+func (d *Tokens) objectKind(i int) string {
+
+	const maxModifiers = 8
+
+	for j := i; j < d.length && j < i+maxModifiers; j++ {
+		if d.tokens[j].tokenString == "(" {
+			break
+		}
+
+		for _, kind := range objectKinds {
+			words := strings.Split(kind, " ")
+			matches := true
+			for k, word := range words {
+				if tokenWord(d.At(j+k)) != word {
+					matches = false
+					break
+				}
+			}
+			if matches {
+				return kind
+			}
+		}
+	}
+	return ""
+}
+
+// tokenWord returns the upper-case value of a keyword, identifier, or
+// function name token. Other tokens return an empty string.
+func tokenWord(t Token) string {
+	switch t.tokenType {
+	case KeywordToken, IdentToken, FunctionToken:
+		return strings.ToUpper(t.tokenString)
+	}
+	return ""
+}
@@ -0,0 +1,77 @@
+package sqlparse
+
+import (
+	"testing"
+)
+
+func TestClassify(t *testing.T) {
+
+	cases := []struct {
+		input    string
+		dialect  int
+		expected Classification
+	}{
+		{"-- get it\nSELECT * FROM t", PostgreSQL, Classification{DMLStatement, "SELECT", "", true}},
+		{"(SELECT 1) UNION (SELECT 2)", PostgreSQL, Classification{DMLStatement, "SELECT", "", true}},
+		{"SELECT * INTO t2 FROM t", MSSQL, Classification{DMLStatement, "SELECT", "", false}},
+		{"WITH x AS (SELECT 1) INSERT INTO t SELECT * FROM x", PostgreSQL, Classification{DMLStatement, "INSERT", "", false}},
+		{"WITH RECURSIVE x (n) AS (SELECT 1 UNION ALL SELECT n + 1 FROM x) SELECT n FROM x", PostgreSQL, Classification{DMLStatement, "SELECT", "", true}},
+		{"EXPLAIN ANALYZE DELETE FROM t", PostgreSQL, Classification{UtilityStatement, "EXPLAIN", "DELETE", false}},
+		{"EXPLAIN (FORMAT JSON) UPDATE t SET a = 1", PostgreSQL, Classification{UtilityStatement, "EXPLAIN", "UPDATE", true}},
+		{"CREATE OR REPLACE MATERIALIZED VIEW v AS SELECT 1", PostgreSQL, Classification{DDLStatement, "CREATE", "MATERIALIZED VIEW", false}},
+		{"CREATE UNIQUE INDEX i ON t (a)", PostgreSQL, Classification{DDLStatement, "CREATE", "INDEX", false}},
+		{"CREATE GLOBAL TEMPORARY TABLE t (a int)", Oracle, Classification{DDLStatement, "CREATE", "TABLE", false}},
+		{"CREATE OR REPLACE PACKAGE BODY p AS END;", Oracle, Classification{DDLStatement, "CREATE", "PACKAGE BODY", false}},
+		{"ALTER INDEX i RENAME TO j", PostgreSQL, Classification{DDLStatement, "ALTER", "INDEX", false}},
+		{"DROP TABLE IF EXISTS t", MySQL, Classification{DDLStatement, "DROP", "TABLE", false}},
+		{"COMMENT ON COLUMN t.a IS 'the a'", PostgreSQL, Classification{DDLStatement, "COMMENT", "COLUMN", false}},
+		{"TRUNCATE t", PostgreSQL, Classification{DDLStatement, "TRUNCATE", "TABLE", false}},
+		{"GRANT SELECT ON t TO u", PostgreSQL, Classification{DCLStatement, "GRANT", "", false}},
+		{"REVOKE ALL ON t FROM u", Oracle, Classification{DCLStatement, "REVOKE", "", false}},
+		{"COMMIT", PostgreSQL, Classification{TCLStatement, "COMMIT", "TRANSACTION", false}},
+		{"END", PostgreSQL, Classification{TCLStatement, "COMMIT", "TRANSACTION", false}},
+		{"END WORK;", PostgreSQL, Classification{TCLStatement, "COMMIT", "TRANSACTION", false}},
+		{"END;", Oracle, Classification{UtilityStatement, "END", "BLOCK", false}},
+		{"END", MSSQL, Classification{UtilityStatement, "END", "BLOCK", false}},
+		{"BEGIN;", PostgreSQL, Classification{TCLStatement, "BEGIN", "TRANSACTION", false}},
+		{"BEGIN TRAN", MSSQL, Classification{TCLStatement, "BEGIN", "TRANSACTION", false}},
+		{"BEGIN\n  NULL;\nEND;", Oracle, Classification{UtilityStatement, "BEGIN", "BLOCK", false}},
+		{"START TRANSACTION", MySQL, Classification{TCLStatement, "BEGIN", "TRANSACTION", false}},
+		{"MERGE INTO t USING s ON (t.id = s.id) WHEN MATCHED THEN UPDATE SET t.a = s.a", Oracle, Classification{DMLStatement, "MERGE", "", false}},
+		{"REPLACE INTO t (a) VALUES (1)", MySQL, Classification{DMLStatement, "REPLACE", "", false}},
+		{"UPSERT INTO t (a) VALUES (1)", StandardSQL, Classification{DMLStatement, "UPSERT", "", false}},
+		{"COPY t FROM STDIN", PostgreSQL, Classification{DMLStatement, "COPY", "", false}},
+		{"COPY (SELECT * FROM t) TO STDOUT", PostgreSQL, Classification{DMLStatement, "COPY", "", true}},
+		{"EXEC sp_who", MSSQL, Classification{UtilityStatement, "EXECUTE", "", false}},
+		{"SET search_path TO app", PostgreSQL, Classification{UtilityStatement, "SET", "", false}},
+		{"frobnicate the widgets", PostgreSQL, Classification{}},
+	}
+
+	for _, c := range cases {
+		tl := ParseStatements(c.input, c.dialect)
+		if got := Classify(tl, c.dialect); got != c.expected {
+			t.Errorf("%q: expected %+v, got %+v", c.input, c.expected, got)
+		}
+	}
+}
+
+func TestClassifyBlockStatements(t *testing.T) {
+
+	// the statements of a block are split at each semi-colon, the END
+	// is not a commit
+	tl := ParseStatements("BEGIN NULL; END;", Oracle)
+	expected := []Classification{
+		{UtilityStatement, "BEGIN", "BLOCK", false},
+		{UtilityStatement, "END", "BLOCK", false},
+	}
+
+	stmts := tl.Statements(Oracle)
+	if len(stmts) != len(expected) {
+		t.Fatalf("expected %d statements, got %d", len(expected), len(stmts))
+	}
+	for i, s := range stmts {
+		if got := Classify(s, Oracle); got != expected[i] {
+			t.Errorf("%q: expected %+v, got %+v", s.String(), expected[i], got)
+		}
+	}
+}
@@ -0,0 +1,178 @@
+package sqlparse
+
+/*
+
+comments.go provides the functionality for associating comments with
+the tokens (and thereby the statements) that they document.
+
+*/
+
+import (
+	"strings"
+)
+
+// Comment placements
+const (
+	// NullComment indicates an undetermined comment placement
+	NullComment = iota
+	// LeadingComment is a comment that precedes the token it is
+	//  attached to (with no intervening blank lines)
+	LeadingComment
+	// TrailingComment is a comment that follows the token it is
+	//  attached to on the same line
+	TrailingComment
+	// DanglingComment is a comment that is not attached to any token
+	//  (such as a comment that is followed by a blank line or that
+	//  appears at the end of the token list)
+	DanglingComment
+)
+
+// Comment provides a comment token along with the token that it is
+// attached to
+type Comment struct {
+	Index     int   // the index of the comment token
+	Target    int   // the index of the token that the comment is attached to (-1 for dangling comments)
+	Placement int   // the placement of the comment relative to the target token
+	Token     Token // the comment token
+}
+
+// CommentMap maps the index of a token to the comments that are
+// attached to that token. Dangling comments are mapped to -1.
+type CommentMap map[int][]Comment
+
+// Text returns the text of the comment without the comment delimiters
+// and surrounding white space
+func (c *Comment) Text() string {
+	s := c.Token.Value()
+	switch {
+	case strings.HasPrefix(s, "--"):
+		s = s[2:]
+	case strings.HasPrefix(s, "#"):
+		s = s[1:]
+	case strings.HasPrefix(s, "/*"):
+		s = strings.TrimSuffix(s[2:], "*/")
+	}
+	return strings.TrimSpace(s)
+}
+
+// Comments returns the comments in the token list along with the
+// tokens that they are attached to.
+//
+// A comment that follows a token on the same line is a trailing comment
+// for the last significant token preceding it (other than a comma or
+// semi-colon). Otherwise the comment is a leading comment for the next
+// significant token unless the comment is separated from that token by
+// a blank line, or there is no next token, in which case the comment is
+// dangling.
+func (d *Tokens) Comments() (c []Comment) {
+
+	for i := 0; i < d.length; i++ {
+		t := d.tokens[i]
+		if !isCommentToken(t.tokenType) {
+			continue
+		}
+
+		cmt := Comment{
+			Index:     i,
+			Target:    -1,
+			Placement: DanglingComment,
+			Token:     t,
+		}
+
+		if prev := d.prevTrailingTarget(i); prev >= 0 {
+			cmt.Target = prev
+			cmt.Placement = TrailingComment
+
+		} else if next := d.nextLeadingTarget(i); next >= 0 {
+			cmt.Target = next
+			cmt.Placement = LeadingComment
+		}
+
+		c = append(c, cmt)
+	}
+
+	return c
+}
+
+// CommentMap returns the map of token indexes to their comments
+func (d *Tokens) CommentMap() CommentMap {
+	m := make(CommentMap)
+	for _, c := range d.Comments() {
+		m[c.Target] = append(m[c.Target], c)
+	}
+	return m
+}
+
+// Leading returns the leading comments for the token at index i
+func (m CommentMap) Leading(i int) []Comment {
+	return m.placed(i, LeadingComment)
+}
+
+// Trailing returns the trailing comments for the token at index i
+func (m CommentMap) Trailing(i int) []Comment {
+	return m.placed(i, TrailingComment)
+}
+
+// Dangling returns the comments that are not attached to any token
+func (m CommentMap) Dangling() []Comment {
+	return m[-1]
+}
+
+// Range returns the comments that are attached to the tokens from
+// index start up to, but not including, index end. Using the range of a
+// statement returns the comments for that statement.
+func (m CommentMap) Range(start, end int) (c []Comment) {
+	for i := start; i < end; i++ {
+		c = append(c, m[i]...)
+	}
+	return c
+}
+
+func (m CommentMap) placed(i, placement int) (c []Comment) {
+	for _, cmt := range m[i] {
+		if cmt.Placement == placement {
+			c = append(c, cmt)
+		}
+	}
+	return c
+}
+
+// prevTrailingTarget returns the index of the token that the comment at
+// index i trails, or -1 if the comment is not on the same line as a
+// preceding token
+func (d *Tokens) prevTrailingTarget(i int) int {
+
+	for j := i; j > 0; j-- {
+		if strings.Contains(d.tokens[j].leadingWhiteSpace, "\n") {
+			return -1
+		}
+
+		p := d.tokens[j-1]
+		switch {
+		case isCommentToken(p.tokenType):
+			if isLineCommentToken(p.tokenType) {
+				return -1
+			}
+		case p.tokenType == OtherToken && (p.tokenString == "," || p.tokenString == ";"):
+			// keep looking for the token that the comment documents
+		default:
+			return j - 1
+		}
+	}
+	return -1
+}
+
+// nextLeadingTarget returns the index of the token that the comment at
+// index i leads, or -1 if there is no such token
+func (d *Tokens) nextLeadingTarget(i int) int {
+
+	for j := i + 1; j < d.length; j++ {
+		if strings.Count(d.tokens[j].leadingWhiteSpace, "\n") > 1 {
+			return -1
+		}
+		if SignificantTokens(d.tokens[j]) {
+			return j
+		}
+	}
+	return -1
+}
@@ -0,0 +1,94 @@
+package sqlparse
+
+import (
+	"testing"
+)
+
+func TestComments(t *testing.T) {
+
+	input := `-- schema for the application
+
+-- Users of the application
+CREATE TABLE users (
+    id integer, -- the user id
+    name text /* the user name */
+) ;
+
+SELECT 1 ; -- trailing
+
+-- the end
+`
+	tl := ParseStatements(input, PostgreSQL)
+
+	expected := []struct {
+		text      string
+		target    string
+		placement int
+	}{
+		{"schema for the application", "", DanglingComment},
+		{"Users of the application", "CREATE", LeadingComment},
+		{"the user id", "integer", TrailingComment},
+		{"the user name", "text", TrailingComment},
+		{"trailing", "1", TrailingComment},
+		{"the end", "", DanglingComment},
+	}
+
+	comments := tl.Comments()
+	if len(comments) != len(expected) {
+		t.Fatalf("expected %d comments, got %d", len(expected), len(comments))
+	}
+
+	for i, e := range expected {
+		c := comments[i]
+		if c.Text() != e.text {
+			t.Errorf("comment %d: expected text %q, got %q", i, e.text, c.Text())
+		}
+		if c.Placement != e.placement {
+			t.Errorf("comment %d: expected placement %d, got %d", i, e.placement, c.Placement)
+		}
+		target := tl.At(c.Target)
+		if target.Value() != e.target {
+			t.Errorf("comment %d: expected target %q, got %q", i, e.target, target.Value())
+		}
+	}
+
+	ranges := tl.StatementRanges(PostgreSQL)
+	if len(ranges) != 2 {
+		t.Fatalf("expected 2 statements, got %d", len(ranges))
+	}
+
+	m := tl.CommentMap()
+	if c := m.Range(ranges[0].Start, ranges[0].End); len(c) != 3 {
+		t.Errorf("expected 3 comments for the first statement, got %d", len(c))
+	}
+	if c := m.Leading(tl.Len()); len(c) != 0 {
+		t.Errorf("expected no comments past the end of the list, got %d", len(c))
+	}
+	if c := m.Dangling(); len(c) != 2 {
+		t.Errorf("expected 2 dangling comments, got %d", len(c))
+	}
+}
+
+func TestStatementRanges(t *testing.T) {
+
+	cases := []struct {
+		input    string
+		dialect  int
+		expected int
+	}{
+		{"SELECT 1; SELECT 2;", PostgreSQL, 2},
+		{"SELECT 1; SELECT 2", PostgreSQL, 2},
+		{"SELECT 1; -- done\n", PostgreSQL, 1},
+		{"SELECT f(';'); SELECT (1);", PostgreSQL, 2},
+		{"SELECT 1\nGO\nSELECT 2\nGO\n", MSSQL, 2},
+		{"SELECT 1\nGO\nSELECT 2\nGO\n", PostgreSQL, 1},
+		{"BEGIN\n  NULL;\nEND;\n/\nSELECT 4 / 2 FROM dual;", Oracle, 3},
+	}
+
+	for _, c := range cases {
+		tl := ParseStatements(c.input, c.dialect)
+		if got := len(tl.Statements(c.dialect)); got != c.expected {
+			t.Errorf("%q: expected %d statements, got %d", c.input, c.expected, got)
+		}
+	}
+}
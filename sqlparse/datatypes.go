@@ -0,0 +1,385 @@
+package sqlparse
+
+/*
+
+datatypes.go provides the functionality for parsing data types into a
+normalized descriptor and for mapping data types between dialects.
+
+*/
+
+import (
+	"strconv"
+	"strings"
+
+	d "github.com/gsiems/sql-parse/dialects"
+)
+
+// DataType provides the normalized description of a data type
+type DataType struct {
+	Name      string   // the base type name, upper-case unless quoted (VARCHAR2, DOUBLE PRECISION, TIMESTAMP, ...)
+	Length    int      // the length of a character or binary type, -1 for MAX, 0 if not specified
+	Precision int      // the precision of a numeric or date-time type, 0 if not specified
+	Scale     int      // the scale of a numeric type
+	ArrayDims int      // the number of array dimensions
+	Modifiers []string // the other modifiers (UNSIGNED, ZEROFILL, WITH TIME ZONE, CHAR, BYTE, ...)
+	Args      []string // the parenthesized arguments, as written
+	Category  int      // the category of the type (dialects.NumericDataType, ...), dialects.NullDataType for types that are not in the catalog
+	Generic   string   // the dialect neutral type that the type maps to, if known
+	Text      string   // the type as written, with the white space normalized
+}
+
+// map["PREVIOUS NEXT"]bool of the words that continue a multi-word type
+// name
+var typeWordPairs = map[string]bool{
+	"BINARY LARGE":       true,
+	"BINARY VARYING":     true,
+	"BIT VARYING":        true,
+	"CHAR VARYING":       true,
+	"CHARACTER LARGE":    true,
+	"CHARACTER VARYING":  true,
+	"DAY TO":             true,
+	"DOUBLE PRECISION":   true,
+	"INTERVAL DAY":       true,
+	"INTERVAL YEAR":      true,
+	"LARGE OBJECT":       true,
+	"LONG RAW":           true,
+	"NATIONAL CHAR":      true,
+	"NATIONAL CHARACTER": true,
+	"NATIONAL VARCHAR":   true,
+	"NATIVE CHARACTER":   true,
+	"NCHAR VARYING":      true,
+	"TO MONTH":           true,
+	"TO SECOND":          true,
+	"VARYING CHARACTER":  true,
+	"YEAR TO":            true,
+}
+
+// DataTypeInfo returns the catalog entry for the supplied data type
+// name in the specified SQL dialect
+func DataTypeInfo(s string, dialect int) (t d.TypeInfo, ok bool) {
+
+	switch dialect {
+	case PostgreSQL:
+		return d.PostgreSQLDataType(s)
+	case SQLite:
+		return d.SQLiteDataType(s)
+	case MySQL:
+		return d.MySQLDataType(s)
+	case Oracle:
+		return d.OracleDataType(s)
+	case MSSQL:
+		return d.MSSQLDataType(s)
+	case MariaDB:
+		return d.MariaDBDataType(s)
+	default:
+		return d.StandardDataType(s)
+	}
+}
+
+// DataTypeName returns the name of the data type to use for the supplied
+// generic data type in the specified SQL dialect
+func DataTypeName(generic string, dialect int) (s string, ok bool) {
+
+	switch dialect {
+	case PostgreSQL:
+		return d.PostgreSQLTypeName(generic)
+	case SQLite:
+		return d.SQLiteTypeName(generic)
+	case MySQL:
+		return d.MySQLTypeName(generic)
+	case Oracle:
+		return d.OracleTypeName(generic)
+	case MSSQL:
+		return d.MSSQLTypeName(generic)
+	case MariaDB:
+		return d.MariaDBTypeName(generic)
+	default:
+		return d.StandardTypeName(generic)
+	}
+}
+
+// ParseDataType parses the data type in the token list
+func ParseDataType(tl Tokens, dialect int) (dt DataType, err error) {
+
+	p := newParser(&tl, dialect)
+	if dt = p.parseDataType(false); dt.Text == "" {
+		return dt, p.errorf("expected data type, found %s", p.found())
+	}
+	if p.k < len(p.sig) {
+		return dt, p.errorf("unexpected %s", p.found())
+	}
+	return dt, nil
+}
+
+// parseDataType parses a data type (see parseTypeName) and returns the
+// descriptor for the type
+func (p *parser) parseDataType(inParens bool) (dt DataType) {
+
+	start := p.k
+	if dt.Text = p.parseTypeName(inParens); dt.Text == "" {
+		return dt
+	}
+	end := p.k
+
+	var name []string
+	var trailing []string
+	prev := ""
+
+	for k := start; k < end; k++ {
+		t := p.tl.tokens[p.sig[k]]
+		w := tokenWord(t)
+
+		switch {
+		case t.tokenString == "(":
+			args := p.typeArgs(k, end)
+			dt.Args = append(dt.Args, args...)
+			k = p.groupEnd(k, end)
+			continue
+
+		case t.tokenString == "[":
+			if k+1 < end && p.tl.tokens[p.sig[k+1]].tokenString == "]" {
+				dt.ArrayDims++
+				k++
+			}
+			continue
+
+		case isArraySuffix(t.tokenString):
+			_, dims := splitArraySuffix(t.tokenString)
+			dt.ArrayDims += dims
+			continue
+
+		case t.tokenType == OtherToken && strings.HasSuffix(t.tokenString, "[]"):
+			base, dims := splitArraySuffix(t.tokenString)
+			dt.ArrayDims += dims
+			name = append(name, strings.ToUpper(base))
+			prev = name[len(name)-1]
+			continue
+
+		case w == "UNSIGNED", w == "SIGNED", w == "ZEROFILL":
+			dt.Modifiers = append(dt.Modifiers, w)
+			continue
+
+		case w == "WITH" || w == "WITHOUT":
+			var words []string
+			for j := k; j < end && j < k+4; j++ {
+				words = append(words, tokenWord(p.tl.tokens[p.sig[j]]))
+				if words[len(words)-1] == "ZONE" {
+					dt.Modifiers = append(dt.Modifiers, strings.Join(words, " "))
+					k = j
+					break
+				}
+			}
+			continue
+
+		case len(name) == 0 || typeWordPairs[prev+" "+w]:
+			if w == "" {
+				w = unquoteName(t.tokenString)
+			}
+			name = append(name, w)
+			prev = w
+			continue
+		}
+
+		trailing = append(trailing, t.tokenString)
+	}
+
+	dt.Name = strings.Join(name, " ")
+	if len(trailing) > 0 {
+		dt.Modifiers = append(dt.Modifiers, strings.Join(trailing, " "))
+	}
+
+	p.lookupDataType(&dt)
+	p.applyTypeArgs(&dt)
+	return dt
+}
+
+// lookupDataType sets the category and generic type of the data type
+// from the catalog of the dialect
+func (p *parser) lookupDataType(dt *DataType) {
+
+	candidates := []string{dt.Name}
+	for _, m := range dt.Modifiers {
+		if strings.HasSuffix(m, "TIME ZONE") {
+			candidates = []string{dt.Name + " " + m, dt.Name}
+		}
+	}
+	if n := unqualifiedName(dt.Name); n != dt.Name {
+		candidates = append(candidates, n)
+	}
+
+	for _, c := range candidates {
+		if info, ok := DataTypeInfo(c, p.dialect); ok {
+			dt.Category = info.Category
+			dt.Generic = info.Generic
+			return
+		}
+	}
+}
+
+// applyTypeArgs sets the length, precision, and scale of the data type
+// from the arguments
+func (p *parser) applyTypeArgs(dt *DataType) {
+
+	var nums []int
+	for _, a := range dt.Args {
+		f := strings.Fields(a)
+		if len(f) == 0 {
+			continue
+		}
+		switch {
+		case strings.ToUpper(f[0]) == "MAX":
+			nums = append(nums, -1)
+		default:
+			n, err := strconv.Atoi(f[0])
+			if err != nil {
+				// ENUM values, etc.
+				return
+			}
+			nums = append(nums, n)
+		}
+		if len(f) > 1 {
+			// Oracle length semantics: VARCHAR2(100 CHAR)
+			dt.Modifiers = append(dt.Modifiers, strings.ToUpper(strings.Join(f[1:], " ")))
+		}
+	}
+	if len(nums) == 0 {
+		return
+	}
+
+	switch dt.Category {
+	case d.NumericDataType, d.DateTimeDataType:
+		dt.Precision = nums[0]
+		if len(nums) > 1 {
+			dt.Scale = nums[1]
+		}
+	default:
+		dt.Length = nums[0]
+	}
+}
+
+// typeArgs returns the comma separated arguments of the group that
+// starts at the k-th significant token
+func (p *parser) typeArgs(k, end int) (args []string) {
+
+	depth := 0
+	start := k + 1
+	for j := k; j < end; j++ {
+		switch p.tl.tokens[p.sig[j]].tokenString {
+		case "(":
+			depth++
+		case ")":
+			depth--
+			if depth == 0 {
+				if j > start {
+					args = append(args, p.tokenText(start, j))
+				}
+				return args
+			}
+		case ",":
+			if depth == 1 {
+				args = append(args, p.tokenText(start, j))
+				start = j + 1
+			}
+		}
+	}
+	return args
+}
+
+// groupEnd returns the index of the closing parenthesis of the group
+// that starts at the k-th significant token
+func (p *parser) groupEnd(k, end int) int {
+	depth := 0
+	for j := k; j < end; j++ {
+		switch p.tl.tokens[p.sig[j]].tokenString {
+		case "(":
+			depth++
+		case ")":
+			depth--
+			if depth == 0 {
+				return j
+			}
+		}
+	}
+	return end
+}
+
+// ConvertDataType returns the name of the data type to use in the
+// specified dialect for the parsed data type. Types without a generic
+// mapping, or whose generic type has no equivalent in the dialect, are
+// returned as written. Array dimensions are only retained for
+// PostgreSQL and UNSIGNED only for MySQL and MariaDB.
+func ConvertDataType(dt DataType, dialect int) string {
+
+	generic := dt.Generic
+	if dt.Length < 0 {
+		// VARCHAR(MAX), NVARCHAR(MAX), VARBINARY(MAX)
+		switch dt.Category {
+		case d.StringDataType:
+			generic = "TEXT"
+		case d.BinaryDataType:
+			generic = "BLOB"
+		}
+	}
+
+	name, ok := DataTypeName(generic, dialect)
+	if generic == "" || !ok {
+		return dt.Text
+	}
+
+	s := name
+	if !strings.Contains(name, "(") {
+		switch {
+		case dt.Length > 0 && generic != "TEXT" && generic != "BLOB":
+			s += "(" + strconv.Itoa(dt.Length) + ")"
+		case dt.Precision > 0 && dt.Category == d.NumericDataType && generic == "DECIMAL":
+			s += "(" + strconv.Itoa(dt.Precision)
+			if dt.Scale > 0 {
+				s += ", " + strconv.Itoa(dt.Scale)
+			}
+			s += ")"
+		}
+	}
+
+	if dialect == MySQL || dialect == MariaDB {
+		for _, m := range dt.Modifiers {
+			if m == "UNSIGNED" || m == "ZEROFILL" {
+				s += " " + m
+			}
+		}
+	}
+	if dialect == PostgreSQL {
+		s += strings.Repeat("[]", dt.ArrayDims)
+	}
+	return s
+}
+
+// splitArraySuffix splits the trailing array dimensions ("[]") from the
+// token string and returns the remaining string and the number of
+// dimensions
+func splitArraySuffix(s string) (base string, dims int) {
+	for strings.HasSuffix(s, "[]") {
+		s = s[:len(s)-2]
+		dims++
+	}
+	return s, dims
+}
+
+// isArraySuffix returns true if the token string consists of array
+// dimensions only ("[]", "[][]", ...)
+func isArraySuffix(s string) bool {
+	base, dims := splitArraySuffix(s)
+	return dims > 0 && base == ""
+}
+
+// unquoteName returns the name without the enclosing quotes, if any
+func unquoteName(s string) string {
+	if len(s) > 1 {
+		switch {
+		case s[0] == '"' && s[len(s)-1] == '"',
+			s[0] == '`' && s[len(s)-1] == '`',
+			s[0] == '[' && s[len(s)-1] == ']':
+			return s[1 : len(s)-1]
+		}
+	}
+	return s
+}
@@ -0,0 +1,89 @@
+package sqlparse
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	d "github.com/gsiems/sql-parse/dialects"
+)
+
+func formatDataType(dt DataType) string {
+	return fmt.Sprintf("%s|%d|%d|%d|%d|%s|%s|%s",
+		dt.Name, dt.Length, dt.Precision, dt.Scale, dt.ArrayDims,
+		strings.Join(dt.Modifiers, ","), d.DataTypeCategoryName(dt.Category), dt.Generic)
+}
+
+func TestParseDataType(t *testing.T) {
+
+	cases := []struct {
+		dialect  int
+		s        string
+		expected string
+	}{
+		{Oracle, "VARCHAR2(100 CHAR)", "VARCHAR2|100|0|0|0|CHAR|StringDataType|VARCHAR"},
+		{Oracle, "NUMBER(10, 2)", "NUMBER|0|10|2|0||NumericDataType|DECIMAL"},
+		{Oracle, "TIMESTAMP(6) WITH LOCAL TIME ZONE", "TIMESTAMP|0|6|0|0|WITH LOCAL TIME ZONE|DateTimeDataType|TIMESTAMPTZ"},
+		{PostgreSQL, "NUMERIC(10,2)", "NUMERIC|0|10|2|0||NumericDataType|DECIMAL"},
+		{PostgreSQL, "TIMESTAMP WITH TIME ZONE", "TIMESTAMP|0|0|0|0|WITH TIME ZONE|DateTimeDataType|TIMESTAMPTZ"},
+		{PostgreSQL, "double precision", "DOUBLE PRECISION|0|0|0|0||NumericDataType|DOUBLE"},
+		{PostgreSQL, "character varying(20)[][]", "CHARACTER VARYING|20|0|0|2||StringDataType|VARCHAR"},
+		{MySQL, "INT UNSIGNED ZEROFILL", "INT|0|0|0|0|UNSIGNED,ZEROFILL|NumericDataType|INTEGER"},
+		{MySQL, "enum('a', 'b')", "ENUM|0|0|0|0||StringDataType|VARCHAR"},
+		{MSSQL, "NVARCHAR(MAX)", "NVARCHAR|-1|0|0|0||StringDataType|NVARCHAR"},
+		{MSSQL, "[datetime2](7)", "datetime2|0|7|0|0||DateTimeDataType|TIMESTAMP"},
+		{SQLite, "my_type", "MY_TYPE|0|0|0|0||NullDataType|"},
+	}
+
+	for _, c := range cases {
+		dt, err := ParseDataType(ParseStatements(c.s, c.dialect), c.dialect)
+		if err != nil {
+			t.Errorf("%s %q: unexpected error: %s", SQLDialectName(c.dialect), c.s, err)
+			continue
+		}
+		if got := formatDataType(dt); got != c.expected {
+			t.Errorf("%s %q:\n  expected %s\n  got      %s", SQLDialectName(c.dialect), c.s, c.expected, got)
+		}
+	}
+}
+
+func TestConvertDataType(t *testing.T) {
+
+	cases := []struct {
+		from     int
+		to       int
+		s        string
+		expected string
+	}{
+		{MSSQL, PostgreSQL, "NVARCHAR(MAX)", "TEXT"},
+		{MSSQL, Oracle, "VARBINARY(MAX)", "BLOB"},
+		{Oracle, PostgreSQL, "VARCHAR2(100 CHAR)", "VARCHAR(100)"},
+		{Oracle, MSSQL, "NUMBER(10, 2)", "DECIMAL(10, 2)"},
+		{PostgreSQL, MySQL, "TIMESTAMP WITH TIME ZONE", "TIMESTAMP"},
+		{PostgreSQL, PostgreSQL, "integer[]", "INTEGER[]"},
+		{PostgreSQL, MySQL, "integer[]", "INT"},
+		{MySQL, MariaDB, "INT(11) UNSIGNED", "INT UNSIGNED"},
+		{MySQL, PostgreSQL, "INT(11) UNSIGNED", "INTEGER"},
+		{SQLite, PostgreSQL, "my_type", "my_type"},
+	}
+
+	for _, c := range cases {
+		dt, err := ParseDataType(ParseStatements(c.s, c.from), c.from)
+		if err != nil {
+			t.Errorf("%q: unexpected error: %s", c.s, err)
+			continue
+		}
+		if got := ConvertDataType(dt, c.to); got != c.expected {
+			t.Errorf("%q %s to %s: expected %q, got %q", c.s, SQLDialectName(c.from), SQLDialectName(c.to), c.expected, got)
+		}
+	}
+}
+
+func TestParseDataTypeErrors(t *testing.T) {
+
+	for _, s := range []string{"", "VARCHAR(10) x"} {
+		if _, err := ParseDataType(ParseStatements(s, PostgreSQL), PostgreSQL); err == nil {
+			t.Errorf("%q: expected an error", s)
+		}
+	}
+}
@@ -0,0 +1,833 @@
+package sqlparse
+
+/*
+
+ddl.go provides the parser for CREATE TABLE statements and the schema
+model that the statements are parsed into.
+
+*/
+
+import (
+	"strings"
+)
+
+// Constraint types
+const (
+	// NullConstraint indicates an undetermined constraint type
+	NullConstraint = iota
+	// PrimaryKeyConstraint is a PRIMARY KEY constraint
+	PrimaryKeyConstraint
+	// ForeignKeyConstraint is a FOREIGN KEY (or REFERENCES) constraint
+	ForeignKeyConstraint
+	// UniqueConstraint is a UNIQUE constraint
+	UniqueConstraint
+	// CheckConstraint is a CHECK constraint
+	CheckConstraint
+)
+
+// Table provides the definition of a table from a CREATE TABLE statement
+type Table struct {
+	Name        string           // the, possibly qualified, name of the table
+	Temporary   bool             // the table is a temporary table
+	IfNotExists bool             // IF NOT EXISTS was specified
+	Columns     []*Column        // the columns of the table
+	Constraints []*Constraint    // the table constraints
+	Indexes     []*Index         // the indexes defined within the table definition (MySQL KEY, INDEX)
+	Inherits    []string         // the parent tables (PostgreSQL INHERITS)
+	PartitionBy string           // the partitioning clause, as written, if any
+	Tablespace  string           // the tablespace (or MSSQL filegroup), if any
+	Options     []TableOption    // the other dialect specific table options
+	Query       *SelectStatement // the query of a CREATE TABLE ... AS SELECT
+}
+
+// Column provides the definition of a column of a table
+type Column struct {
+	Name         string        // the name of the column
+	Type         string        // the data type, as written
+	DataType     DataType      // the parsed data type
+	NotNull      bool          // NOT NULL was specified
+	Default      string        // the default expression, as written, if any
+	Identity     string        // ALWAYS, BY DEFAULT, AUTO_INCREMENT, AUTOINCREMENT, IDENTITY(seed, increment), or SERIAL
+	Generated    string        // the expression of a generated (computed) column, as written, if any
+	Collation    string        // the collation, if any
+	CharacterSet string        // the character set (MySQL), if any
+	OnUpdate     string        // the ON UPDATE expression (MySQL), if any
+	Comment      string        // the comment (MySQL), if any
+	Constraints  []*Constraint // the column constraints
+}
+
+// Constraint provides the definition of a table or column constraint
+type Constraint struct {
+	Name       string   // the name of the constraint, if specified
+	Type       int      // the type of constraint
+	Columns    []string // the constrained columns
+	Check      string   // the CHECK condition, as written
+	RefTable   string   // the table referenced by a foreign key
+	RefColumns []string // the columns referenced by a foreign key
+	OnDelete   string   // the ON DELETE action of a foreign key (CASCADE, SET NULL, ...)
+	OnUpdate   string   // the ON UPDATE action of a foreign key
+}
+
+// Index provides the definition of an index
+type Index struct {
+	Name    string   // the name of the index
+	Table   string   // the table that the index is on
+	Unique  bool     // the index is a unique index
+	Columns []string // the indexed columns (or expressions, as written)
+}
+
+// TableOption provides a dialect specific table option (ENGINE=InnoDB,
+// WITHOUT ROWID, ...)
+type TableOption struct {
+	Name  string // the, upper-case, name of the option
+	Value string // the value of the option, as written, if any
+}
+
+// ConstraintTypeName returns the string representation of the
+// constraint type
+func ConstraintTypeName(constraintType int) (s string) {
+
+	var names = map[int]string{
+		NullConstraint:       "NullConstraint",
+		PrimaryKeyConstraint: "PrimaryKeyConstraint",
+		ForeignKeyConstraint: "ForeignKeyConstraint",
+		UniqueConstraint:     "UniqueConstraint",
+		CheckConstraint:      "CheckConstraint",
+	}
+
+	if s, ok := names[constraintType]; ok {
+		return s
+	}
+	return ""
+}
+
+// the words that start a table constraint (or index) rather than a
+// column definition
+var tableConstraintWords = map[string]bool{
+	"CHECK":      true,
+	"CONSTRAINT": true,
+	"EXCLUDE":    true,
+	"FOREIGN":    true,
+	"FULLTEXT":   true,
+	"INDEX":      true,
+	"KEY":        true,
+	"LIKE":       true,
+	"PERIOD":     true,
+	"PRIMARY":    true,
+	"SPATIAL":    true,
+	"UNIQUE":     true,
+}
+
+// the words that may follow the name of a column when the data type is
+// omitted (SQLite) or that end the data type
+var columnAttributeWords = map[string]bool{
+	"AS":             true,
+	"AUTO_INCREMENT": true,
+	"AUTOINCREMENT":  true,
+	"CHARACTER":      true,
+	"CHARSET":        true,
+	"CHECK":          true,
+	"COLLATE":        true,
+	"COMMENT":        true,
+	"CONSTRAINT":     true,
+	"DEFAULT":        true,
+	"GENERATED":      true,
+	"IDENTITY":       true,
+	"NOT":            true,
+	"NULL":           true,
+	"ON":             true,
+	"PRIMARY":        true,
+	"REFERENCES":     true,
+	"UNIQUE":         true,
+}
+
+// the multi-word table options
+var multiWordOptions = []string{
+	"DEFAULT CHARACTER SET",
+	"DEFAULT CHARSET",
+	"DEFAULT COLLATE",
+	"CHARACTER SET",
+	"WITHOUT ROWID",
+	"ON COMMIT",
+}
+
+// ParseCreateTable parses the CREATE TABLE statement in the token list
+func ParseCreateTable(tl Tokens, dialect int) (t *Table, err error) {
+
+	p := newParser(&tl, dialect)
+
+	if t, err = p.parseCreateTable(); err != nil {
+		return nil, err
+	}
+
+	p.accept(";")
+	if p.k < len(p.sig) {
+		return nil, p.errorf("unexpected %s", p.found())
+	}
+	return t, nil
+}
+
+// ParseCreateIndex parses the CREATE INDEX statement in the token list
+func ParseCreateIndex(tl Tokens, dialect int) (idx *Index, err error) {
+
+	p := newParser(&tl, dialect)
+	if idx, err = p.parseCreateIndex(); err != nil {
+		return nil, err
+	}
+	return idx, nil
+}
+
+// parseCreateTable parses a CREATE TABLE statement
+func (p *parser) parseCreateTable() (t *Table, err error) {
+
+	t = &Table{}
+
+	if err = p.expectWord("CREATE"); err != nil {
+		return nil, err
+	}
+	if p.acceptWord("OR") {
+		if err = p.expectWord("REPLACE"); err != nil {
+			return nil, err
+		}
+	}
+	p.acceptWord("GLOBAL", "LOCAL")
+	if p.acceptWord("TEMPORARY", "TEMP") {
+		t.Temporary = true
+	}
+	p.acceptWord("UNLOGGED")
+	if err = p.expectWord("TABLE"); err != nil {
+		return nil, err
+	}
+	if p.word() == "IF" && tokenWord(p.peek(1)) == "NOT" && tokenWord(p.peek(2)) == "EXISTS" {
+		p.k += 3
+		t.IfNotExists = true
+	}
+
+	if t.Name = p.parseName(); t.Name == "" {
+		return nil, p.errorf("expected table name, found %s", p.found())
+	}
+	if strings.HasPrefix(t.Name, "#") {
+		// MSSQL temporary table
+		t.Temporary = true
+	}
+
+	if p.acceptWord("AS") {
+		if t.Query, err = p.parseQuery(); err != nil {
+			return nil, err
+		}
+		return t, nil
+	}
+
+	if err = p.expect("("); err != nil {
+		return nil, err
+	}
+	for {
+		if tableConstraintWords[p.word()] {
+			err = p.parseTableConstraint(t)
+		} else {
+			var c *Column
+			if c, err = p.parseColumn(); err == nil {
+				t.Columns = append(t.Columns, c)
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+		if !p.accept(",") {
+			break
+		}
+	}
+	if err = p.expect(")"); err != nil {
+		return nil, err
+	}
+
+	if err = p.parseTableOptions(t); err != nil {
+		return nil, err
+	}
+
+	if p.acceptWord("AS") {
+		if t.Query, err = p.parseQuery(); err != nil {
+			return nil, err
+		}
+	}
+	return t, nil
+}
+
+// parseCreateIndex parses a CREATE INDEX statement. The clauses that
+// follow the indexed columns (INCLUDE, WHERE, WITH, ...) are skipped.
+func (p *parser) parseCreateIndex() (idx *Index, err error) {
+
+	idx = &Index{}
+
+	if err = p.expectWord("CREATE"); err != nil {
+		return nil, err
+	}
+	if p.acceptWord("OR") {
+		if err = p.expectWord("REPLACE"); err != nil {
+			return nil, err
+		}
+	}
+	if p.acceptWord("UNIQUE") {
+		idx.Unique = true
+	}
+	p.acceptWord("FULLTEXT", "SPATIAL", "BITMAP")
+	p.acceptWord("CLUSTERED", "NONCLUSTERED")
+	if err = p.expectWord("INDEX"); err != nil {
+		return nil, err
+	}
+	p.acceptWord("CONCURRENTLY")
+	if p.word() == "IF" && tokenWord(p.peek(1)) == "NOT" && tokenWord(p.peek(2)) == "EXISTS" {
+		p.k += 3
+	}
+
+	if p.word() != "ON" {
+		if idx.Name = p.parseName(); idx.Name == "" {
+			idx.Name = p.parseIdentifier()
+		}
+	}
+	if p.acceptWord("USING") {
+		// MySQL index type
+		p.next()
+	}
+	if err = p.expectWord("ON"); err != nil {
+		return nil, err
+	}
+	p.acceptWord("ONLY")
+	if idx.Table = p.parseName(); idx.Table == "" {
+		return nil, p.errorf("expected table name, found %s", p.found())
+	}
+	if p.acceptWord("USING") {
+		// PostgreSQL index method
+		p.next()
+	}
+	if idx.Columns, err = p.parseColumnList(); err != nil {
+		return nil, err
+	}
+
+	for p.k < len(p.sig) && !p.is(";") {
+		if p.is("(") {
+			p.skipGroup()
+			continue
+		}
+		p.next()
+	}
+	p.accept(";")
+	return idx, nil
+}
+
+// parseDefault parses the default value of a column definition. The
+// expression ends before any COLLATE that is not within parentheses,
+// as that is the collation of the column ('x' COLLATE "C" NOT NULL).
+func (p *parser) parseDefault() (err error) {
+
+	end := p.k
+	for depth := 0; end < len(p.sig); end++ {
+		t := p.tl.tokens[p.sig[end]]
+		if depth == 0 && (t.tokenString == "," || t.tokenString == ")" || tokenWord(t) == "COLLATE") {
+			break
+		}
+		switch t.tokenString {
+		case "(":
+			depth++
+		case ")":
+			depth--
+		}
+	}
+
+	sig := p.sig
+	p.sig = p.sig[:end]
+	_, err = p.parseExpr()
+	p.sig = sig
+	return err
+}
+
+// parseColumn parses a column definition
+func (p *parser) parseColumn() (c *Column, err error) {
+
+	c = &Column{}
+	if c.Name = p.parseIdentifier(); c.Name == "" {
+		return nil, p.errorf("expected column name, found %s", p.found())
+	}
+
+	// the data type is optional in SQLite
+	w := p.word()
+	if w == "CHARACTER" && tokenWord(p.peek(1)) != "SET" || !columnAttributeWords[w] && !p.is(",") && !p.is(")") {
+		c.DataType = p.parseDataType(false)
+		if c.Type = c.DataType.Text; c.Type == "" {
+			return nil, p.errorf("expected data type, found %s", p.found())
+		}
+	}
+
+	switch strings.ToUpper(c.Type) {
+	case "SERIAL", "SERIAL2", "SERIAL4", "SERIAL8", "SMALLSERIAL", "BIGSERIAL":
+		if p.dialect == PostgreSQL {
+			c.Identity = "SERIAL"
+		}
+	}
+
+	name := ""
+	for p.k < len(p.sig) && !p.is(",") && !p.is(")") {
+		start := p.k
+
+		switch p.word() {
+		case "CONSTRAINT":
+			p.next()
+			name = p.parseIdentifier()
+			continue
+
+		case "NOT":
+			p.next()
+			if p.acceptWord("NULL") {
+				c.NotNull = true
+			}
+
+		case "NULL":
+			p.next()
+			c.NotNull = false
+
+		case "DEFAULT":
+			p.next()
+			k := p.k
+			if err = p.parseDefault(); err != nil {
+				return nil, err
+			}
+			c.Default = p.tokenText(k, p.k)
+
+		case "COLLATE":
+			p.next()
+			c.Collation = p.token().tokenString
+			p.next()
+
+		case "CHARACTER", "CHARSET":
+			p.next()
+			p.acceptWord("SET")
+			c.CharacterSet = p.token().tokenString
+			p.next()
+
+		case "COMMENT":
+			p.next()
+			c.Comment = p.token().tokenString
+			p.next()
+
+		case "AUTO_INCREMENT", "AUTOINCREMENT":
+			c.Identity = p.word()
+			p.next()
+
+		case "IDENTITY":
+			p.next()
+			if p.is("(") {
+				p.skipGroup()
+			}
+			c.Identity = strings.ToUpper(p.tokenText(start, p.k))
+
+		case "GENERATED":
+			p.next()
+			if p.acceptWord("ALWAYS") {
+				c.Identity = "ALWAYS"
+			} else if p.acceptWord("BY") {
+				if err = p.expectWord("DEFAULT"); err != nil {
+					return nil, err
+				}
+				c.Identity = "BY DEFAULT"
+				p.acceptWord("ON")
+				p.acceptWord("NULL")
+			}
+			if err = p.expectWord("AS"); err != nil {
+				return nil, err
+			}
+			if p.acceptWord("IDENTITY") {
+				if p.is("(") {
+					p.skipGroup()
+				}
+			} else {
+				c.Identity = ""
+				if c.Generated, err = p.parseGroupText(); err != nil {
+					return nil, err
+				}
+			}
+
+		case "AS":
+			// MSSQL and MySQL computed columns
+			p.next()
+			if c.Generated, err = p.parseGroupText(); err != nil {
+				return nil, err
+			}
+
+		case "ON":
+			if tokenWord(p.peek(1)) != "UPDATE" {
+				p.next()
+				break
+			}
+			p.k += 2
+			k := p.k
+			if _, err = p.parseExpr(); err != nil {
+				return nil, err
+			}
+			c.OnUpdate = p.tokenText(k, p.k)
+
+		case "PRIMARY", "UNIQUE", "CHECK", "REFERENCES":
+			var con *Constraint
+			if con, err = p.parseColumnConstraint(c.Name); err != nil {
+				return nil, err
+			}
+			con.Name = name
+			c.Constraints = append(c.Constraints, con)
+
+		default:
+			// other attributes (ENABLE, SPARSE, ROWGUIDCOL, VISIBLE, ...)
+			if p.is("(") {
+				p.skipGroup()
+			} else {
+				p.next()
+			}
+		}
+		name = ""
+	}
+
+	return c, nil
+}
+
+// parseColumnConstraint parses a PRIMARY KEY, UNIQUE, CHECK, or
+// REFERENCES column constraint
+func (p *parser) parseColumnConstraint(column string) (c *Constraint, err error) {
+
+	c = &Constraint{Columns: []string{column}}
+
+	switch p.word() {
+	case "PRIMARY":
+		p.next()
+		if err = p.expectWord("KEY"); err != nil {
+			return nil, err
+		}
+		c.Type = PrimaryKeyConstraint
+		p.acceptWord("ASC", "DESC")
+		p.acceptWord("CLUSTERED", "NONCLUSTERED")
+
+	case "UNIQUE":
+		p.next()
+		p.acceptWord("KEY")
+		c.Type = UniqueConstraint
+		p.acceptWord("CLUSTERED", "NONCLUSTERED")
+
+	case "CHECK":
+		p.next()
+		c.Type = CheckConstraint
+		c.Columns = nil
+		if c.Check, err = p.parseGroupText(); err != nil {
+			return nil, err
+		}
+
+	case "REFERENCES":
+		c.Type = ForeignKeyConstraint
+		if err = p.parseReferences(c); err != nil {
+			return nil, err
+		}
+	}
+
+	return c, nil
+}
+
+// parseTableConstraint parses a table constraint, or a MySQL index
+// definition, and adds it to the table
+func (p *parser) parseTableConstraint(t *Table) (err error) {
+
+	c := &Constraint{}
+	if p.acceptWord("CONSTRAINT") {
+		if !tableConstraintWords[p.word()] {
+			c.Name = p.parseIdentifier()
+		}
+	}
+
+	switch p.word() {
+	case "PRIMARY":
+		p.next()
+		if err = p.expectWord("KEY"); err != nil {
+			return err
+		}
+		c.Type = PrimaryKeyConstraint
+
+	case "UNIQUE":
+		p.next()
+		p.acceptWord("KEY", "INDEX")
+		c.Type = UniqueConstraint
+		if !p.is("(") && c.Name == "" {
+			// MySQL UNIQUE KEY name (columns)
+			c.Name = p.parseIdentifier()
+		}
+
+	case "FOREIGN":
+		p.next()
+		if err = p.expectWord("KEY"); err != nil {
+			return err
+		}
+		c.Type = ForeignKeyConstraint
+		if !p.is("(") {
+			p.parseIdentifier()
+		}
+
+	case "CHECK":
+		p.next()
+		c.Type = CheckConstraint
+		if c.Check, err = p.parseGroupText(); err != nil {
+			return err
+		}
+		t.Constraints = append(t.Constraints, c)
+		return p.skipToElementEnd()
+
+	case "KEY", "INDEX", "FULLTEXT", "SPATIAL":
+		// MySQL index definitions
+		p.next()
+		p.acceptWord("KEY", "INDEX")
+		idx := &Index{Table: t.Name}
+		if !p.is("(") {
+			idx.Name = p.parseIdentifier()
+		}
+		if idx.Columns, err = p.parseColumnList(); err != nil {
+			return err
+		}
+		t.Indexes = append(t.Indexes, idx)
+		return p.skipToElementEnd()
+
+	default:
+		// LIKE, EXCLUDE, PERIOD, ...
+		return p.skipToElementEnd()
+	}
+
+	p.acceptWord("CLUSTERED", "NONCLUSTERED")
+	if c.Columns, err = p.parseColumnList(); err != nil {
+		return err
+	}
+	if c.Type == ForeignKeyConstraint {
+		if err = p.parseReferences(c); err != nil {
+			return err
+		}
+	}
+
+	t.Constraints = append(t.Constraints, c)
+	return p.skipToElementEnd()
+}
+
+// parseReferences parses the REFERENCES clause of a foreign key
+func (p *parser) parseReferences(c *Constraint) (err error) {
+
+	if err = p.expectWord("REFERENCES"); err != nil {
+		return err
+	}
+	if c.RefTable = p.parseName(); c.RefTable == "" {
+		return p.errorf("expected table name, found %s", p.found())
+	}
+	if p.is("(") {
+		if c.RefColumns, err = p.parseColumnList(); err != nil {
+			return err
+		}
+	}
+
+	for {
+		switch {
+		case p.word() == "ON" && tokenWord(p.peek(1)) == "DELETE":
+			p.k += 2
+			c.OnDelete = p.parseReferentialAction()
+		case p.word() == "ON" && tokenWord(p.peek(1)) == "UPDATE":
+			p.k += 2
+			c.OnUpdate = p.parseReferentialAction()
+		case p.word() == "MATCH":
+			p.k += 2
+		default:
+			return nil
+		}
+	}
+}
+
+// parseReferentialAction parses the action of an ON DELETE or ON UPDATE
+// clause
+func (p *parser) parseReferentialAction() string {
+	switch p.word() {
+	case "SET":
+		p.next()
+		w := p.word()
+		p.next()
+		return "SET " + w
+	case "NO":
+		p.k += 2
+		return "NO ACTION"
+	}
+	w := p.word()
+	p.next()
+	return w
+}
+
+// parseTableOptions parses the dialect specific options that follow the
+// column definitions
+func (p *parser) parseTableOptions(t *Table) (err error) {
+
+	for p.k < len(p.sig) && !p.is(";") && p.word() != "AS" {
+		if p.accept(",") {
+			continue
+		}
+
+		switch p.word() {
+		case "INHERITS":
+			p.next()
+			if t.Inherits, err = p.parseColumnList(); err != nil {
+				return err
+			}
+			continue
+
+		case "PARTITION":
+			if tokenWord(p.peek(1)) != "BY" {
+				break
+			}
+			k := p.k
+			for p.k < len(p.sig) && !p.is("(") && !p.is(";") {
+				p.next()
+			}
+			p.skipGroup()
+			if p.is("(") {
+				// the partition definitions (MySQL, Oracle)
+				p.skipGroup()
+			}
+			t.PartitionBy = p.tokenText(k, p.k)
+			continue
+
+		case "TABLESPACE", "ON":
+			if p.word() == "ON" && tokenWord(p.peek(1)) == "COMMIT" {
+				break
+			}
+			p.next()
+			t.Tablespace = p.token().tokenString
+			p.next()
+			continue
+		}
+
+		opt := TableOption{}
+		for _, name := range multiWordOptions {
+			words := strings.Split(name, " ")
+			matches := true
+			for i, w := range words {
+				if tokenWord(p.peek(i)) != w {
+					matches = false
+					break
+				}
+			}
+			if matches {
+				opt.Name = name
+				p.k += len(words)
+				break
+			}
+		}
+		if opt.Name == "" {
+			opt.Name = strings.ToUpper(p.token().tokenString)
+			p.next()
+		}
+
+		switch {
+		case opt.Name == "ON COMMIT":
+			k := p.k
+			p.acceptWord("PRESERVE", "DELETE", "DROP")
+			p.acceptWord("ROWS")
+			opt.Value = p.tokenText(k, p.k)
+		case p.accept("="):
+			opt.Value = p.optionValue()
+		case p.is("("):
+			opt.Value = p.optionValue()
+		default:
+			switch p.token().tokenType {
+			case IdentToken, NumericToken, SingleQuotedToken, DoubleQuotedToken, BacktickQuotedToken, BracketQuotedToken:
+				opt.Value = p.optionValue()
+			}
+		}
+		t.Options = append(t.Options, opt)
+	}
+	return nil
+}
+
+// optionValue returns the value of a table option, a single token or a
+// parenthesized group
+func (p *parser) optionValue() string {
+	k := p.k
+	if p.is("(") {
+		p.skipGroup()
+	} else {
+		p.next()
+	}
+	return p.tokenText(k, p.k)
+}
+
+// parseIdentifier parses a column, constraint, or index name. Unlike
+// parseName, keywords are accepted as names.
+func (p *parser) parseIdentifier() string {
+	t := p.token()
+	switch t.tokenType {
+	case IdentToken, FunctionToken, KeywordToken, DoubleQuotedToken, BacktickQuotedToken, BracketQuotedToken:
+		p.next()
+		return t.tokenString
+	}
+	return ""
+}
+
+// parseColumnList parses a parenthesized list of column names or index
+// expressions. Sort orders and MySQL prefix lengths are not included.
+func (p *parser) parseColumnList() (cols []string, err error) {
+
+	if err = p.expect("("); err != nil {
+		return nil, err
+	}
+	for {
+		k := p.k
+		if p.is("(") {
+			// an index expression
+			p.skipGroup()
+		} else if p.parseIdentifier() == "" {
+			return nil, p.errorf("expected column name, found %s", p.found())
+		} else if p.is("(") {
+			// a function, or a MySQL prefix length
+			if p.peek(1).tokenType == NumericToken && p.peek(2).tokenString == ")" {
+				p.skipGroup()
+				cols = append(cols, p.tokenText(k, k+1))
+				k = -1
+			} else {
+				p.skipGroup()
+			}
+		}
+		if k >= 0 {
+			cols = append(cols, p.tokenText(k, p.k))
+		}
+		p.acceptWord("ASC", "DESC")
+
+		if !p.accept(",") {
+			break
+		}
+	}
+	if err = p.expect(")"); err != nil {
+		return nil, err
+	}
+	return cols, nil
+}
+
+// parseGroupText parses a parenthesized group and returns the text
+// within the parentheses
+func (p *parser) parseGroupText() (s string, err error) {
+
+	if !p.is("(") {
+		return "", p.errorf("expected \"(\", found %s", p.found())
+	}
+	k := p.k
+	p.skipGroup()
+	if p.peek(-1).tokenString != ")" {
+		return "", p.errorf("expected \")\", found %s", p.found())
+	}
+	return p.tokenText(k+1, p.k-1), nil
+}
+
+// skipToElementEnd skips to the comma or closing parenthesis that ends
+// an element of the table definition
+func (p *parser) skipToElementEnd() error {
+	for p.k < len(p.sig) && !p.is(",") && !p.is(")") {
+		if p.is("(") {
+			p.skipGroup()
+			continue
+		}
+		p.next()
+	}
+	return nil
+}
@@ -0,0 +1,292 @@
+package sqlparse
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// formatTable renders the parsed table definition as a list of its
+// columns, constraints, indexes, and options
+func formatTable(t *Table) string {
+
+	var s []string
+	add := func(format string, args ...interface{}) {
+		s = append(s, fmt.Sprintf(format, args...))
+	}
+	constraint := func(c *Constraint) string {
+		r := strings.TrimSuffix(ConstraintTypeName(c.Type), "Constraint")
+		if c.Name != "" {
+			r += " " + c.Name
+		}
+		if c.Columns != nil {
+			r += " (" + strings.Join(c.Columns, ",") + ")"
+		}
+		if c.Check != "" {
+			r += " (" + c.Check + ")"
+		}
+		if c.RefTable != "" {
+			r += " " + c.RefTable + "(" + strings.Join(c.RefColumns, ",") + ")"
+		}
+		if c.OnDelete != "" {
+			r += " delete " + c.OnDelete
+		}
+		if c.OnUpdate != "" {
+			r += " update " + c.OnUpdate
+		}
+		return r
+	}
+
+	add("table %s", t.Name)
+	if t.Temporary {
+		add("temporary")
+	}
+	if t.IfNotExists {
+		add("if not exists")
+	}
+	for _, c := range t.Columns {
+		col := c.Name + " " + c.Type
+		if c.NotNull {
+			col += " not null"
+		}
+		for _, a := range []struct{ name, value string }{
+			{"default", c.Default},
+			{"identity", c.Identity},
+			{"generated", c.Generated},
+			{"collate", c.Collation},
+			{"charset", c.CharacterSet},
+			{"on update", c.OnUpdate},
+			{"comment", c.Comment},
+		} {
+			if a.value != "" {
+				col += " " + a.name + "=" + a.value
+			}
+		}
+		for _, con := range c.Constraints {
+			col += " [" + constraint(con) + "]"
+		}
+		add("column %s", col)
+	}
+	for _, c := range t.Constraints {
+		add("constraint %s", constraint(c))
+	}
+	for _, idx := range t.Indexes {
+		add("index %s (%s)", idx.Name, strings.Join(idx.Columns, ","))
+	}
+	if t.Inherits != nil {
+		add("inherits %s", strings.Join(t.Inherits, ","))
+	}
+	if t.PartitionBy != "" {
+		add("partition %s", t.PartitionBy)
+	}
+	if t.Tablespace != "" {
+		add("tablespace %s", t.Tablespace)
+	}
+	for _, o := range t.Options {
+		add("option %s=%s", o.Name, o.Value)
+	}
+	if t.Query != nil {
+		add("query")
+	}
+	return strings.Join(s, "\n")
+}
+
+func TestParseCreateTable(t *testing.T) {
+
+	cases := []struct {
+		dialect  int
+		input    string
+		expected string
+	}{
+		{PostgreSQL, `CREATE TABLE IF NOT EXISTS public.orders (
+    id bigserial PRIMARY KEY,
+    customer_id integer NOT NULL REFERENCES customers (id) ON DELETE CASCADE,
+    status varchar(20) DEFAULT 'new'::character varying NOT NULL,
+    name text COLLATE "C",
+    code text DEFAULT 'x' COLLATE "C" NOT NULL,
+    total numeric(10, 2) CHECK (total >= 0),
+    created timestamp with time zone DEFAULT now(),
+    seq int GENERATED BY DEFAULT AS IDENTITY (START WITH 10),
+    CONSTRAINT orders_status_uq UNIQUE (customer_id, status)
+) INHERITS (base_orders) PARTITION BY RANGE (created) WITH (fillfactor = 70) TABLESPACE fast;`,
+			`table public.orders
+if not exists
+column id bigserial identity=SERIAL [PrimaryKey (id)]
+column customer_id integer not null [ForeignKey (customer_id) customers(id) delete CASCADE]
+column status varchar(20) not null default='new'::character varying
+column name text collate="C"
+column code text not null default='x' collate="C"
+column total numeric(10, 2) [Check (total >= 0)]
+column created timestamp with time zone default=now()
+column seq int identity=BY DEFAULT
+constraint Unique orders_status_uq (customer_id,status)
+inherits base_orders
+partition PARTITION BY RANGE(created)
+tablespace fast
+option WITH=(fillfactor = 70)`},
+
+		{MySQL, "CREATE TABLE `users` (\n" +
+			"  `id` int(11) unsigned NOT NULL AUTO_INCREMENT,\n" +
+			"  `email` varchar(255) CHARACTER SET utf8mb4 COLLATE utf8mb4_bin NOT NULL COMMENT 'login',\n" +
+			"  `code` varchar(10) DEFAULT 'x' COLLATE utf8mb4_bin,\n" +
+			"  `updated` datetime DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,\n" +
+			"  `full_name` varchar(100) AS (concat(`first`, ' ', `last`)) STORED,\n" +
+			"  PRIMARY KEY (`id`),\n" +
+			"  UNIQUE KEY `email_uq` (`email`),\n" +
+			"  KEY `name_idx` (`full_name`(20), `id`),\n" +
+			"  CONSTRAINT `fk_org` FOREIGN KEY (`org_id`) REFERENCES `orgs` (`id`) ON DELETE SET NULL ON UPDATE NO ACTION\n" +
+			") ENGINE=InnoDB AUTO_INCREMENT=42 DEFAULT CHARSET=utf8mb4 COMMENT='the users'",
+			"table `users`\n" +
+				"column `id` int(11) unsigned not null identity=AUTO_INCREMENT\n" +
+				"column `email` varchar(255) not null collate=utf8mb4_bin charset=utf8mb4 comment='login'\n" +
+				"column `code` varchar(10) default='x' collate=utf8mb4_bin\n" +
+				"column `updated` datetime default=CURRENT_TIMESTAMP on update=CURRENT_TIMESTAMP\n" +
+				"column `full_name` varchar(100) generated=concat(`first`, ' ', `last`)\n" +
+				"constraint PrimaryKey (`id`)\n" +
+				"constraint Unique `email_uq` (`email`)\n" +
+				"constraint ForeignKey `fk_org` (`org_id`) `orgs`(`id`) delete SET NULL update NO ACTION\n" +
+				"index `name_idx` (`full_name`,`id`)\n" +
+				"option ENGINE=InnoDB\n" +
+				"option AUTO_INCREMENT=42\n" +
+				"option DEFAULT CHARSET=utf8mb4\n" +
+				"option COMMENT='the users'"},
+
+		{MariaDB, "CREATE OR REPLACE TABLE t (a int NOT NULL, b int, INDEX (b)) ENGINE=Aria",
+			`table t
+column a int not null
+column b int
+index  (b)
+option ENGINE=Aria`},
+
+		{MSSQL, `CREATE TABLE [dbo].[Orders] (
+    [Id] [int] IDENTITY(1,1) NOT NULL,
+    [Total] money NULL,
+    [Tax] AS ([Total] * 0.2) PERSISTED,
+    [Code] nvarchar(10) COLLATE Latin1_General_CI_AS CONSTRAINT DF_Code DEFAULT ('x'),
+    CONSTRAINT [PK_Orders] PRIMARY KEY CLUSTERED ([Id] ASC) WITH (PAD_INDEX = OFF) ON [PRIMARY]
+) ON [PRIMARY]`,
+			`table [dbo].[Orders]
+column [Id] [int] not null identity=IDENTITY(1, 1)
+column [Total] money
+column [Tax]  generated=[Total] * 0.2
+column [Code] nvarchar(10) default=('x') collate=Latin1_General_CI_AS
+constraint PrimaryKey [PK_Orders] ([Id])
+tablespace [PRIMARY]`},
+
+		{MSSQL, "CREATE TABLE #work (id int)",
+			`table #work
+temporary
+column id int`},
+
+		{Oracle, `CREATE GLOBAL TEMPORARY TABLE hr.emp (
+    id NUMBER(10) GENERATED ALWAYS AS IDENTITY,
+    name VARCHAR2(100 CHAR) NOT NULL,
+    dept_id NUMBER CONSTRAINT emp_dept_fk REFERENCES dept (id),
+    CONSTRAINT emp_pk PRIMARY KEY (id) USING INDEX TABLESPACE idx,
+    CONSTRAINT emp_name_ck CHECK (name <> 'x')
+) TABLESPACE users PCTFREE 10 PARTITION BY RANGE (id) (PARTITION p1 VALUES LESS THAN (100)) ON COMMIT PRESERVE ROWS`,
+			`table hr.emp
+temporary
+column id NUMBER(10) identity=ALWAYS
+column name VARCHAR2(100 CHAR) not null
+column dept_id NUMBER [ForeignKey emp_dept_fk (dept_id) dept(id)]
+constraint PrimaryKey emp_pk (id)
+constraint Check emp_name_ck (name <> 'x')
+partition PARTITION BY RANGE(id)(PARTITION p1 VALUES LESS THAN(100))
+tablespace users
+option PCTFREE=10
+option ON COMMIT=PRESERVE ROWS`},
+
+		{SQLite, `CREATE TEMP TABLE kv (
+    k PRIMARY KEY,
+    v TEXT DEFAULT '' NOT NULL,
+    n INTEGER UNIQUE ON CONFLICT REPLACE,
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    FOREIGN KEY (n) REFERENCES other (id) MATCH FULL ON UPDATE RESTRICT
+) WITHOUT ROWID, STRICT`,
+			`table kv
+temporary
+column k  [PrimaryKey (k)]
+column v TEXT not null default=''
+column n INTEGER [Unique (n)]
+column id INTEGER identity=AUTOINCREMENT [PrimaryKey (id)]
+constraint ForeignKey (n) other(id) update RESTRICT
+option WITHOUT ROWID=
+option STRICT=`},
+
+		{StandardSQL, "CREATE TABLE t (a integer, b double precision NOT NULL, PRIMARY KEY (a, b))",
+			`table t
+column a integer
+column b double precision not null
+constraint PrimaryKey (a,b)`},
+
+		{PostgreSQL, "CREATE TABLE t2 AS SELECT * FROM t",
+			`table t2
+query`},
+	}
+
+	for _, c := range cases {
+		tl := ParseStatements(c.input, c.dialect)
+		table, err := ParseCreateTable(tl, c.dialect)
+		if err != nil {
+			t.Errorf("%s %q: unexpected error: %s", SQLDialectName(c.dialect), c.input, err)
+			continue
+		}
+		if got := formatTable(table); got != c.expected {
+			t.Errorf("%s:\n  expected\n%s\n  got\n%s", SQLDialectName(c.dialect), c.expected, got)
+		}
+	}
+}
+
+func TestParseCreateTableErrors(t *testing.T) {
+
+	cases := []struct {
+		input    string
+		expected string
+	}{
+		{"CREATE VIEW v AS SELECT 1", `line 1, column 8: expected TABLE, found "VIEW"`},
+		{"CREATE TABLE t (a int", `line 1, column 22: expected ")", found end of input`},
+		{"CREATE TABLE t (a int CHECK a > 0)", `line 1, column 29: expected "(", found "a"`},
+		{"CREATE TABLE t (a int) x y;z", `line 1, column 28: unexpected "z"`},
+	}
+
+	for _, c := range cases {
+		_, err := ParseCreateTable(ParseStatements(c.input, PostgreSQL), PostgreSQL)
+		if err == nil {
+			t.Errorf("%q: expected an error", c.input)
+			continue
+		}
+		if err.Error() != c.expected {
+			t.Errorf("%q:\n  expected %s\n  got      %s", c.input, c.expected, err)
+		}
+	}
+}
+
+func TestParseCreateIndex(t *testing.T) {
+
+	cases := []struct {
+		dialect  int
+		input    string
+		expected string
+	}{
+		{PostgreSQL, "CREATE UNIQUE INDEX CONCURRENTLY IF NOT EXISTS orders_ix ON ONLY public.orders USING btree (customer_id, lower(status) DESC) WHERE total > 0;", "UNIQUE orders_ix public.orders (customer_id,lower(status))"},
+		{MySQL, "CREATE INDEX name_idx USING BTREE ON `users` (`name`(10), id)", " name_idx `users` (`name`,id)"},
+		{MSSQL, "CREATE NONCLUSTERED INDEX [IX_Orders] ON [dbo].[Orders] ([Total] ASC) INCLUDE ([Id]) ON [PRIMARY]", " [IX_Orders] [dbo].[Orders] ([Total])"},
+		{Oracle, "CREATE BITMAP INDEX hr.emp_ix ON hr.emp (dept_id) TABLESPACE idx", " hr.emp_ix hr.emp (dept_id)"},
+	}
+
+	for _, c := range cases {
+		idx, err := ParseCreateIndex(ParseStatements(c.input, c.dialect), c.dialect)
+		if err != nil {
+			t.Errorf("%s %q: unexpected error: %s", SQLDialectName(c.dialect), c.input, err)
+			continue
+		}
+		got := fmt.Sprintf(" %s %s (%s)", idx.Name, idx.Table, strings.Join(idx.Columns, ","))
+		if idx.Unique {
+			got = "UNIQUE" + got
+		}
+		if got != c.expected {
+			t.Errorf("%s %q:\n  expected %s\n  got      %s", SQLDialectName(c.dialect), c.input, c.expected, got)
+		}
+	}
+}
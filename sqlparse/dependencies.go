@@ -0,0 +1,524 @@
+package sqlparse
+
+/*
+
+dependencies.go provides the functionality for determining the order in
+which the objects defined by a set of DDL scripts need to be created.
+
+*/
+
+import (
+	"regexp"
+	"strings"
+)
+
+// DBObject provides a database object that is defined by a CREATE
+// statement
+type DBObject struct {
+	Kind       string   // the kind of object (TABLE, VIEW, FUNCTION, ...)
+	Name       string   // the name of the object, as written in the definition
+	Source     string   // the source (file name, etc.) of the definition
+	Dialect    int      // the SQL dialect of the definition
+	References []string // the names of the objects that the definition refers to, as written
+}
+
+// DependencyGraph tracks the objects that are defined by a set of
+// scripts and the dependencies between them
+type DependencyGraph struct {
+	objects  []*DBObject
+	dialects []int                          // the dialects of the added token lists, in the order that they were first added
+	keys     map[int]map[string]*DBObject   // map[dialect]the objects by name
+	names    map[int]map[string][]*DBObject // map[dialect]the objects by unqualified name
+}
+
+// DependencyCycleError indicates that the objects could not be ordered
+// because of a circular dependency
+type DependencyCycleError struct {
+	Cycle []*DBObject // the objects that form the cycle, in dependency order
+}
+
+// Error implements the error interface for the dependency cycle error
+func (e *DependencyCycleError) Error() string {
+	var names []string
+	for _, o := range e.Cycle {
+		names = append(names, o.Name)
+	}
+	if len(names) > 0 {
+		names = append(names, names[0])
+	}
+	return "dependency cycle: " + strings.Join(names, " -> ")
+}
+
+// the kinds of objects that have procedural bodies that may contain
+// statement terminators
+var routineKinds = map[string]bool{
+	"FUNCTION":     true,
+	"PACKAGE":      true,
+	"PACKAGE BODY": true,
+	"PROCEDURE":    true,
+	"TRIGGER":      true,
+	"TYPE BODY":    true,
+}
+
+// the kinds of objects that are defined on a table (... ON table)
+var onTableKinds = map[string]bool{
+	"INDEX":   true,
+	"POLICY":  true,
+	"RULE":    true,
+	"TRIGGER": true,
+}
+
+// the words that may follow END without closing a BEGIN or CASE block
+var endBlockWords = map[string]bool{
+	"FOR":    true,
+	"IF":     true,
+	"LOOP":   true,
+	"REPEAT": true,
+	"WHILE":  true,
+}
+
+var reDollarQuote = regexp.MustCompile(`^\$[A-Za-z_0-9]*\$$`)
+
+// NewDependencyGraph creates an empty dependency graph
+func NewDependencyGraph() *DependencyGraph {
+	return &DependencyGraph{
+		keys:  make(map[int]map[string]*DBObject),
+		names: make(map[int]map[string][]*DBObject),
+	}
+}
+
+// Add adds the objects that are defined by the CREATE statements in the
+// token list. Objects that are defined more than once (CREATE OR
+// REPLACE) are only added once, the references of the definitions are
+// combined. The names of the objects are compared per the dialect of the
+// token list that defines them (see CanonicalName).
+func (g *DependencyGraph) Add(source string, tl Tokens, dialect int) {
+
+	if _, ok := g.keys[dialect]; !ok {
+		g.dialects = append(g.dialects, dialect)
+		g.keys[dialect] = make(map[string]*DBObject)
+		g.names[dialect] = make(map[string][]*DBObject)
+	}
+	keys, names := g.keys[dialect], g.names[dialect]
+
+	for _, r := range tl.definitionRanges(dialect) {
+		stmt := tl.Slice(r.Start, r.End)
+		c := Classify(stmt, dialect)
+		if c.Verb != "CREATE" || c.Object == "" {
+			continue
+		}
+
+		p := newParser(&stmt, dialect)
+		name := p.parseObjectName(c.Object)
+		if name == "" {
+			continue
+		}
+		refs := p.objectReferences(c.Object, name)
+
+		key := objectKey(name, dialect)
+		if o, ok := keys[key]; ok {
+			o.References = appendNew(o.References, refs...)
+			continue
+		}
+
+		o := &DBObject{
+			Kind:       c.Object,
+			Name:       name,
+			Source:     source,
+			Dialect:    dialect,
+			References: refs,
+		}
+		g.objects = append(g.objects, o)
+		keys[key] = o
+		short := objectKey(unqualifiedName(name), dialect)
+		names[short] = append(names[short], o)
+	}
+}
+
+// Objects returns the objects in the order that they were added
+func (g *DependencyGraph) Objects() []*DBObject {
+	return append([]*DBObject(nil), g.objects...)
+}
+
+// Lookup returns the object that a name refers to. Unqualified names
+// match a qualified object name when there is only one such object. The
+// name is compared to the names of the objects per the dialect of each
+// object, in the order that the dialects were added.
+func (g *DependencyGraph) Lookup(name string) *DBObject {
+	return g.lookup(name, NullDialect)
+}
+
+// lookup returns the object that a name, of the specified dialect,
+// refers to. Objects of the dialect take precedence over those of the
+// other dialects.
+func (g *DependencyGraph) lookup(name string, dialect int) *DBObject {
+
+	dialects := g.dialects
+	if _, ok := g.keys[dialect]; ok {
+		dialects = append([]int{dialect}, dialects...)
+	}
+
+	for _, d := range dialects {
+		if o, ok := g.keys[d][objectKey(name, d)]; ok {
+			return o
+		}
+	}
+	if strings.Contains(name, ".") {
+		return nil
+	}
+
+	var match *DBObject
+	for _, d := range dialects {
+		for _, o := range g.names[d][objectKey(name, d)] {
+			if match != nil && o != match {
+				return nil
+			}
+			match = o
+		}
+	}
+	return match
+}
+
+// Dependencies returns the objects in the graph that the object refers
+// to. References to objects that are not in the graph are ignored.
+func (g *DependencyGraph) Dependencies(o *DBObject) (deps []*DBObject) {
+
+	seen := map[*DBObject]bool{o: true}
+	for _, ref := range o.References {
+		if d := g.lookup(ref, o.Dialect); d != nil && !seen[d] {
+			seen[d] = true
+			deps = append(deps, d)
+		}
+	}
+	return deps
+}
+
+// Cycles returns the circular dependencies in the graph, one cycle for
+// each group of mutually dependent objects
+func (g *DependencyGraph) Cycles() (cycles [][]*DBObject) {
+
+	// Tarjan's strongly connected components
+	index := make(map[*DBObject]int)
+	low := make(map[*DBObject]int)
+	onStack := make(map[*DBObject]bool)
+	var stack []*DBObject
+	var components [][]*DBObject
+
+	var connect func(o *DBObject)
+	connect = func(o *DBObject) {
+		index[o] = len(index)
+		low[o] = index[o]
+		stack = append(stack, o)
+		onStack[o] = true
+
+		for _, d := range g.Dependencies(o) {
+			if _, ok := index[d]; !ok {
+				connect(d)
+				if low[d] < low[o] {
+					low[o] = low[d]
+				}
+			} else if onStack[d] && index[d] < low[o] {
+				low[o] = index[d]
+			}
+		}
+
+		if low[o] == index[o] {
+			var c []*DBObject
+			for {
+				n := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				onStack[n] = false
+				c = append(c, n)
+				if n == o {
+					break
+				}
+			}
+			if len(c) > 1 {
+				components = append(components, c)
+			}
+		}
+	}
+
+	for _, o := range g.objects {
+		if _, ok := index[o]; !ok {
+			connect(o)
+		}
+	}
+
+	for _, c := range components {
+		cycles = append(cycles, g.cyclePath(c))
+	}
+	return cycles
+}
+
+// cyclePath returns a cycle through the objects of a strongly connected
+// component, starting at the first defined object of the component
+func (g *DependencyGraph) cyclePath(component []*DBObject) []*DBObject {
+
+	members := make(map[*DBObject]bool)
+	for _, o := range component {
+		members[o] = true
+	}
+	var start *DBObject
+	for _, o := range g.objects {
+		if members[o] {
+			start = o
+			break
+		}
+	}
+
+	// breadth first search for the shortest path back to the start
+	prev := map[*DBObject]*DBObject{}
+	queue := []*DBObject{start}
+	for len(queue) > 0 {
+		o := queue[0]
+		queue = queue[1:]
+		for _, d := range g.Dependencies(o) {
+			if !members[d] {
+				continue
+			}
+			if d == start {
+				var path []*DBObject
+				for n := o; n != start; n = prev[n] {
+					path = append([]*DBObject{n}, path...)
+				}
+				return append([]*DBObject{start}, path...)
+			}
+			if _, ok := prev[d]; !ok {
+				prev[d] = o
+				queue = append(queue, d)
+			}
+		}
+	}
+	return component
+}
+
+// DeploymentOrder returns the objects ordered so that each object
+// follows the objects that it depends on. Objects that do not depend on
+// each other remain in the order that they were added. If there is a
+// circular dependency then a DependencyCycleError is returned.
+func (g *DependencyGraph) DeploymentOrder() (order []*DBObject, err error) {
+
+	if cycles := g.Cycles(); len(cycles) > 0 {
+		return nil, &DependencyCycleError{Cycle: cycles[0]}
+	}
+
+	placed := make(map[*DBObject]bool)
+	for len(order) < len(g.objects) {
+		for _, o := range g.objects {
+			if placed[o] {
+				continue
+			}
+			ready := true
+			for _, d := range g.Dependencies(o) {
+				if !placed[d] {
+					ready = false
+					break
+				}
+			}
+			if ready {
+				placed[o] = true
+				order = append(order, o)
+				break
+			}
+		}
+	}
+	return order, nil
+}
+
+// definitionRanges returns the statement ranges of the token list with
+// the statements that make up the body of a function, procedure, or
+// trigger merged into the range of the CREATE statement. Bodies end at
+// the next batch separator for MSSQL and Oracle, at the closing
+// dollar-quote for PostgreSQL, and at the END that matches the first
+// BEGIN otherwise.
+func (d *Tokens) definitionRanges(dialect int) (r []StatementRange) {
+
+	ranges := d.StatementRanges(dialect)
+	for i := 0; i < len(ranges); i++ {
+		cur := ranges[i]
+		c := Classify(d.Slice(cur.Start, cur.End), dialect)
+		if c.Verb == "CREATE" && routineKinds[c.Object] {
+			for i+1 < len(ranges) && !d.isBodyComplete(cur, dialect) {
+				i++
+				cur.End = ranges[i].End
+			}
+		}
+		r = append(r, cur)
+	}
+	return r
+}
+
+// isBodyComplete determines whether or not the range contains the
+// complete body of a function, procedure, or trigger
+func (d *Tokens) isBodyComplete(r StatementRange, dialect int) bool {
+
+	switch dialect {
+	case MSSQL, Oracle:
+		for j := r.End - 1; j < d.length; j++ {
+			if d.isBatchSeparator(j, dialect) {
+				return true
+			}
+			if j >= r.End && SignificantTokens(d.tokens[j]) {
+				return false
+			}
+		}
+		return true
+
+	case PostgreSQL:
+		quotes := make(map[string]int)
+		for j := r.Start; j < r.End; j++ {
+			if t := d.tokens[j]; reDollarQuote.MatchString(t.tokenString) {
+				quotes[t.tokenString]++
+			}
+		}
+		for _, n := range quotes {
+			if n%2 != 0 {
+				return false
+			}
+		}
+		return true
+	}
+
+	depth := 0
+	for j := r.Start; j < r.End; j++ {
+		switch tokenWord(d.tokens[j]) {
+		case "BEGIN", "CASE":
+			depth++
+		case "END":
+			next := Token{}
+			for k := j + 1; k < r.End; k++ {
+				if SignificantTokens(d.tokens[k]) {
+					next = d.tokens[k]
+					break
+				}
+			}
+			if !endBlockWords[tokenWord(next)] {
+				depth--
+			}
+		}
+	}
+	return depth <= 0
+}
+
+// parseObjectName parses the name of the object that is being created.
+// The parser is positioned after the name.
+func (p *parser) parseObjectName(kind string) (name string) {
+
+	words := strings.Split(kind, " ")
+	for ; p.k < len(p.sig); p.k++ {
+		matches := true
+		for i, w := range words {
+			if tokenWord(p.peek(i)) != w {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			p.k += len(words)
+			break
+		}
+	}
+
+	if p.word() == "IF" && tokenWord(p.peek(1)) == "NOT" && tokenWord(p.peek(2)) == "EXISTS" {
+		p.k += 3
+	}
+	if kind == "INDEX" {
+		p.acceptWord("CONCURRENTLY")
+	}
+	if name = p.parseName(); name == "" {
+		name = p.parseIdentifier()
+	}
+	return name
+}
+
+// objectReferences returns the names of the objects that are referred
+// to by the remainder of the definition: the tables that are read from
+// or written to, the called functions, the tables that indexes and
+// triggers are defined on, and the tables that are referenced by
+// foreign keys.
+func (p *parser) objectReferences(kind, name string) (refs []string) {
+
+	self := objectKey(name, p.dialect)
+	add := func(s string) {
+		if s != "" && objectKey(s, p.dialect) != self {
+			refs = appendNew(refs, s)
+		}
+	}
+
+	if onTableKinds[kind] {
+		for k := p.k; k < len(p.sig); k++ {
+			if tokenWord(p.tl.tokens[p.sig[k]]) == "ON" {
+				q := parser{tl: p.tl, sig: p.sig, k: k + 1, dialect: p.dialect}
+				add(q.parseName())
+				break
+			}
+		}
+	}
+
+	for _, ref := range TableReferences(p.tl.Slice(p.pos(), p.tl.length), p.dialect) {
+		add(ref.Name)
+	}
+
+	if kind == "TABLE" {
+		if t, err := ParseCreateTable(*p.tl, p.dialect); err == nil {
+			for _, n := range t.Inherits {
+				add(n)
+			}
+			for _, c := range t.Columns {
+				if n := typeBaseName(c.Type); !IsKeyword(n, p.dialect) {
+					add(n)
+				}
+				for _, con := range c.Constraints {
+					add(con.RefTable)
+				}
+			}
+			for _, con := range t.Constraints {
+				add(con.RefTable)
+			}
+		}
+	}
+
+	// function calls
+	for p.k < len(p.sig) {
+		if !isNameToken(p.token(), p.dialect) {
+			p.next()
+			continue
+		}
+		if n := p.parseName(); p.is("(") {
+			add(n)
+		}
+	}
+	return refs
+}
+
+// typeBaseName returns the name of a data type without the length,
+// precision, or array suffix
+func typeBaseName(s string) string {
+	if i := strings.IndexAny(s, "( ["); i >= 0 {
+		return s[:i]
+	}
+	return s
+}
+
+// objectKey returns the key for comparing object names in the dialect
+func objectKey(name string, dialect int) string {
+	return CanonicalObjectName(name, dialect)
+}
+
+// appendNew appends the strings that are not already in the list
+func appendNew(l []string, s ...string) []string {
+	for _, v := range s {
+		found := false
+		for _, x := range l {
+			if x == v {
+				found = true
+				break
+			}
+		}
+		if !found {
+			l = append(l, v)
+		}
+	}
+	return l
+}
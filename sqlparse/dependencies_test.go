@@ -0,0 +1,177 @@
+package sqlparse
+
+import (
+	"strings"
+	"testing"
+)
+
+func formatObjects(l []*DBObject) string {
+	var s []string
+	for _, o := range l {
+		s = append(s, o.Name)
+	}
+	return strings.Join(s, ", ")
+}
+
+func TestDeploymentOrder(t *testing.T) {
+
+	cases := []struct {
+		dialect  int
+		scripts  []string
+		expected string
+	}{
+		{PostgreSQL, []string{
+			`CREATE TRIGGER orders_audit AFTER INSERT ON app.orders
+    FOR EACH ROW EXECUTE FUNCTION app.audit();`,
+			`CREATE OR REPLACE FUNCTION app.audit() RETURNS trigger AS $$
+BEGIN
+    INSERT INTO app.audit_log (tbl) VALUES (TG_TABLE_NAME);
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;`,
+			`CREATE VIEW app.order_totals AS
+    SELECT c.name, app.total(o.id) FROM app.orders o JOIN customers c ON c.id = o.customer_id;`,
+			`CREATE FUNCTION app.total(id integer) RETURNS numeric AS $body$
+    SELECT sum(amount) FROM app.order_lines WHERE order_id = id;
+$body$ LANGUAGE sql;`,
+			`CREATE TABLE app.order_lines (order_id integer REFERENCES app.orders (id), amount app.money_t);
+CREATE TABLE app.orders (id integer PRIMARY KEY, customer_id integer REFERENCES app.customers);
+CREATE TABLE app.customers (id integer PRIMARY KEY, name text);
+CREATE TABLE app.audit_log (tbl text);
+CREATE DOMAIN app.money_t AS numeric(12, 2);
+CREATE INDEX orders_customer_ix ON app.orders (customer_id);`,
+		}, "app.customers, app.orders, app.audit_log, app.audit, orders_audit, app.money_t, app.order_lines, app.total, app.order_totals, orders_customer_ix"},
+
+		{MSSQL, []string{
+			`CREATE PROCEDURE dbo.archive AS
+BEGIN
+    INSERT INTO dbo.archive_log SELECT * FROM dbo.events;
+    DELETE FROM dbo.events;
+END;
+GO
+CREATE TABLE dbo.events (id int)
+GO
+CREATE TABLE dbo.archive_log (id int)
+GO
+`,
+		}, "dbo.events, dbo.archive_log, dbo.archive"},
+
+		{SQLite, []string{
+			`CREATE TRIGGER kv_touch AFTER UPDATE ON kv
+BEGIN
+    UPDATE kv_meta SET changed = CASE WHEN 1 THEN 1 END;
+    INSERT INTO kv_history SELECT * FROM kv;
+END;
+CREATE TABLE kv (k TEXT PRIMARY KEY, v TEXT);
+CREATE TABLE kv_meta (changed INTEGER);
+CREATE TABLE kv_history (k TEXT, v TEXT);`,
+		}, "kv, kv_meta, kv_history, kv_touch"},
+
+		{Oracle, []string{
+			`CREATE OR REPLACE PROCEDURE hr.raise AS
+BEGIN
+    UPDATE hr.emp SET sal = sal * 1.1;
+    COMMIT;
+END;
+/
+CREATE TABLE hr.emp (id NUMBER, sal NUMBER);
+`,
+		}, "hr.emp, hr.raise"},
+
+		{Oracle, []string{
+			`CREATE OR REPLACE PROCEDURE hr.raise AS
+BEGIN
+    UPDATE "HR"."EMP" SET sal = sal * 1.1;
+    COMMIT;
+END;
+/
+CREATE TABLE hr.emp (id NUMBER, sal NUMBER);
+`,
+		}, "hr.emp, hr.raise"},
+	}
+
+	for _, c := range cases {
+		g := NewDependencyGraph()
+		for i, s := range c.scripts {
+			g.Add(string(rune('a'+i))+".sql", ParseStatements(s, c.dialect), c.dialect)
+		}
+		order, err := g.DeploymentOrder()
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", SQLDialectName(c.dialect), err)
+			continue
+		}
+		if got := formatObjects(order); got != c.expected {
+			t.Errorf("%s:\n  expected %s\n  got      %s", SQLDialectName(c.dialect), c.expected, got)
+		}
+	}
+}
+
+func TestDependencies(t *testing.T) {
+
+	g := NewDependencyGraph()
+	g.Add("schema.sql", ParseStatements(`CREATE TABLE "Orders" (id int, customer_id int REFERENCES Customers);
+CREATE TABLE customers (id int);
+CREATE VIEW v AS SELECT * FROM "Orders" JOIN other.customers USING (id);`, PostgreSQL), PostgreSQL)
+
+	cases := map[string]string{
+		`"Orders"`:  "customers",
+		"customers": "",
+		"v":         `"Orders"`,
+	}
+	for name, expected := range cases {
+		o := g.Lookup(name)
+		if o == nil {
+			t.Errorf("%s: not found", name)
+			continue
+		}
+		if got := formatObjects(g.Dependencies(o)); got != expected {
+			t.Errorf("%s: expected %q, got %q", name, expected, got)
+		}
+	}
+}
+
+func TestDependenciesMixedDialects(t *testing.T) {
+
+	g := NewDependencyGraph()
+	g.Add("pg.sql", ParseStatements("CREATE TABLE emp (id int);\nCREATE VIEW emp_v AS SELECT * FROM EMP;", PostgreSQL), PostgreSQL)
+	g.Add("ora.sql", ParseStatements("CREATE TABLE dept (id NUMBER);\nCREATE VIEW dept_v AS SELECT * FROM \"DEPT\";", Oracle), Oracle)
+
+	for _, name := range []string{"emp", "EMP", "dept", "DEPT", `"DEPT"`} {
+		if g.Lookup(name) == nil {
+			t.Errorf("%s: not found", name)
+		}
+	}
+	if o := g.Lookup(`"Emp"`); o != nil {
+		t.Errorf(`"Emp": expected no match, got %s`, o.Name)
+	}
+
+	for name, expected := range map[string]string{"emp_v": "emp", "dept_v": "dept"} {
+		o := g.Lookup(name)
+		if o == nil {
+			t.Errorf("%s: not found", name)
+			continue
+		}
+		if got := formatObjects(g.Dependencies(o)); got != expected {
+			t.Errorf("%s: expected %q, got %q", name, expected, got)
+		}
+	}
+}
+
+func TestDependencyCycles(t *testing.T) {
+
+	g := NewDependencyGraph()
+	g.Add("a.sql", ParseStatements(`CREATE TABLE a (id int, b_id int REFERENCES b (id));
+CREATE TABLE b (id int, c_id int REFERENCES c (id));
+CREATE TABLE c (id int, a_id int REFERENCES a (id));
+CREATE TABLE d (id int, d_id int REFERENCES d (id));`, PostgreSQL), PostgreSQL)
+
+	cycles := g.Cycles()
+	if len(cycles) != 1 || formatObjects(cycles[0]) != "a, b, c" {
+		t.Errorf("expected the cycle a, b, c, got %v", cycles)
+	}
+
+	_, err := g.DeploymentOrder()
+	if err == nil || err.Error() != "dependency cycle: a -> b -> c -> a" {
+		t.Errorf("expected a dependency cycle error, got %v", err)
+	}
+}
@@ -0,0 +1,218 @@
+package sqlparse
+
+/*
+
+detect.go provides the functionality for guessing the SQL dialect of
+SQL of unknown origin.
+
+*/
+
+import (
+	"sort"
+	"strings"
+)
+
+// DialectScore provides the likelihood that SQL is of a dialect
+type DialectScore struct {
+	Dialect    int      // the SQL dialect
+	Score      int      // the sum of the weights of the signals that were found, less the number of unrecognized tokens
+	Confidence float64  // the share of the positive scores of all of the dialects that is held by the dialect (0 to 1)
+	Signals    []string // the signals that were found for the dialect, and the unrecognized tokens
+}
+
+// dialectSignal provides a feature of the SQL that indicates the
+// dialects that it belongs to
+type dialectSignal struct {
+	name    string                       // the description of the signal
+	weights map[int]int                  // map[dialect]the weight of the signal for the dialect
+	match   func(tl *Tokens, i int) bool // determines whether or not the token at index i is the signal
+}
+
+// the dialects to detect, in order of preference for equal scores
+var detectDialects = []int{
+	StandardSQL,
+	PostgreSQL,
+	SQLite,
+	MySQL,
+	Oracle,
+	MSSQL,
+	MariaDB,
+}
+
+// the structural signals (quoting, comments, operators, and batch
+// separators). Those that depend on the tokenizing rules of a dialect,
+// such as back-tick quoted identifiers, are only found when tokenizing
+// for the dialects that have those rules.
+var dialectSignals = []dialectSignal{
+	{
+		name:    "back-tick quoted identifiers",
+		weights: map[int]int{MySQL: 3, MariaDB: 3, SQLite: 1},
+		match: func(tl *Tokens, i int) bool {
+			return tl.tokens[i].tokenType == BacktickQuotedToken
+		},
+	},
+	{
+		name:    "square bracket quoted identifiers",
+		weights: map[int]int{MSSQL: 3, SQLite: 1},
+		match: func(tl *Tokens, i int) bool {
+			return tl.tokens[i].tokenType == BracketQuotedToken
+		},
+	},
+	{
+		name:    "# comments",
+		weights: map[int]int{MySQL: 3, MariaDB: 3},
+		match: func(tl *Tokens, i int) bool {
+			t := tl.tokens[i]
+			return t.tokenType == PoundLineCommentToken || (t.tokenType == LineCommentToken && strings.HasPrefix(t.tokenString, "#"))
+		},
+	},
+	{
+		name:    "dollar quoted bodies",
+		weights: map[int]int{PostgreSQL: 3},
+		match: func(tl *Tokens, i int) bool {
+			return tl.tokens[i].tokenType == OtherToken && reDollarQuote.MatchString(tl.tokens[i].tokenString)
+		},
+	},
+	{
+		name:    ":: casts",
+		weights: map[int]int{PostgreSQL: 3},
+		match: func(tl *Tokens, i int) bool {
+			return tl.tokens[i].tokenType == OperatorToken && tl.tokens[i].tokenString == "::"
+		},
+	},
+	{
+		name:    "@ variables",
+		weights: map[int]int{MSSQL: 2},
+		match: func(tl *Tokens, i int) bool {
+			return tl.tokens[i].tokenType == IdentToken && strings.HasPrefix(tl.tokens[i].tokenString, "@")
+		},
+	},
+	{
+		name:    "GO batch separators",
+		weights: map[int]int{MSSQL: 3},
+		match: func(tl *Tokens, i int) bool {
+			return tl.isBatchSeparator(i, MSSQL)
+		},
+	},
+	{
+		name:    "/ batch separators",
+		weights: map[int]int{Oracle: 2},
+		match: func(tl *Tokens, i int) bool {
+			return tl.isBatchSeparator(i, Oracle)
+		},
+	},
+}
+
+// map[word]map[dialect]the weight of the word for the dialect, for the
+// functions, data types, and other words that are specific to a few
+// dialects
+var dialectWords = map[string]map[int]int{
+	"AUTO_INCREMENT": {MySQL: 2, MariaDB: 2},
+	"AUTOINCREMENT":  {SQLite: 2},
+	"BIGSERIAL":      {PostgreSQL: 2},
+	"DECODE":         {Oracle: 1},
+	"DUAL":           {Oracle: 2, MySQL: 1, MariaDB: 1},
+	"ENGINE":         {MySQL: 2, MariaDB: 2},
+	"GETDATE":        {MSSQL: 2},
+	"IFNULL":         {MySQL: 2, MariaDB: 2, SQLite: 2},
+	"ILIKE":          {PostgreSQL: 2},
+	"ISNULL":         {MSSQL: 2},
+	"LIMIT":          {PostgreSQL: 1, MySQL: 1, MariaDB: 1, SQLite: 1},
+	"NOLOCK":         {MSSQL: 2},
+	"NVARCHAR":       {MSSQL: 1},
+	"NVL":            {Oracle: 3},
+	"NVL2":           {Oracle: 3},
+	"PLPGSQL":        {PostgreSQL: 3},
+	"PRAGMA":         {SQLite: 3},
+	"ROWNUM":         {Oracle: 2},
+	"SERIAL":         {PostgreSQL: 2},
+	"SYSDATE":        {Oracle: 2},
+	"TOP":            {MSSQL: 2},
+	"UNSIGNED":       {MySQL: 2, MariaDB: 2},
+	"VARCHAR2":       {Oracle: 3},
+}
+
+// the tokens that are not of any specific type in any of the dialects
+var punctuationTokens = map[string]bool{
+	"(": true,
+	")": true,
+	",": true,
+	".": true,
+	";": true,
+}
+
+// DetectDialect guesses the SQL dialect of the SQL. The SQL is tokenized
+// for each of the dialects and scored on the signals that are found
+// (back-tick or square bracket quoted identifiers, # comments, dollar
+// quoted bodies, :: casts, GO separators, dialect specific functions
+// such as NVL, ISNULL, or IFNULL, ...). Each unrecognized token (a token
+// that the tokenizer for the dialect could not assign a type to) counts
+// against the dialect. The dialects are returned in order of descending
+// score, dialects with equal scores are ordered with the more general
+// dialects first (StandardSQL, then PostgreSQL, ...).
+func DetectDialect(sql string) (scores []DialectScore) {
+
+	total := 0
+	for _, dialect := range detectDialects {
+		s := scoreDialect(sql, dialect)
+		if s.Score > 0 {
+			total += s.Score
+		}
+		scores = append(scores, s)
+	}
+
+	for i := range scores {
+		if total > 0 && scores[i].Score > 0 {
+			scores[i].Confidence = float64(scores[i].Score) / float64(total)
+		}
+	}
+
+	sort.SliceStable(scores, func(i, j int) bool { return scores[i].Score > scores[j].Score })
+	return scores
+}
+
+// scoreDialect returns the score of the SQL for the dialect
+func scoreDialect(sql string, dialect int) (s DialectScore) {
+
+	s.Dialect = dialect
+	tl := ParseStatements(sql, dialect)
+
+	found := make(map[string]bool)
+	add := func(name string, weight int) {
+		s.Score += weight
+		if !found[name] {
+			found[name] = true
+			s.Signals = append(s.Signals, name)
+		}
+	}
+
+	unknown := 0
+	for i := 0; i < tl.length; i++ {
+		t := tl.tokens[i]
+
+		for _, signal := range dialectSignals {
+			if w := signal.weights[dialect]; w != 0 && signal.match(&tl, i) {
+				add(signal.name, w)
+			}
+		}
+
+		if w := dialectWords[tokenWord(t)][dialect]; w != 0 {
+			add(tokenWord(t), w)
+		}
+
+		switch {
+		case t.tokenType != OtherToken, punctuationTokens[t.tokenString]:
+		case dialect == PostgreSQL && reDollarQuote.MatchString(t.tokenString):
+		case strings.HasPrefix(t.tokenString, "@"):
+			// MySQL user variables
+		default:
+			unknown++
+		}
+	}
+
+	if unknown > 0 {
+		s.Score -= unknown
+		s.Signals = append(s.Signals, "unrecognized tokens")
+	}
+	return s
+}
@@ -0,0 +1,87 @@
+package sqlparse
+
+import (
+	"testing"
+)
+
+func TestDetectDialect(t *testing.T) {
+
+	cases := []struct {
+		name     string
+		sql      string
+		expected int
+	}{
+		{
+			name:     "MySQL",
+			sql:      "SELECT `a`, b FROM t # note\nWHERE IFNULL(x, 1) = 1 LIMIT 10;",
+			expected: MySQL,
+		},
+		{
+			name:     "PostgreSQL",
+			sql:      "CREATE FUNCTION f() RETURNS int AS $$ SELECT 1::int; $$ LANGUAGE plpgsql;",
+			expected: PostgreSQL,
+		},
+		{
+			name:     "MSSQL",
+			sql:      "DECLARE @n int;\nSELECT TOP 10 [a] FROM dbo.t WITH (NOLOCK) WHERE ISNULL(x, 0) = @n\nGO\n",
+			expected: MSSQL,
+		},
+		{
+			name:     "Oracle",
+			sql:      "SELECT NVL(a, 1), SYSDATE FROM dual WHERE ROWNUM < 5;\n/\n",
+			expected: Oracle,
+		},
+		{
+			name:     "SQLite",
+			sql:      "PRAGMA foreign_keys = ON;\nCREATE TABLE t (id INTEGER PRIMARY KEY AUTOINCREMENT);",
+			expected: SQLite,
+		},
+		{
+			name:     "no signals",
+			sql:      "SELECT a FROM t WHERE b = 1;",
+			expected: StandardSQL,
+		},
+	}
+
+	for _, c := range cases {
+		scores := DetectDialect(c.sql)
+		if len(scores) != len(detectDialects) {
+			t.Fatalf("%s: expected %d scores, got %d", c.name, len(detectDialects), len(scores))
+		}
+		if scores[0].Dialect != c.expected {
+			t.Errorf("%s: expected %s, got %s (%+v)", c.name, SQLDialectName(c.expected), SQLDialectName(scores[0].Dialect), scores)
+		}
+		for i := 1; i < len(scores); i++ {
+			if scores[i].Score > scores[i-1].Score {
+				t.Errorf("%s: scores are not in descending order: %+v", c.name, scores)
+			}
+		}
+	}
+}
+
+func TestDetectDialectConfidence(t *testing.T) {
+
+	scores := DetectDialect("SELECT a FROM t WHERE b = 1;")
+	for _, s := range scores {
+		if s.Confidence != 0 {
+			t.Errorf("expected no confidence for %s, got %v", SQLDialectName(s.Dialect), s.Confidence)
+		}
+	}
+
+	// MySQL and MariaDB have the same signals
+	scores = DetectDialect("SELECT `a` FROM t # note\n;")
+	if scores[0].Dialect != MySQL || scores[1].Dialect != MariaDB || scores[0].Score != scores[1].Score {
+		t.Errorf("expected MySQL and MariaDB to tie, got %+v", scores)
+	}
+
+	total := 0.0
+	for _, s := range scores {
+		total += s.Confidence
+	}
+	if total < 0.999 || total > 1.001 {
+		t.Errorf("expected the confidences to total 1, got %v", total)
+	}
+	if len(scores[0].Signals) != 2 {
+		t.Errorf("expected 2 signals, got %v", scores[0].Signals)
+	}
+}
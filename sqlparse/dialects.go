@@ -170,3 +170,27 @@ func IsLabel(s string, dialect int) bool {
 		return d.IsStandardLabel(s)
 	}
 }
+
+// OperatorPrecedence returns the precedence and associativity of the
+// supplied operator, with the specified fixity (dialects.InfixOperator,
+// dialects.PrefixOperator, or dialects.PostfixOperator), in the
+// specified SQL dialect
+func OperatorPrecedence(s string, fixity int, dialect int) (op d.Operator, ok bool) {
+
+	switch dialect {
+	case PostgreSQL:
+		return d.PostgreSQLOperatorPrecedence(s, fixity)
+	case SQLite:
+		return d.SQLiteOperatorPrecedence(s, fixity)
+	case MySQL:
+		return d.MySQLOperatorPrecedence(s, fixity)
+	case Oracle:
+		return d.OracleOperatorPrecedence(s, fixity)
+	case MSSQL:
+		return d.MSSQLOperatorPrecedence(s, fixity)
+	case MariaDB:
+		return d.MariaDBOperatorPrecedence(s, fixity)
+	default:
+		return d.StandardOperatorPrecedence(s, fixity)
+	}
+}
@@ -0,0 +1,346 @@
+package sqlparse
+
+/*
+
+directives.go provides the functionality for reading the parsing
+directives (dialect, statement delimiter, and options) that are embedded
+in the comments at the top of SQL files.
+
+*/
+
+import (
+	"io/ioutil"
+	"regexp"
+	"strings"
+)
+
+// Directives provides the parsing directives for SQL
+type Directives struct {
+	Dialect   int               // the SQL dialect (NullDialect if not specified)
+	Detected  bool              // indicates that the dialect was detected (see DetectDialect) rather than specified
+	Delimiter string            // the statement delimiter ("" if not specified)
+	Options   map[string]string // map[lower-case name]value of any other directives
+}
+
+// a MySQL client DELIMITER command, on a line by itself (the command is
+// only recognized at the start of a statement)
+var reDelimiterCommand = regexp.MustCompile(`(?im)^[ \t]*(delimiter)[ \t]+(\S+)[ \t]*\r?$`)
+
+// map[lower-case name]dialect of the names, and common aliases, of the
+// dialects that may be used in directives and editor modelines
+var dialectAliases = map[string]int{
+	"ansi":        StandardSQL,
+	"standardsql": StandardSQL,
+	"postgresql":  PostgreSQL,
+	"postgres":    PostgreSQL,
+	"pgsql":       PostgreSQL,
+	"pg":          PostgreSQL,
+	"sqlite":      SQLite,
+	"sqlite3":     SQLite,
+	"mysql":       MySQL,
+	"oracle":      Oracle,
+	"plsql":       Oracle,
+	"sqloracle":   Oracle,
+	"mssql":       MSSQL,
+	"ms":          MSSQL,
+	"sqlserver":   MSSQL,
+	"tsql":        MSSQL,
+	"mariadb":     MariaDB,
+}
+
+// directiveDialect returns the dialect for the name used in a directive
+func directiveDialect(s string) (dialect int, ok bool) {
+	dialect, ok = dialectAliases[strings.ToLower(strings.TrimSpace(s))]
+	return dialect, ok
+}
+
+// ParseDirectives returns the parsing directives from the line comments
+// at the top of the SQL (up to the first line that is not blank or a
+// line comment). Directives are written as comma separated "name: value"
+// pairs, and the line must start with a dialect, delimiter, or options
+// directive to be recognized:
+//
+//	-- dialect: PostgreSQL
+//	-- dialect: MySQL, delimiter: $$
+//	-- options: strict fold=upper
+//
+// The options directive is a space separated list of "name=value" (or
+// just "name", for a value of "true") options. Any other directive on a
+// directive line is also added to the options.
+//
+// The sql-product of Emacs modelines (-- -*- mode: sql; sql-product: postgres -*-)
+// and the filetype of Vim modelines (-- vim: set ft=mysql:) are also
+// recognized. Dialect names are case insensitive and common aliases
+// (postgres, pg, ms, tsql, plsql, ...) are accepted. Unknown dialects
+// are ignored.
+func ParseDirectives(stmts string) (dir Directives) {
+
+	for _, line := range strings.Split(stmts, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case line == "":
+			continue
+		case !strings.HasPrefix(line, "--"):
+			return dir
+		}
+
+		body := strings.TrimSpace(strings.TrimLeft(line, "-"))
+		switch {
+		case strings.Contains(body, "-*-"):
+			dir.parseEmacsModeline(body)
+		case strings.HasPrefix(body, "vim:"), strings.HasPrefix(body, "vi:"):
+			dir.parseVimModeline(body)
+		default:
+			dir.parseDirectiveLine(body)
+		}
+	}
+	return dir
+}
+
+// parseDirectiveLine adds the "name: value" directives of a comment line
+func (dir *Directives) parseDirectiveLine(s string) {
+
+	for i, pair := range strings.Split(s, ",") {
+		kv := strings.SplitN(pair, ":", 2)
+		if len(kv) < 2 {
+			continue
+		}
+		key := strings.ToLower(strings.TrimSpace(kv[0]))
+		value := strings.TrimSpace(kv[1])
+
+		switch key {
+		case "dialect":
+			if dialect, ok := directiveDialect(value); ok {
+				dir.Dialect = dialect
+			}
+		case "delimiter":
+			dir.Delimiter = value
+		case "options":
+			for _, opt := range strings.Fields(value) {
+				kv := strings.SplitN(opt, "=", 2)
+				if len(kv) < 2 {
+					kv = append(kv, "true")
+				}
+				dir.setOption(kv[0], kv[1])
+			}
+		default:
+			if i == 0 {
+				// not a directive line
+				return
+			}
+			dir.setOption(key, value)
+		}
+	}
+}
+
+// parseEmacsModeline sets the dialect from the sql-product of an Emacs
+// modeline (-*- mode: sql; sql-product: postgres -*-)
+func (dir *Directives) parseEmacsModeline(s string) {
+
+	parts := strings.Split(s, "-*-")
+	if len(parts) < 3 {
+		return
+	}
+	for _, v := range strings.Split(parts[1], ";") {
+		kv := strings.SplitN(v, ":", 2)
+		if len(kv) == 2 && strings.TrimSpace(kv[0]) == "sql-product" {
+			if dialect, ok := directiveDialect(kv[1]); ok {
+				dir.Dialect = dialect
+			}
+		}
+	}
+}
+
+// parseVimModeline sets the dialect from the filetype of a Vim modeline
+// (vim: set ft=mysql:)
+func (dir *Directives) parseVimModeline(s string) {
+
+	fields := strings.FieldsFunc(s, func(r rune) bool {
+		return r == ' ' || r == '\t' || r == ':'
+	})
+	for _, f := range fields {
+		kv := strings.SplitN(f, "=", 2)
+		if len(kv) == 2 && (kv[0] == "ft" || kv[0] == "filetype") {
+			if dialect, ok := directiveDialect(kv[1]); ok {
+				dir.Dialect = dialect
+			}
+		}
+	}
+}
+
+// setOption adds an option to the directives
+func (dir *Directives) setOption(name, value string) {
+	if dir.Options == nil {
+		dir.Options = make(map[string]string)
+	}
+	dir.Options[strings.ToLower(name)] = value
+}
+
+// ParseAuto tokenizes the SQL per the directives that it contains (see
+// ParseDirectives). When there is no dialect directive the dialect is
+// detected (see DetectDialect). When there is a delimiter directive the
+// delimiter is tokenized as a single OtherToken wherever it occurs
+// outside of quoted strings and comments, and the statements of the
+// token list are terminated by the delimiter rather than by semi-colons
+// (see StatementRanges).
+//
+// For MySQL and MariaDB the client DELIMITER commands (DELIMITER // on a
+// line by itself, at the start of a statement) are also honored, the
+// delimiter changes at each command. Each command is a statement of its
+// own.
+func ParseAuto(stmts string) (tl Tokens, dir Directives) {
+
+	dir = ParseDirectives(stmts)
+	if dir.Dialect == NullDialect {
+		dir.Dialect = DetectDialect(stmts)[0].Dialect
+		dir.Detected = true
+	}
+
+	return parseDelimited(stmts, dir.Dialect, dir.Delimiter), dir
+}
+
+// ParseFile reads and tokenizes the SQL file per the directives that it
+// contains (see ParseAuto)
+func ParseFile(filename string) (tl Tokens, dir Directives, err error) {
+
+	b, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return tl, dir, err
+	}
+
+	tl, dir = ParseAuto(string(b))
+	return tl, dir, nil
+}
+
+// parseDelimited tokenizes the SQL with the statement delimiters as
+// separate tokens that are marked as terminating the statements. The SQL
+// is split at each occurrence of the delimiter, that is not within a
+// quoted string or comment, and each piece is tokenized separately as
+// the delimiter may otherwise be run together with the adjacent tokens
+// (END$$). For MySQL and MariaDB the delimiter changes at each DELIMITER
+// command that starts a statement.
+func parseDelimited(stmts string, dialect int, delimiter string) (tl Tokens) {
+
+	var commands [][]int
+	if isMySQLFamily(dialect) {
+		commands = reDelimiterCommand.FindAllStringSubmatchIndex(stmts, -1)
+	}
+	if len(commands) == 0 && (delimiter == "" || delimiter == ";") {
+		return ParseStatements(stmts, dialect)
+	}
+	if delimiter == "" {
+		delimiter = ";"
+	}
+
+	protected := protectedRanges(stmts, dialect)
+	var active [][]int
+	for _, c := range commands {
+		if _, ok := protectedEnd(protected, c[2]); !ok {
+			active = append(active, c)
+		}
+	}
+	commands = active
+
+	start := 0
+	for pos := 0; ; {
+		next := len(stmts)
+		if len(commands) > 0 {
+			next = commands[0][0]
+		}
+
+		if i := indexUnprotected(stmts, pos, delimiter, protected); i >= 0 && i < next {
+			piece := ParseStatements(stmts[start:i], dialect)
+			tl.tokens = append(tl.tokens, piece.tokens...)
+			tl.tokens = append(tl.tokens, terminatorToken(delimiter, piece.trailingWhiteSpace))
+			start = i + len(delimiter)
+			pos = start
+			continue
+		}
+		if len(commands) == 0 {
+			break
+		}
+
+		// DELIMITER //, the submatches are the command and the delimiter
+		c := commands[0]
+		commands = commands[1:]
+
+		piece := ParseStatements(stmts[start:c[2]], dialect)
+		if piece.NextFiltered(SignificantTokens).tokenString != "" {
+			// not at the start of a statement (a column named delimiter)
+			continue
+		}
+		tl.tokens = append(tl.tokens, piece.tokens...)
+		word := stmts[c[2]:c[3]]
+		wordType := IdentToken
+		if IsKeyword(word, dialect) {
+			wordType = KeywordToken
+		}
+		tl.tokens = append(tl.tokens, NewToken(word, wordType, piece.trailingWhiteSpace))
+		delimiter = stmts[c[4]:c[5]]
+		tl.tokens = append(tl.tokens, terminatorToken(delimiter, stmts[c[3]:c[4]]))
+		start = c[5]
+		pos = start
+	}
+
+	piece := ParseStatements(stmts[start:], dialect)
+	tl.tokens = append(tl.tokens, piece.tokens...)
+	tl.trailingWhiteSpace = piece.trailingWhiteSpace
+	tl.length = len(tl.tokens)
+	tl.delimited = true
+	return tl
+}
+
+// terminatorToken returns a statement delimiter token
+func terminatorToken(delimiter, ws string) (t Token) {
+	t = NewToken(delimiter, OtherToken, ws)
+	t.terminator = true
+	return t
+}
+
+// protectedRanges returns the offsets of the quoted strings and
+// comments in the SQL
+func protectedRanges(stmts string, dialect int) (r [][2]int) {
+
+	all := ParseStatements(stmts, dialect)
+	offset := 0
+	for _, t := range all.tokens {
+		offset += len(t.leadingWhiteSpace)
+		if isQuotedToken(t.tokenType) || isCommentToken(t.tokenType) {
+			r = append(r, [2]int{offset, offset + len(t.tokenString)})
+		}
+		offset += len(t.tokenString)
+	}
+	return r
+}
+
+// protectedEnd returns the end of the quoted string or comment that
+// contains the offset i, if any
+func protectedEnd(protected [][2]int, i int) (end int, ok bool) {
+	for _, r := range protected {
+		if i >= r[0] && i < r[1] {
+			return r[1], true
+		}
+	}
+	return 0, false
+}
+
+// indexUnprotected returns the offset of the first occurrence of the
+// delimiter, at or following pos, that is not within a quoted string or
+// comment. If there is no such occurrence then -1 is returned.
+func indexUnprotected(stmts string, pos int, delimiter string, protected [][2]int) int {
+
+	for pos < len(stmts) {
+		i := strings.Index(stmts[pos:], delimiter)
+		if i < 0 {
+			return -1
+		}
+		i += pos
+
+		end, ok := protectedEnd(protected, i)
+		if !ok {
+			return i
+		}
+		pos = end
+	}
+	return -1
+}
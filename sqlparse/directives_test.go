@@ -0,0 +1,203 @@
+package sqlparse
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseDirectives(t *testing.T) {
+
+	cases := []struct {
+		name      string
+		sql       string
+		dialect   int
+		delimiter string
+		options   map[string]string
+	}{
+		{
+			name:    "dialect",
+			sql:     "-- dialect: PostgreSQL\nSELECT 1;",
+			dialect: PostgreSQL,
+		},
+		{
+			name:      "dialect and delimiter",
+			sql:       "\n--dialect: mysql, delimiter: $$\n-- some comment\nSELECT 1$$",
+			dialect:   MySQL,
+			delimiter: "$$",
+		},
+		{
+			name:    "options",
+			sql:     "-- dialect: tsql\n-- options: strict fold=upper\n-- delimiter: GO, batch: 10\nSELECT 1;",
+			dialect: MSSQL,
+			options: map[string]string{
+				"strict": "true",
+				"fold":   "upper",
+				"batch":  "10",
+			},
+			delimiter: "GO",
+		},
+		{
+			name:    "emacs modeline",
+			sql:     "-- -*- mode: sql; sql-product: postgres -*-\nSELECT 1;",
+			dialect: PostgreSQL,
+		},
+		{
+			name:    "vim modeline",
+			sql:     "-- vim: set ft=plsql ts=4:\nSELECT 1 FROM dual;",
+			dialect: Oracle,
+		},
+		{
+			name: "not directives",
+			sql:  "-- Note: this is not a directive, dialect: Oracle\n-- dialect: no-such-dialect\nSELECT 1;",
+		},
+		{
+			name: "after the header",
+			sql:  "SELECT 1;\n-- dialect: Oracle",
+		},
+	}
+
+	for _, c := range cases {
+		dir := ParseDirectives(c.sql)
+		if dir.Dialect != c.dialect {
+			t.Errorf("%s: expected dialect %q, got %q", c.name, SQLDialectName(c.dialect), SQLDialectName(dir.Dialect))
+		}
+		if dir.Delimiter != c.delimiter {
+			t.Errorf("%s: expected delimiter %q, got %q", c.name, c.delimiter, dir.Delimiter)
+		}
+		if len(dir.Options) != len(c.options) {
+			t.Errorf("%s: expected options %v, got %v", c.name, c.options, dir.Options)
+		}
+		for k, v := range c.options {
+			if dir.Options[k] != v {
+				t.Errorf("%s: expected option %s to be %q, got %q", c.name, k, v, dir.Options[k])
+			}
+		}
+	}
+}
+
+func TestParseAuto(t *testing.T) {
+
+	sql := `-- dialect: MySQL, delimiter: $$
+CREATE PROCEDURE p()
+BEGIN
+    SELECT 'a$$b'; -- not $$ here
+    SELECT (1);
+END$$
+SELECT 2$$
+`
+	tl, dir := ParseAuto(sql)
+	if dir.Dialect != MySQL || dir.Detected {
+		t.Errorf("expected MySQL to be specified, got %+v", dir)
+	}
+	if tl.String() != sql {
+		t.Errorf("expected the token list to render the input, got %q", tl.String())
+	}
+
+	var got []string
+	for _, s := range tl.Statements(dir.Dialect) {
+		got = append(got, strings.TrimSpace(s.String()))
+	}
+	expected := []string{
+		"-- dialect: MySQL, delimiter: $$\nCREATE PROCEDURE p()\nBEGIN\n    SELECT 'a$$b'; -- not $$ here\n    SELECT (1);\nEND$$",
+		"SELECT 2$$",
+	}
+	if strings.Join(got, "\n--\n") != strings.Join(expected, "\n--\n") {
+		t.Errorf("expected statements:\n%s\ngot:\n%s", strings.Join(expected, "\n--\n"), strings.Join(got, "\n--\n"))
+	}
+
+	// no dialect directive
+	tl, dir = ParseAuto("SELECT NVL(a, 0) FROM dual;")
+	if dir.Dialect != Oracle || !dir.Detected {
+		t.Errorf("expected Oracle to be detected, got %+v", dir)
+	}
+	if r := tl.StatementRanges(dir.Dialect); len(r) != 1 {
+		t.Errorf("expected 1 statement, got %d", len(r))
+	}
+}
+
+func TestParseAutoDelimiterCommands(t *testing.T) {
+
+	sql := `-- dialect: MySQL
+CREATE TABLE t (a INT);
+DELIMITER //
+CREATE PROCEDURE p()
+BEGIN
+    SELECT 'DELIMITER ;' FROM t; -- // not here
+    SELECT (1);
+END//
+delimiter ;
+SELECT 1; SELECT 2;
+`
+	tl, dir := ParseAuto(sql)
+	if tl.String() != sql {
+		t.Errorf("expected the token list to render the input, got %q", tl.String())
+	}
+
+	var got []string
+	for _, s := range tl.Statements(dir.Dialect) {
+		got = append(got, strings.TrimSpace(s.String()))
+	}
+	expected := []string{
+		"-- dialect: MySQL\nCREATE TABLE t (a INT);",
+		"DELIMITER //",
+		"CREATE PROCEDURE p()\nBEGIN\n    SELECT 'DELIMITER ;' FROM t; -- // not here\n    SELECT (1);\nEND//",
+		"delimiter ;",
+		"SELECT 1;",
+		"SELECT 2;",
+	}
+	if strings.Join(got, "\n--\n") != strings.Join(expected, "\n--\n") {
+		t.Errorf("expected statements:\n%s\ngot:\n%s", strings.Join(expected, "\n--\n"), strings.Join(got, "\n--\n"))
+	}
+
+	// a column named delimiter is not a command
+	tl, _ = ParseAuto("-- dialect: MySQL\nCREATE TABLE t (\n id int,\n delimiter varchar(10)\n);\nSELECT 1;")
+	got = nil
+	for _, s := range tl.Statements(MySQL) {
+		got = append(got, strings.TrimSpace(s.String()))
+	}
+	expected = []string{
+		"-- dialect: MySQL\nCREATE TABLE t (\n id int,\n delimiter varchar(10)\n);",
+		"SELECT 1;",
+	}
+	if strings.Join(got, "\n--\n") != strings.Join(expected, "\n--\n") {
+		t.Errorf("expected statements:\n%s\ngot:\n%s", strings.Join(expected, "\n--\n"), strings.Join(got, "\n--\n"))
+	}
+
+	// DELIMITER is a MySQL (and MariaDB) client command
+	tl, _ = ParseAuto("-- dialect: PostgreSQL\nDELIMITER //\nSELECT 1;")
+	if r := tl.StatementRanges(PostgreSQL); len(r) != 1 {
+		t.Errorf("expected 1 statement, got %d", len(r))
+	}
+}
+
+func TestParseFile(t *testing.T) {
+
+	dir, err := ioutil.TempDir("", "sqlparse")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	filename := filepath.Join(dir, "test.sql")
+	if err := ioutil.WriteFile(filename, []byte("-- -*- sql-product: ms -*-\nSELECT [a] FROM t\nGO\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tl, d, err := ParseFile(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.Dialect != MSSQL {
+		t.Errorf("expected MSSQL, got %q", SQLDialectName(d.Dialect))
+	}
+	if tk := tl.At(2); tk.Type() != BracketQuotedToken {
+		t.Errorf("expected a BracketQuotedToken, got %s", tk.TypeName())
+	}
+
+	if _, _, err := ParseFile(filepath.Join(dir, "missing.sql")); err == nil {
+		t.Errorf("expected an error for a missing file")
+	}
+}
@@ -0,0 +1,186 @@
+package sqlparse
+
+/*
+
+editor.go provides an edit buffer for rewriting a token list while
+leaving the untouched portions of the list exactly as they were.
+
+*/
+
+// Editor provides an edit buffer over a token list. All edits refer to
+// the indexes of the tokens in the original token list so that any
+// number of edits may be made without the indexes shifting as tokens
+// are inserted or deleted.
+type Editor struct {
+	tokens   Tokens
+	replaced map[int]Token
+	deleted  map[int]bool
+	before   map[int][]Token
+	after    map[int][]Token
+}
+
+// NewEditor returns a new edit buffer for the supplied token list
+func NewEditor(tl Tokens) *Editor {
+	return &Editor{
+		tokens:   tl,
+		replaced: make(map[int]Token),
+		deleted:  make(map[int]bool),
+		before:   make(map[int][]Token),
+		after:    make(map[int][]Token),
+	}
+}
+
+// Replace replaces the token at index i with the supplied token. If the
+// new token has no leading white space then it takes the leading white
+// space of the token being replaced.
+func (e *Editor) Replace(i int, t Token) {
+	if i < 0 || i >= e.tokens.Len() {
+		return
+	}
+	if t.leadingWhiteSpace == "" {
+		t.leadingWhiteSpace = e.tokens.tokens[i].leadingWhiteSpace
+	}
+	e.replaced[i] = t
+	delete(e.deleted, i)
+}
+
+// InsertBefore inserts the supplied tokens in front of the token at
+// index i. The first inserted token takes over the leading white space
+// of the token at index i.
+func (e *Editor) InsertBefore(i int, t ...Token) {
+	if i < 0 || i >= e.tokens.Len() {
+		return
+	}
+	e.before[i] = append(e.before[i], t...)
+}
+
+// InsertAfter inserts the supplied tokens following the token at
+// index i.
+func (e *Editor) InsertAfter(i int, t ...Token) {
+	if i < 0 || i >= e.tokens.Len() {
+		return
+	}
+	e.after[i] = append(e.after[i], t...)
+}
+
+// Delete removes the tokens from index i through index j (inclusive).
+// The white space preceding the deleted tokens is removed with them.
+func (e *Editor) Delete(i, j int) {
+	if i < 0 {
+		i = 0
+	}
+	if j >= e.tokens.Len() {
+		j = e.tokens.Len() - 1
+	}
+	for k := i; k <= j; k++ {
+		e.deleted[k] = true
+		delete(e.replaced, k)
+	}
+}
+
+// edited determines whether or not any of the tokens from index i
+// through index j (inclusive) have been edited
+func (e *Editor) edited(i, j int) bool {
+	for k := i; k <= j; k++ {
+		_, replaced := e.replaced[k]
+		if replaced || e.deleted[k] || len(e.before[k]) > 0 || len(e.after[k]) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Tokens returns the edited token list
+func (e *Editor) Tokens() (tl Tokens) {
+
+	var prev Token
+	pendingWS := ""
+
+	// emit appends a token to the edited list, supplying white space
+	// for inserted tokens that have none
+	emit := func(t Token, inserted bool) {
+		if pendingWS != "" {
+			if t.leadingWhiteSpace == "" {
+				t.leadingWhiteSpace = pendingWS
+			}
+			pendingWS = ""
+		}
+		if inserted && t.leadingWhiteSpace == "" && prev.tokenString != "" {
+			t.leadingWhiteSpace = separatorWhiteSpace(prev, t)
+		}
+		tl.Push(t)
+		prev = t
+	}
+
+	for i := 0; i < e.tokens.Len(); i++ {
+		orig := e.tokens.tokens[i]
+
+		if ins, ok := e.before[i]; ok && len(ins) > 0 {
+			if !e.deleted[i] {
+				// the inserted tokens take the place of the original
+				// token with respect to the leading white space
+				pendingWS = orig.leadingWhiteSpace
+				orig.leadingWhiteSpace = ""
+			}
+			for _, t := range ins {
+				emit(t, true)
+			}
+		}
+
+		switch {
+		case e.deleted[i]:
+			// nothing to emit
+		case e.hasReplacement(i):
+			t := e.replaced[i]
+			_, hasBefore := e.before[i]
+			if hasBefore {
+				t.leadingWhiteSpace = ""
+			}
+			emit(t, hasBefore)
+		default:
+			// the deleted, or inserted, tokens may have been all that
+			// kept this token from running into the preceding token
+			touched := i > 0 && (e.deleted[i-1] || len(e.after[i-1]) > 0)
+			if _, ok := e.before[i]; ok {
+				touched = true
+			}
+			emit(orig, touched)
+		}
+
+		for _, t := range e.after[i] {
+			emit(t, true)
+		}
+	}
+
+	tl.trailingWhiteSpace = e.tokens.trailingWhiteSpace
+	tl.delimited = e.tokens.delimited
+	tl.CloseToken()
+	return tl
+}
+
+// String returns the text of the edited token list
+func (e *Editor) String() string {
+	tl := e.Tokens()
+	return tl.String()
+}
+
+func (e *Editor) hasReplacement(i int) bool {
+	_, ok := e.replaced[i]
+	return ok
+}
+
+// separatorWhiteSpace returns the white space that is customarily used
+// to separate two adjacent tokens
+func separatorWhiteSpace(prev, t Token) string {
+
+	if prev.tokenType == OtherToken && prev.tokenString == "(" {
+		return ""
+	}
+	if t.tokenType == OtherToken {
+		switch t.tokenString {
+		case ")", ",", ";":
+			return ""
+		}
+	}
+	return " "
+}
@@ -0,0 +1,90 @@
+package sqlparse
+
+import (
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+func TestRoundTrip(t *testing.T) {
+
+	files, err := ioutil.ReadDir("testdata/input")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, file := range files {
+		if !strings.HasSuffix(file.Name(), ".sql") {
+			continue
+		}
+
+		b, err := ioutil.ReadFile("testdata/input/" + file.Name())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, dialect := range []int{StandardSQL, PostgreSQL, Oracle} {
+			tl := ParseStatements(string(b), dialect)
+			if s := tl.String(); s != string(b) {
+				t.Errorf("%s (%s): rendered text does not match the input", file.Name(), SQLDialectName(dialect))
+			}
+		}
+	}
+}
+
+func TestEditor(t *testing.T) {
+
+	// 0      1  2 3    4    5     6     7 8 9 10  11 12 13 14
+	// SELECT id , name FROM users WHERE a = 1 AND b  =  2  ;
+	input := "SELECT id,\n   name\n  FROM users\n WHERE a=1 AND b = 2;\n"
+
+	cases := []struct {
+		name     string
+		edit     func(e *Editor)
+		expected string
+	}{
+		{
+			"no edits",
+			func(e *Editor) {},
+			input,
+		},
+		{
+			"replace",
+			func(e *Editor) { e.Replace(5, NewToken("app.users", IdentToken, "")) },
+			"SELECT id,\n   name\n  FROM app.users\n WHERE a=1 AND b = 2;\n",
+		},
+		{
+			"insert before",
+			func(e *Editor) {
+				e.InsertBefore(3, NewToken("DISTINCT", KeywordToken, ""))
+			},
+			"SELECT id,\n   DISTINCT name\n  FROM users\n WHERE a=1 AND b = 2;\n",
+		},
+		{
+			"insert after",
+			func(e *Editor) {
+				e.InsertAfter(13, NewToken("LIMIT", KeywordToken, ""), NewToken("10", NumericToken, ""))
+			},
+			"SELECT id,\n   name\n  FROM users\n WHERE a=1 AND b = 2 LIMIT 10;\n",
+		},
+		{
+			"delete",
+			func(e *Editor) { e.Delete(7, 10) },
+			"SELECT id,\n   name\n  FROM users\n WHERE b = 2;\n",
+		},
+		{
+			"delete needing separation",
+			func(e *Editor) { e.Delete(8, 8) },
+			"SELECT id,\n   name\n  FROM users\n WHERE a 1 AND b = 2;\n",
+		},
+	}
+
+	for _, c := range cases {
+		tl := ParseStatements(input, PostgreSQL)
+		e := NewEditor(tl)
+		c.edit(e)
+		if s := e.String(); s != c.expected {
+			t.Errorf("%s: expected %q, got %q", c.name, c.expected, s)
+		}
+	}
+}
@@ -0,0 +1,967 @@
+package sqlparse
+
+/*
+
+expr.go provides the operator precedence parser for expressions.
+
+*/
+
+import (
+	"strings"
+
+	d "github.com/gsiems/sql-parse/dialects"
+)
+
+// map[keyword]bool of the pattern matching operators that are parsed as
+// LikeExprs
+var likeOperators = map[string]bool{
+	"GLOB":    true,
+	"ILIKE":   true,
+	"LIKE":    true,
+	"LIKE2":   true,
+	"LIKE4":   true,
+	"LIKEC":   true,
+	"MATCH":   true,
+	"REGEXP":  true,
+	"RLIKE":   true,
+	"SIMILAR": true,
+	"SOUNDS":  true,
+}
+
+// map[keyword]bool of the words that separate the arguments of the
+// functions with special argument syntax, EXTRACT(YEAR FROM d),
+// TRIM(BOTH ' ' FROM s), SUBSTRING(s FROM 1 FOR 2), POSITION(a IN s),
+// CONVERT(s USING utf8), GROUP_CONCAT(a SEPARATOR ','), ...
+var argumentWords = map[string]bool{
+	"BOTH":      true,
+	"FOR":       true,
+	"FROM":      true,
+	"IN":        true,
+	"LEADING":   true,
+	"PLACING":   true,
+	"SEPARATOR": true,
+	"TRAILING":  true,
+	"USING":     true,
+}
+
+// map[keyword]bool of the reserved words that are used without
+// parentheses as functions or pseudo-columns
+var niladicWords = map[string]bool{
+	"CURRENT_CATALOG":   true,
+	"CURRENT_DATE":      true,
+	"CURRENT_ROLE":      true,
+	"CURRENT_SCHEMA":    true,
+	"CURRENT_TIME":      true,
+	"CURRENT_TIMESTAMP": true,
+	"CURRENT_USER":      true,
+	"LEVEL":             true,
+	"LOCALTIME":         true,
+	"LOCALTIMESTAMP":    true,
+	"ROWID":             true,
+	"ROWNUM":            true,
+	"SESSION_USER":      true,
+	"SYSDATE":           true,
+	"SYSTEM_USER":       true,
+	"SYSTIMESTAMP":      true,
+	"UID":               true,
+	"USER":              true,
+}
+
+// map[unit]bool of the interval units
+var intervalUnits = map[string]bool{
+	"CENTURY":     true,
+	"DAY":         true,
+	"DECADE":      true,
+	"HOUR":        true,
+	"MICROSECOND": true,
+	"MILLENNIUM":  true,
+	"MILLISECOND": true,
+	"MINUTE":      true,
+	"MONTH":       true,
+	"QUARTER":     true,
+	"SECOND":      true,
+	"WEEK":        true,
+	"YEAR":        true,
+}
+
+// ParseExpr parses the expression in the token list. The expression may
+// be preceded by comments and followed by a semicolon.
+func ParseExpr(tl Tokens, dialect int) (e Expr, err error) {
+
+	p := newParser(&tl, dialect)
+
+	e, err = p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+
+	p.accept(";")
+	if p.k < len(p.sig) {
+		return nil, p.errorf("unexpected %s", p.found())
+	}
+	return e, nil
+}
+
+// parseExpr parses an expression
+func (p *parser) parseExpr() (e Expr, err error) {
+	return p.parseBinary(0)
+}
+
+// parseBinary parses an expression whose infix operators have at least
+// the minimum precedence
+func (p *parser) parseBinary(minPrec int) (e Expr, err error) {
+	if e, err = p.parseUnary(); err != nil {
+		return nil, err
+	}
+	return p.parseInfix(e, minPrec)
+}
+
+// parseUnary parses an expression that may start with prefix operators
+func (p *parser) parseUnary() (e Expr, err error) {
+
+	t := p.token()
+	switch t.tokenType {
+	case OperatorToken, KeywordToken, IdentToken:
+		op, ok := OperatorPrecedence(t.tokenString, d.PrefixOperator, p.dialect)
+		if !ok {
+			break
+		}
+
+		u := &UnaryExpr{Op: strings.ToUpper(t.tokenString)}
+		u.pos = p.pos()
+		p.next()
+		if u.Expr, err = p.parseBinary(op.Precedence); err != nil {
+			return nil, err
+		}
+		u.end = p.end()
+		return u, nil
+	}
+
+	return p.parsePrimary()
+}
+
+// parseInfix parses the infix and postfix operators, of at least the
+// minimum precedence, that follow the left operand
+func (p *parser) parseInfix(left Expr, minPrec int) (e Expr, err error) {
+
+	for p.k < len(p.sig) {
+		start := left.Pos()
+
+		if name, n, op, ok := p.postfixOperator(); ok {
+			if op.Precedence < minPrec {
+				break
+			}
+			p.k += n
+			left, err = p.postfixExpr(name, left)
+			if err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		name, not, op, ok := p.infixOperator()
+		if !ok || op.Precedence < minPrec {
+			break
+		}
+
+		next := op.Precedence + 1
+		if op.Associativity == d.RightAssociative {
+			next = op.Precedence
+		}
+
+		// "a -1" is tokenized as a followed by the number -1
+		if t := p.token(); t.tokenType == NumericToken {
+			lit := &Literal{Value: t.tokenString[1:]}
+			lit.pos = p.pos()
+			p.next()
+			lit.end = p.end()
+
+			b := &BinaryExpr{Op: name, Left: left}
+			if b.Right, err = p.parseInfix(lit, next); err != nil {
+				return nil, err
+			}
+			b.pos = start
+			b.end = p.end()
+			left = b
+			continue
+		}
+
+		p.next()
+		if not {
+			p.next()
+		}
+
+		switch {
+		case name == "IS":
+			left, err = p.parseIs(left, next)
+		case name == "BETWEEN":
+			left, err = p.parseBetween(left, not, next)
+		case name == "IN":
+			left, err = p.parseIn(left, not)
+		case likeOperators[name]:
+			left, err = p.parseLike(left, name, not, next)
+		case name == "::" && p.dialect != MSSQL:
+			c := &CastExpr{Op: name, Expr: left}
+			c.DataType = p.parseDataType(false)
+			c.Type = c.DataType.Text
+			if c.Type == "" {
+				return nil, p.errorf("expected data type, found %s", p.found())
+			}
+			c.pos = start
+			c.end = p.end()
+			left = c
+		default:
+			b := &BinaryExpr{Op: name, Left: left}
+			switch name {
+			case "AT":
+				// AT TIME ZONE
+				p.k += 2
+				b.Op = "AT TIME ZONE"
+				b.Right, err = p.parseBinary(next)
+			case "COLLATE":
+				b.Right, err = p.parsePrimary()
+			default:
+				b.Right, err = p.parseBinary(next)
+			}
+			b.pos = start
+			b.end = p.end()
+			left = b
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return left, nil
+}
+
+// infixOperator determines the infix operator at the current token, if
+// any. Keyword operators are returned in upper-case and the NOT of
+// NOT BETWEEN, NOT IN, NOT LIKE, ... is indicated separately.
+func (p *parser) infixOperator() (name string, not bool, op d.Operator, ok bool) {
+
+	t := p.token()
+	switch t.tokenType {
+	case OperatorToken:
+		name = t.tokenString
+	case NumericToken:
+		if !strings.HasPrefix(t.tokenString, "-") && !strings.HasPrefix(t.tokenString, "+") {
+			return "", false, op, false
+		}
+		name = t.tokenString[:1]
+	case KeywordToken, IdentToken:
+		name = tokenWord(t)
+		n := 1
+		if name == "NOT" {
+			name = tokenWord(p.peek(1))
+			if name != "BETWEEN" && name != "IN" && !likeOperators[name] {
+				return "", false, op, false
+			}
+			not = true
+			n = 2
+		}
+
+		switch name {
+		case "IN":
+			if p.peek(n).tokenString != "(" {
+				return "", false, op, false
+			}
+		case "AT":
+			if tokenWord(p.peek(n)) != "TIME" || tokenWord(p.peek(n+1)) != "ZONE" {
+				return "", false, op, false
+			}
+		case "SIMILAR":
+			if tokenWord(p.peek(n)) != "TO" {
+				return "", false, op, false
+			}
+		case "SOUNDS":
+			if tokenWord(p.peek(n)) != "LIKE" {
+				return "", false, op, false
+			}
+		}
+	default:
+		return "", false, op, false
+	}
+
+	op, ok = OperatorPrecedence(name, d.InfixOperator, p.dialect)
+	return name, not, op, ok
+}
+
+// postfixOperator determines the postfix operator at the current token,
+// if any, and the number of tokens that make up the operator
+func (p *parser) postfixOperator() (name string, n int, op d.Operator, ok bool) {
+
+	if p.isOuterJoinMarker() {
+		name, n = "(+)", 3
+	} else if name, n = tokenWord(p.token()), 1; name == "" {
+		return "", 0, op, false
+	}
+
+	op, ok = OperatorPrecedence(name, d.PostfixOperator, p.dialect)
+	return name, n, op, ok
+}
+
+// isOuterJoinMarker determines whether or not the current token starts
+// an Oracle (+) outer join marker
+func (p *parser) isOuterJoinMarker() bool {
+	return p.dialect == Oracle &&
+		p.is("(") &&
+		p.peek(1).tokenString == "+" &&
+		p.peek(2).tokenString == ")" &&
+		p.peek(1).leadingWhiteSpace == "" &&
+		p.peek(2).leadingWhiteSpace == ""
+}
+
+// postfixExpr creates the expression for a postfix operator
+func (p *parser) postfixExpr(name string, left Expr) (e Expr, err error) {
+
+	switch name {
+	case "ISNULL", "NOTNULL":
+		is := &IsExpr{Not: name == "NOTNULL", Expr: left, Value: "NULL"}
+		is.pos = left.Pos()
+		is.end = p.end()
+		return is, nil
+	}
+
+	u := &UnaryExpr{Op: name, Postfix: true, Expr: left}
+	u.pos = left.Pos()
+	u.end = p.end()
+	return u, nil
+}
+
+// parseIs parses the remainder of an IS expression
+func (p *parser) parseIs(left Expr, prec int) (e Expr, err error) {
+
+	is := &IsExpr{Expr: left}
+	is.pos = left.Pos()
+	is.Not = p.acceptWord("NOT")
+
+	switch w := p.word(); w {
+	case "NULL", "TRUE", "FALSE", "UNKNOWN":
+		is.Value = w
+		p.next()
+	case "DISTINCT":
+		p.next()
+		if err = p.expectWord("FROM"); err != nil {
+			return nil, err
+		}
+		is.Value = "DISTINCT FROM"
+		if is.Right, err = p.parseBinary(prec); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, p.errorf("expected NULL, TRUE, FALSE, UNKNOWN, or DISTINCT FROM, found %s", p.found())
+	}
+
+	is.end = p.end()
+	return is, nil
+}
+
+// parseBetween parses the remainder of a BETWEEN expression
+func (p *parser) parseBetween(left Expr, not bool, prec int) (e Expr, err error) {
+
+	b := &BetweenExpr{Not: not, Expr: left}
+	b.pos = left.Pos()
+	if p.acceptWord("SYMMETRIC") {
+		b.Symmetric = true
+	} else {
+		p.acceptWord("ASYMMETRIC")
+	}
+
+	if b.Low, err = p.parseBinary(prec); err != nil {
+		return nil, err
+	}
+	if err = p.expectWord("AND"); err != nil {
+		return nil, err
+	}
+	if b.High, err = p.parseBinary(prec); err != nil {
+		return nil, err
+	}
+
+	b.end = p.end()
+	return b, nil
+}
+
+// parseIn parses the remainder of an IN expression
+func (p *parser) parseIn(left Expr, not bool) (e Expr, err error) {
+
+	in := &InExpr{Not: not, Expr: left}
+	in.pos = left.Pos()
+
+	if p.isDerivedTable() {
+		p.next()
+		if in.Query, err = p.parseQuery(); err != nil {
+			return nil, err
+		}
+	} else {
+		p.next()
+		if in.List, err = p.parseExprList(); err != nil {
+			return nil, err
+		}
+	}
+	if err = p.expect(")"); err != nil {
+		return nil, err
+	}
+
+	in.end = p.end()
+	return in, nil
+}
+
+// parseLike parses the remainder of a pattern matching expression
+func (p *parser) parseLike(left Expr, name string, not bool, prec int) (e Expr, err error) {
+
+	l := &LikeExpr{Not: not, Op: name, Expr: left}
+	l.pos = left.Pos()
+	switch name {
+	case "SIMILAR":
+		p.next()
+		l.Op = "SIMILAR TO"
+	case "SOUNDS":
+		p.next()
+		l.Op = "SOUNDS LIKE"
+	}
+
+	if l.Pattern, err = p.parseBinary(prec); err != nil {
+		return nil, err
+	}
+	if p.acceptWord("ESCAPE") {
+		if l.Escape, err = p.parseBinary(prec); err != nil {
+			return nil, err
+		}
+	}
+
+	l.end = p.end()
+	return l, nil
+}
+
+// parsePrimary parses an operand: a literal, name, function call,
+// parenthesized expression, subquery, or one of the keyword expressions
+// (CASE, CAST, EXISTS, INTERVAL, ...)
+func (p *parser) parsePrimary() (e Expr, err error) {
+
+	t := p.token()
+	start := p.pos()
+
+	switch t.tokenType {
+	case NumericToken:
+		lit := &Literal{Value: t.tokenString}
+		lit.pos = start
+		p.next()
+		lit.end = p.end()
+		return lit, nil
+
+	case SingleQuotedToken:
+		return p.parseString("", ""), nil
+
+	case BindParameterToken:
+		param := &Param{Name: t.tokenString}
+		param.pos = start
+		p.next()
+		param.end = p.end()
+		return param, nil
+
+	case OtherToken:
+		if t.tokenString == "(" {
+			return p.parseParenExpr()
+		}
+	}
+
+	next := p.peek(1)
+	w := tokenWord(t)
+	switch w {
+	case "NULL", "TRUE", "FALSE":
+		lit := &Literal{Value: w}
+		lit.pos = start
+		p.next()
+		lit.end = p.end()
+		return lit, nil
+
+	case "CASE":
+		return p.parseCase()
+
+	case "CAST", "TRY_CAST", "SAFE_CAST":
+		if next.tokenString == "(" {
+			return p.parseCast()
+		}
+
+	case "EXISTS", "ANY", "SOME", "ALL":
+		if next.tokenString == "(" {
+			p.next()
+			if w == "EXISTS" || p.isDerivedTable() {
+				s := &SubqueryExpr{Op: w}
+				s.pos = start
+				p.next()
+				if s.Query, err = p.parseQuery(); err != nil {
+					return nil, err
+				}
+				if err = p.expect(")"); err != nil {
+					return nil, err
+				}
+				s.end = p.end()
+				return s, nil
+			}
+			p.k--
+		}
+
+	case "INTERVAL":
+		if next.tokenType != OperatorToken && next.tokenString != ")" && next.tokenString != "," {
+			return p.parseInterval()
+		}
+
+	case "DATE", "TIME", "TIMESTAMP":
+		if next.tokenType == SingleQuotedToken {
+			p.next()
+			lit := p.parseString(w, "")
+			lit.pos = start
+			return lit, nil
+		}
+	}
+
+	// prefixed strings, N'abc', E'a\tb', X'0F', _utf8'abc'
+	if t.tokenType == IdentToken && next.tokenType == SingleQuotedToken && next.leadingWhiteSpace == "" {
+		p.next()
+		lit := p.parseString("", t.tokenString)
+		lit.pos = start
+		return lit, nil
+	}
+
+	if isNameToken(t, p.dialect) || niladicWords[w] || p.isFunctionCall() {
+		var name string
+		if isNameToken(t, p.dialect) {
+			name = p.parseName()
+		} else {
+			name = t.tokenString
+			p.next()
+		}
+
+		if p.is("(") && !p.isOuterJoinMarker() {
+			return p.parseFuncCall(name, start)
+		}
+
+		id := &Ident{Name: name}
+		id.pos = start
+		id.end = p.end()
+		return id, nil
+	}
+
+	return nil, p.errorf("expected expression, found %s", p.found())
+}
+
+// isFunctionCall determines whether or not the current token is a
+// keyword that is used as the name of a function, LEFT(s, 2),
+// REPLACE(s, 'a', 'b'), ...
+func (p *parser) isFunctionCall() bool {
+
+	t := p.token()
+	if t.tokenType != KeywordToken || p.peek(1).tokenString != "(" {
+		return false
+	}
+
+	switch w := tokenWord(t); w {
+	case "LEFT", "RIGHT":
+		return true
+	default:
+		return !clauseWords[w]
+	}
+}
+
+// parseString parses a string literal. Adjacent quoted strings that are
+// not separated by white space (as with an escaped quote) are a single
+// literal.
+func (p *parser) parseString(typeName, prefix string) *Literal {
+
+	lit := &Literal{Type: typeName, Value: prefix}
+	lit.pos = p.pos()
+	for p.k < len(p.sig) {
+		t := p.token()
+		if t.tokenType != SingleQuotedToken || (p.pos() != lit.pos && t.leadingWhiteSpace != "") {
+			break
+		}
+		lit.Value += t.tokenString
+		p.next()
+	}
+	lit.end = p.end()
+	return lit
+}
+
+// parseParenExpr parses a parenthesized expression, list of
+// expressions, or subquery
+func (p *parser) parseParenExpr() (e Expr, err error) {
+
+	start := p.pos()
+
+	if p.isDerivedTable() {
+		// a subquery, unless the query is only the start of a
+		// parenthesized expression ((SELECT 1) + 1)
+		k := p.k
+		p.next()
+		if q, err := p.parseQuery(); err == nil && p.accept(")") {
+			s := &SubqueryExpr{Query: q}
+			s.pos = start
+			s.end = p.end()
+			return s, nil
+		}
+		p.k = k
+	}
+
+	p.next()
+	l, err := p.parseExprList()
+	if err != nil {
+		return nil, err
+	}
+	if err = p.expect(")"); err != nil {
+		return nil, err
+	}
+
+	if len(l) > 1 {
+		le := &ListExpr{Exprs: l}
+		le.pos = start
+		le.end = p.end()
+		return le, nil
+	}
+
+	pe := &ParenExpr{Expr: l[0]}
+	pe.pos = start
+	pe.end = p.end()
+	return pe, nil
+}
+
+// parseFuncCall parses the arguments, and any WITHIN GROUP, FILTER, or
+// OVER clauses, of a function call
+func (p *parser) parseFuncCall(name string, start int) (e Expr, err error) {
+
+	f := &FuncCall{Name: name}
+	f.pos = start
+	p.next()
+
+	if p.is("*") && p.peek(1).tokenString == ")" {
+		f.Star = true
+		p.next()
+	} else if p.acceptWord("DISTINCT") {
+		f.Distinct = true
+	} else {
+		p.acceptWord("ALL")
+	}
+
+	separated := true
+	for p.k < len(p.sig) && !p.is(")") {
+		if p.accept(",") || argumentWords[p.word()] && p.acceptWord(p.word()) {
+			separated = true
+			continue
+		}
+		if p.word() == "ORDER" && tokenWord(p.peek(1)) == "BY" {
+			p.k += 2
+			if f.OrderBy, err = p.parseOrderBy(); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if !separated {
+			break
+		}
+
+		var arg Expr
+		if t := p.token(); t.tokenType == KeywordToken && tokenWord(p.peek(1)) == "FROM" {
+			// the field of EXTRACT(YEAR FROM d)
+			id := &Ident{Name: t.tokenString}
+			id.pos = p.pos()
+			p.next()
+			id.end = p.end()
+			arg = id
+		} else if arg, err = p.parseExpr(); err != nil {
+			return nil, err
+		}
+		f.Args = append(f.Args, arg)
+		separated = false
+	}
+	if err = p.expect(")"); err != nil {
+		return nil, err
+	}
+
+	if p.word() == "WITHIN" && tokenWord(p.peek(1)) == "GROUP" {
+		p.k += 2
+		if err = p.expect("("); err != nil {
+			return nil, err
+		}
+		if err = p.expectWord("ORDER"); err != nil {
+			return nil, err
+		}
+		if err = p.expectWord("BY"); err != nil {
+			return nil, err
+		}
+		if f.WithinGroup, err = p.parseOrderBy(); err != nil {
+			return nil, err
+		}
+		if err = p.expect(")"); err != nil {
+			return nil, err
+		}
+	}
+
+	if p.word() == "FILTER" && p.peek(1).tokenString == "(" {
+		p.k += 2
+		if err = p.expectWord("WHERE"); err != nil {
+			return nil, err
+		}
+		if f.Filter, err = p.parseExpr(); err != nil {
+			return nil, err
+		}
+		if err = p.expect(")"); err != nil {
+			return nil, err
+		}
+	}
+
+	if p.acceptWord("OVER") {
+		if f.Over, err = p.parseWindowSpec(); err != nil {
+			return nil, err
+		}
+	}
+
+	f.end = p.end()
+	return f, nil
+}
+
+// parseWindowSpec parses the window name or specification that follows
+// OVER
+func (p *parser) parseWindowSpec() (w *WindowSpec, err error) {
+
+	w = &WindowSpec{}
+	w.pos = p.pos()
+
+	if !p.accept("(") {
+		if w.Name = p.parseName(); w.Name == "" {
+			return nil, p.errorf("expected window, found %s", p.found())
+		}
+		w.end = p.end()
+		return w, nil
+	}
+
+	switch p.word() {
+	case "PARTITION", "ORDER", "ROWS", "RANGE", "GROUPS":
+	default:
+		w.Name = p.parseName()
+	}
+
+	if p.word() == "PARTITION" && tokenWord(p.peek(1)) == "BY" {
+		p.k += 2
+		if w.PartitionBy, err = p.parseExprList(); err != nil {
+			return nil, err
+		}
+	}
+
+	if p.word() == "ORDER" && tokenWord(p.peek(1)) == "BY" {
+		p.k += 2
+		if w.OrderBy, err = p.parseOrderBy(); err != nil {
+			return nil, err
+		}
+	}
+
+	switch p.word() {
+	case "ROWS", "RANGE", "GROUPS":
+		frame := &RawExpr{}
+		frame.pos = p.pos()
+		for p.k < len(p.sig) && !p.is(")") {
+			if p.is("(") {
+				p.skipGroup()
+				continue
+			}
+			p.next()
+		}
+		frame.end = p.end()
+		w.Frame = frame
+	}
+
+	if err = p.expect(")"); err != nil {
+		return nil, err
+	}
+
+	w.end = p.end()
+	return w, nil
+}
+
+// parseCase parses a CASE expression
+func (p *parser) parseCase() (e Expr, err error) {
+
+	c := &CaseExpr{}
+	c.pos = p.pos()
+	p.next()
+
+	if p.word() != "WHEN" {
+		if c.Operand, err = p.parseExpr(); err != nil {
+			return nil, err
+		}
+	}
+
+	for p.word() == "WHEN" {
+		w := &When{}
+		w.pos = p.pos()
+		p.next()
+		if w.Cond, err = p.parseExpr(); err != nil {
+			return nil, err
+		}
+		if err = p.expectWord("THEN"); err != nil {
+			return nil, err
+		}
+		if w.Result, err = p.parseExpr(); err != nil {
+			return nil, err
+		}
+		w.end = p.end()
+		c.Whens = append(c.Whens, w)
+	}
+	if len(c.Whens) == 0 {
+		return nil, p.errorf("expected WHEN, found %s", p.found())
+	}
+
+	if p.acceptWord("ELSE") {
+		if c.Else, err = p.parseExpr(); err != nil {
+			return nil, err
+		}
+	}
+	if err = p.expectWord("END"); err != nil {
+		return nil, err
+	}
+
+	c.end = p.end()
+	return c, nil
+}
+
+// parseCast parses a CAST(expr AS type) expression
+func (p *parser) parseCast() (e Expr, err error) {
+
+	c := &CastExpr{Op: p.word()}
+	c.pos = p.pos()
+	p.k += 2
+
+	if c.Expr, err = p.parseExpr(); err != nil {
+		return nil, err
+	}
+	if err = p.expectWord("AS"); err != nil {
+		return nil, err
+	}
+	c.DataType = p.parseDataType(true)
+	if c.Type = c.DataType.Text; c.Type == "" {
+		return nil, p.errorf("expected data type, found %s", p.found())
+	}
+	if err = p.expect(")"); err != nil {
+		return nil, err
+	}
+
+	c.end = p.end()
+	return c, nil
+}
+
+// parseInterval parses an INTERVAL expression
+func (p *parser) parseInterval() (e Expr, err error) {
+
+	iv := &IntervalExpr{}
+	iv.pos = p.pos()
+	p.next()
+
+	if iv.Value, err = p.parseUnary(); err != nil {
+		return nil, err
+	}
+
+	var units []string
+	for {
+		w := p.word()
+		if !isIntervalUnit(w) && (w != "TO" || len(units) == 0) {
+			break
+		}
+		units = append(units, w)
+		p.next()
+	}
+	iv.Unit = strings.Join(units, " ")
+
+	iv.end = p.end()
+	return iv, nil
+}
+
+// isIntervalUnit determines whether or not the word is an interval unit
+// (DAY, DAYS, DAY_SECOND, ...)
+func isIntervalUnit(w string) bool {
+	if w == "" {
+		return false
+	}
+	for _, u := range strings.Split(w, "_") {
+		if !intervalUnits[u] && !intervalUnits[strings.TrimSuffix(u, "S")] {
+			return false
+		}
+	}
+	return true
+}
+
+// parseTypeName parses a data type and returns the type as written
+// (with the white space normalized). When inParens is true the type
+// extends to the closing parenthesis of the enclosing CAST, otherwise
+// the type is a single name with any multi-word continuation, modifiers,
+// and array brackets (as in x::double precision or x::varchar(10)[]).
+func (p *parser) parseTypeName(inParens bool) string {
+
+	start := p.k
+	if inParens {
+		for p.k < len(p.sig) && !p.is(")") {
+			if p.is("(") {
+				p.skipGroup()
+				continue
+			}
+			p.next()
+		}
+		return p.tokenText(start, p.k)
+	}
+
+	if base, dims := splitArraySuffix(p.token().tokenString); dims > 0 && base != "" && p.token().tokenType == OtherToken {
+		// PostgreSQL array types such as integer[] are a single token
+		p.next()
+	} else if p.parseName() == "" {
+		// type names such as INT and CHAR are reserved in some dialects
+		if p.token().tokenType != KeywordToken {
+			return ""
+		}
+		p.next()
+	}
+	prev := tokenWord(p.peek(-1))
+	for p.k < len(p.sig) {
+		switch {
+		case p.is("("):
+			p.skipGroup()
+			continue
+		case p.is("[") && p.peek(1).tokenString == "]":
+			p.k += 2
+		case isArraySuffix(p.token().tokenString):
+			p.next()
+		case typeWordPairs[prev+" "+p.word()]:
+			p.next()
+		case (p.word() == "WITH" || p.word() == "WITHOUT") && tokenWord(p.peek(1)) == "TIME" && tokenWord(p.peek(2)) == "ZONE":
+			p.k += 3
+		case p.word() == "WITH" && tokenWord(p.peek(1)) == "LOCAL" && tokenWord(p.peek(2)) == "TIME" && tokenWord(p.peek(3)) == "ZONE":
+			p.k += 4
+		case p.word() == "UNSIGNED", p.word() == "SIGNED", p.word() == "ZEROFILL":
+			p.next()
+		default:
+			return p.tokenText(start, p.k)
+		}
+		prev = tokenWord(p.peek(-1))
+	}
+	return p.tokenText(start, p.k)
+}
+
+// tokenText returns the text of the significant tokens from the k-th up
+// to (but not including) the end-th, with a single space between words
+func (p *parser) tokenText(k, end int) string {
+
+	var b strings.Builder
+	for j := k; j < end; j++ {
+		t := p.tl.tokens[p.sig[j]]
+		if j > k {
+			prev := p.tl.tokens[p.sig[j-1]].tokenString
+			switch {
+			case prev == ",":
+				b.WriteString(" ")
+			case prev == "(", t.tokenString == "(", t.tokenString == ")", t.tokenString == ",", t.tokenString == "[", t.tokenString == "]":
+			case t.leadingWhiteSpace != "":
+				b.WriteString(" ")
+			}
+		}
+		b.WriteString(t.tokenString)
+	}
+	return b.String()
+}
@@ -0,0 +1,201 @@
+package sqlparse
+
+import (
+	"strings"
+	"testing"
+)
+
+// formatExpr renders an expression with parentheses around each
+// operation so that the precedence of the operators is visible
+func formatExpr(tl Tokens, e Expr) string {
+
+	exprs := func(l []Expr) string {
+		var s []string
+		for _, x := range l {
+			s = append(s, formatExpr(tl, x))
+		}
+		return strings.Join(s, ", ")
+	}
+	not := func(b bool) string {
+		if b {
+			return "NOT "
+		}
+		return ""
+	}
+
+	switch e := e.(type) {
+	case *Ident:
+		return e.Name
+	case *Literal:
+		if e.Type != "" {
+			return e.Type + " " + e.Value
+		}
+		return e.Value
+	case *Param:
+		return e.Name
+	case *BinaryExpr:
+		return "(" + formatExpr(tl, e.Left) + " " + e.Op + " " + formatExpr(tl, e.Right) + ")"
+	case *UnaryExpr:
+		if e.Postfix {
+			return "(" + formatExpr(tl, e.Expr) + " " + e.Op + ")"
+		}
+		return "(" + e.Op + " " + formatExpr(tl, e.Expr) + ")"
+	case *ParenExpr:
+		return formatExpr(tl, e.Expr)
+	case *ListExpr:
+		return "(" + exprs(e.Exprs) + ")"
+	case *FuncCall:
+		s := e.Name + "("
+		if e.Distinct {
+			s += "DISTINCT "
+		}
+		if e.Star {
+			s += "*"
+		}
+		s += exprs(e.Args) + ")"
+		if e.Filter != nil {
+			s += " FILTER " + formatExpr(tl, e.Filter)
+		}
+		if e.Over != nil {
+			s += " OVER (" + exprs(e.Over.PartitionBy)
+			for _, o := range e.Over.OrderBy {
+				s += " ORDER " + formatExpr(tl, o.Expr)
+			}
+			s += ")"
+		}
+		return s
+	case *CaseExpr:
+		s := "CASE"
+		if e.Operand != nil {
+			s += " " + formatExpr(tl, e.Operand)
+		}
+		for _, w := range e.Whens {
+			s += " WHEN " + formatExpr(tl, w.Cond) + " THEN " + formatExpr(tl, w.Result)
+		}
+		if e.Else != nil {
+			s += " ELSE " + formatExpr(tl, e.Else)
+		}
+		return s + " END"
+	case *CastExpr:
+		if e.Op == "::" {
+			return "(" + formatExpr(tl, e.Expr) + "::" + e.Type + ")"
+		}
+		return e.Op + "(" + formatExpr(tl, e.Expr) + " AS " + e.Type + ")"
+	case *BetweenExpr:
+		return "(" + formatExpr(tl, e.Expr) + " " + not(e.Not) + "BETWEEN " + formatExpr(tl, e.Low) + " AND " + formatExpr(tl, e.High) + ")"
+	case *InExpr:
+		list := exprs(e.List)
+		if e.Query != nil {
+			list = formatNode(tl, e.Query)
+		}
+		return "(" + formatExpr(tl, e.Expr) + " " + not(e.Not) + "IN (" + list + "))"
+	case *LikeExpr:
+		s := "(" + formatExpr(tl, e.Expr) + " " + not(e.Not) + e.Op + " " + formatExpr(tl, e.Pattern)
+		if e.Escape != nil {
+			s += " ESCAPE " + formatExpr(tl, e.Escape)
+		}
+		return s + ")"
+	case *IsExpr:
+		s := "(" + formatExpr(tl, e.Expr) + " IS " + not(e.Not) + e.Value
+		if e.Right != nil {
+			s += " " + formatExpr(tl, e.Right)
+		}
+		return s + ")"
+	case *SubqueryExpr:
+		return strings.TrimSpace(e.Op + " (" + formatNode(tl, e.Query) + ")")
+	case *IntervalExpr:
+		return strings.TrimSpace("INTERVAL " + formatExpr(tl, e.Value) + " " + e.Unit)
+	}
+
+	return formatNode(tl, e)
+}
+
+func TestParseExpr(t *testing.T) {
+
+	cases := []struct {
+		input    string
+		dialect  int
+		expected string
+	}{
+		// PostgreSQL
+		{"a + b * c - d", PostgreSQL, "((a + (b * c)) - d)"},
+		{"NOT a = 1 AND b IS NOT DISTINCT FROM c OR d ISNULL", PostgreSQL, "(((NOT (a = 1)) AND (b IS NOT DISTINCT FROM c)) OR (d IS NULL))"},
+		{"x::int + y->>'k' || 'z'", PostgreSQL, "((((x::int) + y) ->> 'k') || 'z')"},
+		{"- a ^ 2 ^ 3", PostgreSQL, "(((- a) ^ 2) ^ 3)"},
+		{"a BETWEEN 1 AND 2 AND b NOT IN (1, 2)", PostgreSQL, "((a BETWEEN 1 AND 2) AND (b NOT IN (1, 2)))"},
+		{"name NOT ILIKE 'a!%%' ESCAPE '!'", PostgreSQL, "(name NOT ILIKE 'a!%%' ESCAPE '!')"},
+		{"s SIMILAR TO '(a|b)%' AND t IS TRUE", PostgreSQL, "((s SIMILAR TO '(a|b)%') AND (t IS TRUE))"},
+		{"CASE WHEN a > 0 THEN 'p' WHEN a < 0 THEN 'n' ELSE 'z' END", PostgreSQL, "CASE WHEN (a > 0) THEN 'p' WHEN (a < 0) THEN 'n' ELSE 'z' END"},
+		{"CASE a WHEN 1 THEN 'it''s' END", PostgreSQL, "CASE a WHEN 1 THEN 'it''s' END"},
+		{"CAST(a AS character varying(10)) = b::timestamp with time zone", PostgreSQL, "(CAST(a AS character varying(10)) = (b::timestamp with time zone))"},
+		{"EXISTS (SELECT 1 FROM t WHERE t.id = x.id) AND a = ANY (SELECT b FROM u)", PostgreSQL, "(EXISTS (SELECT 1 FROM t WHERE t.id = x.id) AND (a = ANY (SELECT b FROM u)))"},
+		{"count(DISTINCT a) FILTER (WHERE b > 0) + row_number() OVER (PARTITION BY c ORDER BY d DESC)", PostgreSQL, "(count(DISTINCT a) FILTER (b > 0) + row_number() OVER (c ORDER d))"},
+		{"extract(year FROM now()) - 1 + count(*)", PostgreSQL, "((extract(year, now()) - 1) + count(*))"},
+		{"trim(BOTH ' ' FROM s) || substring(s FROM 2 FOR 3) || position('a' IN s)", PostgreSQL, "((trim(' ', s) || substring(s, 2, 3)) || position('a', s))"},
+		{"ts AT TIME ZONE 'UTC' > now() - INTERVAL '1 day'", PostgreSQL, "((ts AT TIME ZONE 'UTC') > (now() - INTERVAL '1 day'))"},
+		{"a -1 = $1 AND (b, c) = (1, 2)", PostgreSQL, "(((a - 1) = $1) AND ((b, c) = (1, 2)))"},
+		{"(SELECT max(a) FROM t) + 1 > DATE '2020-01-01'", PostgreSQL, "(((SELECT max(a) FROM t) + 1) > DATE '2020-01-01')"},
+		{"a COLLATE \"C\" < b", PostgreSQL, "((a COLLATE \"C\") < b)"},
+
+		// MySQL
+		{"a <=> b XOR c AND d", MySQL, "((a <=> b) XOR (c AND d))"},
+		{"a || b && c", MySQL, "(a || (b && c))"},
+		{"a DIV 2 MOD 3 + 1", MySQL, "(((a DIV 2) MOD 3) + 1)"},
+		{"col->>'$.name' = 'x' AND d > NOW() - INTERVAL 1 DAY", MySQL, "(((col ->> '$.name') = 'x') AND (d > (NOW() - INTERVAL 1 DAY)))"},
+		{"! a = b", MySQL, "((! a) = b)"},
+		{"a NOT REGEXP '^x' OR b SOUNDS LIKE c", MySQL, "((a NOT REGEXP '^x') OR (b SOUNDS LIKE c))"},
+		{"group_concat(a ORDER BY a SEPARATOR ',')", MySQL, "group_concat(a, ',')"},
+
+		// MSSQL
+		{"a + b & c", MSSQL, "((a + b) & c)"},
+		{"NOT a LIKE 'x%' AND b = N'y'", MSSQL, "((NOT (a LIKE 'x%')) AND (b = N'y'))"},
+		{"TRY_CAST(@p1 AS int) + LEFT(s, 2)", MSSQL, "(TRY_CAST(@p1 AS int) + LEFT(s, 2))"},
+
+		// Oracle
+		{"a.id = b.id(+) AND x || y = 'z'", Oracle, "((a.id = (b.id (+))) AND ((x || y) = 'z'))"},
+		{"PRIOR id = parent_id", Oracle, "((PRIOR id) = parent_id)"},
+
+		// SQLite
+		{"a = b < c", SQLite, "(a = (b < c))"},
+		{"x ISNULL OR y NOTNULL OR z GLOB 'x*'", SQLite, "(((x IS NULL) OR (y IS NOT NULL)) OR (z GLOB 'x*'))"},
+
+		// Standard SQL
+		{"a + -b * c", StandardSQL, "(a + ((- b) * c))"},
+	}
+
+	for _, c := range cases {
+		tl := ParseStatements(c.input, c.dialect)
+		e, err := ParseExpr(tl, c.dialect)
+		if err != nil {
+			t.Errorf("%s %q: unexpected error: %s", SQLDialectName(c.dialect), c.input, err)
+			continue
+		}
+		if got := formatExpr(tl, e); got != c.expected {
+			t.Errorf("%s %q:\n  expected %s\n  got      %s", SQLDialectName(c.dialect), c.input, c.expected, got)
+		}
+	}
+}
+
+func TestParseExprPositions(t *testing.T) {
+
+	tl := ParseStatements("-- the total\n  price * qty AS total", PostgreSQL)
+	p := newParser(&tl, PostgreSQL)
+	e, err := p.parseExpr()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b, ok := e.(*BinaryExpr)
+	if !ok {
+		t.Fatalf("expected a BinaryExpr, got %T", e)
+	}
+	if b.Pos() != 1 || b.End() != 4 {
+		t.Errorf("expected tokens 1 to 4, got %d to %d", b.Pos(), b.End())
+	}
+	if line, col := tl.Position(b.Right.Pos()); line != 2 || col != 11 {
+		t.Errorf("expected qty at 2:11, got %d:%d", line, col)
+	}
+	if p.word() != "AS" {
+		t.Errorf("expected the expression to end before AS, found %s", p.found())
+	}
+}
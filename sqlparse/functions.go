@@ -0,0 +1,172 @@
+package sqlparse
+
+/*
+
+functions.go provides the functionality for identifying function calls
+and for looking up the built-in functions of the dialects.
+
+*/
+
+import (
+	d "github.com/gsiems/sql-parse/dialects"
+)
+
+// map[previous word]bool of the words that indicate that an identifier
+// followed by an opening parenthesis is a table (or other object) name,
+// a column list, or a data type rather than a function call
+var notFunctionWords = map[string]bool{
+	"AS":         true,
+	"COPY":       true,
+	"EXISTS":     true,
+	"INDEX":      true,
+	"INSERT":     true,
+	"INTO":       true,
+	"KEY":        true,
+	"RECURSIVE":  true,
+	"REFERENCES": true,
+	"RETURNS":    true,
+	"TABLE":      true,
+	"TYPE":       true,
+	"VIEW":       true,
+	"WITH":       true,
+}
+
+// map[keyword]bool of the keywords that may be followed by an opening
+// parenthesis without being function names (along with the clauseWords,
+// other than LEFT and RIGHT)
+var notFunctionKeywords = map[string]bool{
+	"ALL":      true,
+	"ANY":      true,
+	"CHECK":    true,
+	"EXISTS":   true,
+	"FILTER":   true,
+	"IDENTITY": true,
+	"IN":       true,
+	"INCLUDE":  true,
+	"KEY":      true,
+	"LATERAL":  true,
+	"OVER":     true,
+	"SET":      true,
+	"SOME":     true,
+	"UNIQUE":   true,
+	"VALUES":   true,
+}
+
+// FunctionInfo returns the catalog entry for the supplied built-in
+// function name in the specified SQL dialect
+func FunctionInfo(s string, dialect int) (f d.FunctionInfo, ok bool) {
+
+	switch dialect {
+	case PostgreSQL:
+		return d.PostgreSQLFunction(s)
+	case SQLite:
+		return d.SQLiteFunction(s)
+	case MySQL:
+		return d.MySQLFunction(s)
+	case Oracle:
+		return d.OracleFunction(s)
+	case MSSQL:
+		return d.MSSQLFunction(s)
+	case MariaDB:
+		return d.MariaDBFunction(s)
+	default:
+		return d.StandardFunction(s)
+	}
+}
+
+// IsFunction returns true if the supplied string is the name of a
+// built-in function in the specified SQL dialect
+func IsFunction(s string, dialect int) bool {
+	_, ok := FunctionInfo(s, dialect)
+	return ok
+}
+
+// tagFunctions sets the type of those identifiers, and keywords, that
+// are function names to FunctionToken. An identifier is a function name
+// when it is followed by an opening parenthesis and it is not a table
+// name (INSERT INTO t (a, b)), a common table expression (WITH t (a) AS
+// ...), or a data type (name VARCHAR2(50)). Keywords are function names
+// under the same conditions when they are built-in functions (COUNT,
+// NVL, COALESCE, LEFT, ...).
+func (d *Tokens) tagFunctions(dialect int) {
+
+	prev := -1
+	isIndex := false
+
+	for i := 0; i < d.length; i++ {
+		t := d.tokens[i]
+		if !SignificantTokens(t) {
+			continue
+		}
+
+		switch {
+		case t.tokenType == OtherToken && t.tokenString == ";":
+			isIndex = false
+		case tokenWord(t) == "INDEX":
+			isIndex = true
+		case (t.tokenType == IdentToken || isFunctionKeyword(t, dialect)) && d.isFunctionName(i, prev, isIndex, dialect):
+			d.tokens[i].tokenType = FunctionToken
+		}
+		prev = i
+	}
+}
+
+// isFunctionKeyword determines whether or not the keyword token may be
+// the name of a function. Only the keywords that are built-in functions
+// of the dialect qualify as keywords that are not functions (WHEN, BY,
+// RETURN, ...) may also be followed by an opening parenthesis.
+func isFunctionKeyword(t Token, dialect int) bool {
+
+	if t.tokenType != KeywordToken {
+		return false
+	}
+
+	w := tokenWord(t)
+	switch {
+	case notFunctionKeywords[w]:
+		return false
+	case w == "LEFT", w == "RIGHT":
+	case clauseWords[w]:
+		return false
+	}
+
+	return IsFunction(t.tokenString, dialect)
+}
+
+// isFunctionName determines whether or not the identifier (or keyword)
+// at index i is the name of a function (see tagFunctions). The index of
+// the preceding significant token is prev.
+func (d *Tokens) isFunctionName(i, prev int, isIndex bool, dialect int) bool {
+
+	next := i + 1
+	for next < d.length && !SignificantTokens(d.tokens[next]) {
+		next++
+	}
+	if next >= d.length || d.tokens[next].tokenString != "(" {
+		return false
+	}
+
+	s := d.tokens[i].tokenString
+	if _, ok := DataTypeInfo(s, dialect); ok && !IsFunction(s, dialect) {
+		return false
+	}
+
+	if prev < 0 {
+		return true
+	}
+	p := d.tokens[prev]
+	switch p.tokenType {
+	case IdentToken, FunctionToken, DoubleQuotedToken, BacktickQuotedToken, BracketQuotedToken:
+		// the data type of a column, parameter, or variable
+		return false
+	case OperatorToken:
+		return p.tokenString != "::"
+	}
+
+	w := tokenWord(p)
+	if w == "ON" {
+		// CREATE INDEX ... ON t (a, b)
+		return !isIndex
+	}
+	return !notFunctionWords[w]
+}
@@ -0,0 +1,71 @@
+package sqlparse
+
+import (
+	"strings"
+	"testing"
+
+	d "github.com/gsiems/sql-parse/dialects"
+)
+
+func TestFunctionTokens(t *testing.T) {
+
+	cases := []struct {
+		dialect  int
+		s        string
+		expected string
+	}{
+		{PostgreSQL, "SELECT app.total(o.id), string_agg(name, ',') FROM generate_series(1, 10) g", "app.total, string_agg, generate_series"},
+		{PostgreSQL, "INSERT INTO t (a, b) SELECT x::my_type(10) FROM s", ""},
+		{PostgreSQL, "WITH cte (a) AS (SELECT 1) SELECT * FROM cte", ""},
+		{PostgreSQL, "CREATE INDEX t_ix ON t (lower(a)); SELECT * FROM a JOIN b ON fn(a.x) = b.x", "lower, fn"},
+		{MySQL, "CREATE TABLE t (id int, KEY t_ix (id), FOREIGN KEY (id) REFERENCES p(id))", ""},
+		{Oracle, "CREATE TABLE t (name VARCHAR2(50), d DATE DEFAULT app_util.first_day(SYSDATE))", "app_util.first_day"},
+		{MSSQL, "SELECT DATEADD(day, 1, GETDATE()), ISNULL(a, 0) FROM t", "DATEADD, GETDATE, ISNULL"},
+		{Oracle, "SELECT count(*), sum(a), nvl(b, 0), lower(c), substr(d, 1, 2) FROM t", "count, sum, nvl, lower, substr"},
+		{Oracle, "SELECT coalesce(a, b) FROM t WHERE x IN (1, 2) AND CASE WHEN (a = 1) THEN 1 END = 1", "coalesce"},
+		{Oracle, "CREATE TABLE t (id NUMBER(10), CONSTRAINT t_pk PRIMARY KEY (id), CHECK (id > 0), UNIQUE (id))", ""},
+		{PostgreSQL, "SELECT coalesce(a, 0), left(b, 1) FROM t ORDER BY (SELECT 1)", "coalesce, left"},
+		{MySQL, "SELECT left(b, 1) FROM t LEFT JOIN u ON t.id = u.id", "left"},
+	}
+
+	for _, c := range cases {
+		tl := ParseStatements(c.s, c.dialect)
+		var got []string
+		for i := 0; i < tl.Len(); i++ {
+			if tk := tl.At(i); tk.Type() == FunctionToken {
+				got = append(got, tk.Value())
+			}
+		}
+		if strings.Join(got, ", ") != c.expected {
+			t.Errorf("%s %q: expected %q, got %q", SQLDialectName(c.dialect), c.s, c.expected, strings.Join(got, ", "))
+		}
+	}
+}
+
+func TestFunctionInfo(t *testing.T) {
+
+	cases := []struct {
+		dialect int
+		s       string
+		found   bool
+		kind    int
+		args    int
+		accepts bool
+	}{
+		{Oracle, "nvl", true, d.ScalarFunction, 2, true},
+		{PostgreSQL, "NVL", false, d.NullFunction, 2, false},
+		{PostgreSQL, "string_agg", true, d.AggregateFunction, 1, false},
+		{MySQL, "GROUP_CONCAT", true, d.AggregateFunction, 5, true},
+		{MSSQL, "ROW_NUMBER", true, d.WindowFunction, 0, true},
+		{SQLite, "json_extract", true, d.ScalarFunction, 1, false},
+		{StandardSQL, "COALESCE", true, d.ScalarFunction, 3, true},
+	}
+
+	for _, c := range cases {
+		f, ok := FunctionInfo(c.s, c.dialect)
+		if ok != c.found || f.Kind != c.kind || f.AcceptsArgs(c.args) != c.accepts {
+			t.Errorf("%s %s: expected %v %s %v, got %v %s %v", SQLDialectName(c.dialect), c.s,
+				c.found, d.FunctionKindName(c.kind), c.accepts, ok, d.FunctionKindName(f.Kind), f.AcceptsArgs(c.args))
+		}
+	}
+}
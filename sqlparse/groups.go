@@ -0,0 +1,193 @@
+package sqlparse
+
+/*
+
+groups.go provides the functionality for determining the nesting of
+parenthesized groups of tokens within the token list.
+
+*/
+
+import (
+	"fmt"
+)
+
+// Group provides a parenthesized group of tokens
+type Group struct {
+	Open     int      // the index of the opening parenthesis
+	Close    int      // the index of the closing parenthesis (-1 if there is no closing parenthesis)
+	Depth    int      // the nesting depth of the group (outer-most groups have a depth of 1)
+	Parent   *Group   // the group that encloses this group (nil for outer-most groups)
+	Children []*Group // the groups that are directly enclosed by this group
+}
+
+// GroupError provides the diagnostic for a mismatched parenthesis
+type GroupError struct {
+	Index int    // the index of the mismatched parenthesis
+	Msg   string // the description of the mismatch
+}
+
+// Error implements the error interface for the group error
+func (e GroupError) Error() string {
+	return fmt.Sprintf("token %d: %s", e.Index, e.Msg)
+}
+
+// Contains returns true if the token at index i is within the group.
+// The opening and closing parentheses are considered to be part of
+// the group.
+func (g *Group) Contains(i int) bool {
+	if i < g.Open {
+		return false
+	}
+	if g.Close < 0 {
+		return true
+	}
+	return i <= g.Close
+}
+
+// Groups returns the tree of parenthesized groups in the token list
+// along with a list of any mismatched parentheses. Opening parentheses
+// that are never closed result in a group with a Close index of -1.
+func (d *Tokens) Groups() (groups []*Group, errs []GroupError) {
+
+	var stack []*Group
+
+	for i := 0; i < d.length; i++ {
+		if d.tokens[i].tokenType != OtherToken {
+			continue
+		}
+
+		switch d.tokens[i].tokenString {
+		case "(":
+			g := &Group{
+				Open:  i,
+				Close: -1,
+				Depth: len(stack) + 1,
+			}
+			if len(stack) > 0 {
+				g.Parent = stack[len(stack)-1]
+				g.Parent.Children = append(g.Parent.Children, g)
+			} else {
+				groups = append(groups, g)
+			}
+			stack = append(stack, g)
+
+		case ")":
+			if len(stack) == 0 {
+				errs = append(errs, GroupError{Index: i, Msg: "unmatched closing parenthesis"})
+				continue
+			}
+			stack[len(stack)-1].Close = i
+			stack = stack[:len(stack)-1]
+		}
+	}
+
+	for _, g := range stack {
+		errs = append(errs, GroupError{Index: g.Open, Msg: "unclosed opening parenthesis"})
+	}
+
+	return groups, errs
+}
+
+// GroupIndex provides the parenthesized groups of a token list indexed
+// by token so that the enclosing group of any token can be found
+// without walking the group tree
+type GroupIndex struct {
+	groups    []*Group // the outer-most groups
+	enclosing []*Group // the inner-most group that contains each token (nil for tokens that are not in a group)
+}
+
+// GroupIndex returns the index of the parenthesized groups in the token
+// list. The index is built in a single pass over the token list and
+// should be reused when looking up more than one token, as each call to
+// EnclosingGroup, Depth, or MatchingParen of the token list builds the
+// index anew.
+func (d *Tokens) GroupIndex() (x GroupIndex) {
+
+	x.groups, _ = d.Groups()
+	x.enclosing = make([]*Group, d.length)
+
+	var index func(groups []*Group)
+	index = func(groups []*Group) {
+		for _, g := range groups {
+			end := g.Close
+			if end < 0 {
+				end = d.length - 1
+			}
+			for i := g.Open; i <= end; i++ {
+				x.enclosing[i] = g
+			}
+			// the children overwrite the tokens that they contain
+			index(g.Children)
+		}
+	}
+	index(x.groups)
+
+	return x
+}
+
+// Groups returns the outer-most groups of the index
+func (x *GroupIndex) Groups() []*Group {
+	return x.groups
+}
+
+// EnclosingGroup returns the inner-most group that contains the token
+// at index i. If the token is not within any group then nil is returned.
+func (x *GroupIndex) EnclosingGroup(i int) *Group {
+	if i < 0 || i >= len(x.enclosing) {
+		return nil
+	}
+	return x.enclosing[i]
+}
+
+// Depth returns the parenthesis nesting depth of the token at index i.
+// Tokens that are not enclosed by any parentheses have a depth of zero.
+func (x *GroupIndex) Depth(i int) int {
+	if g := x.EnclosingGroup(i); g != nil {
+		return g.Depth
+	}
+	return 0
+}
+
+// MatchingParen returns the index of the parenthesis that matches the
+// parenthesis at index i. If the token at index i is not a parenthesis,
+// or if there is no matching parenthesis, then -1 is returned.
+func (x *GroupIndex) MatchingParen(i int) int {
+
+	g := x.EnclosingGroup(i)
+	switch {
+	case g == nil:
+	case g.Open == i:
+		return g.Close
+	case g.Close == i:
+		return g.Open
+	}
+	return -1
+}
+
+// EnclosingGroup returns the inner-most group that contains the token
+// at index i. If the token is not within any group then nil is returned.
+// The groups are determined on each call, use GroupIndex when looking up
+// more than one token.
+func (d *Tokens) EnclosingGroup(i int) *Group {
+	x := d.GroupIndex()
+	return x.EnclosingGroup(i)
+}
+
+// Depth returns the parenthesis nesting depth of the token at index i.
+// Tokens that are not enclosed by any parentheses have a depth of zero.
+// The groups are determined on each call, use GroupIndex when looking up
+// more than one token.
+func (d *Tokens) Depth(i int) int {
+	x := d.GroupIndex()
+	return x.Depth(i)
+}
+
+// MatchingParen returns the index of the parenthesis that matches the
+// parenthesis at index i. If the token at index i is not a parenthesis,
+// or if there is no matching parenthesis, then -1 is returned. The
+// groups are determined on each call, use GroupIndex when looking up
+// more than one token.
+func (d *Tokens) MatchingParen(i int) int {
+	x := d.GroupIndex()
+	return x.MatchingParen(i)
+}
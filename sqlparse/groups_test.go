@@ -0,0 +1,84 @@
+package sqlparse
+
+import (
+	"testing"
+)
+
+func TestGroups(t *testing.T) {
+
+	// 0      1 2 3 4 5 6 7 8 9    10 11    12 13 14 15 16
+	// SELECT f ( a , ( b ) ) FROM t  WHERE x  IN ( 1  )
+	tl := ParseStatements("SELECT f(a, (b)) FROM t WHERE x IN (1)", PostgreSQL)
+
+	groups, errs := tl.Groups()
+	if len(errs) != 0 {
+		t.Errorf("unexpected errors: %v", errs)
+	}
+	if len(groups) != 2 {
+		t.Fatalf("expected 2 outer groups, got %d", len(groups))
+	}
+	if groups[0].Open != 2 || groups[0].Close != 8 {
+		t.Errorf("expected first group 2..8, got %d..%d", groups[0].Open, groups[0].Close)
+	}
+	if len(groups[0].Children) != 1 || groups[0].Children[0].Depth != 2 {
+		t.Errorf("expected a single nested group of depth 2")
+	}
+
+	cases := []struct {
+		idx   int
+		depth int
+		match int
+	}{
+		{1, 0, -1},
+		{2, 1, 8},
+		{6, 2, -1},
+		{7, 2, 5},
+		{8, 1, 2},
+		{14, 1, 16},
+		{15, 1, -1},
+	}
+
+	x := tl.GroupIndex()
+	for _, c := range cases {
+		if got := tl.Depth(c.idx); got != c.depth {
+			t.Errorf("Depth(%d): expected %d, got %d", c.idx, c.depth, got)
+		}
+		if got := tl.MatchingParen(c.idx); got != c.match {
+			t.Errorf("MatchingParen(%d): expected %d, got %d", c.idx, c.match, got)
+		}
+		if got := x.Depth(c.idx); got != c.depth {
+			t.Errorf("GroupIndex.Depth(%d): expected %d, got %d", c.idx, c.depth, got)
+		}
+		if got := x.MatchingParen(c.idx); got != c.match {
+			t.Errorf("GroupIndex.MatchingParen(%d): expected %d, got %d", c.idx, c.match, got)
+		}
+	}
+	if len(x.Groups()) != 2 || x.EnclosingGroup(-1) != nil || x.EnclosingGroup(17) != nil {
+		t.Errorf("GroupIndex: unexpected groups")
+	}
+
+	if g := tl.EnclosingGroup(6); g == nil || g.Open != 5 || g.Parent == nil || g.Parent.Open != 2 {
+		t.Errorf("EnclosingGroup(6): unexpected group %v", g)
+	}
+}
+
+func TestGroupsMismatched(t *testing.T) {
+
+	tl := ParseStatements("SELECT (a)) + ((b)", PostgreSQL)
+
+	groups, errs := tl.Groups()
+	if len(errs) != 2 {
+		t.Fatalf("expected 2 errors, got %d: %v", len(errs), errs)
+	}
+	if errs[0].Index != 4 || errs[1].Index != 6 {
+		t.Errorf("unexpected error indexes: %v", errs)
+	}
+	if groups[1].Close != -1 {
+		t.Errorf("expected unclosed group, got close at %d", groups[1].Close)
+	}
+
+	x := tl.GroupIndex()
+	if x.Depth(9) != 2 || x.Depth(5) != 0 || x.MatchingParen(6) != -1 || x.MatchingParen(7) != 9 {
+		t.Errorf("unexpected depths or matches for the unclosed group")
+	}
+}
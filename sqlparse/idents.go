@@ -0,0 +1,193 @@
+package sqlparse
+
+/*
+
+idents.go provides the functionality for quoting and unquoting
+identifiers per the rules of the dialects.
+
+*/
+
+import (
+	"strings"
+)
+
+// NameFolding provides the case sensitivity of names for the dialects
+// where that depends on the configuration of the database. Names are
+// case insensitive for those dialects by default.
+type NameFolding struct {
+	MySQLCaseSensitive bool // names are case sensitive in MySQL and MariaDB (lower_case_table_names=0)
+	MSSQLCaseSensitive bool // names are case sensitive in MSSQL (the database has a case sensitive collation)
+}
+
+// CanonicalName returns the canonical form of the, possibly qualified
+// and possibly quoted, name in the token for comparing names in the
+// specified SQL dialect. Names are case insensitive for MySQL, MariaDB,
+// MSSQL and SQLite (see NameFolding for case sensitive databases).
+func CanonicalName(t Token, dialect int) string {
+	return NameFolding{}.CanonicalName(t, dialect)
+}
+
+// CanonicalObjectName returns the canonical form of the, possibly
+// qualified and possibly quoted, name as written (see CanonicalName)
+func CanonicalObjectName(name string, dialect int) string {
+	return NameFolding{}.CanonicalObjectName(name, dialect)
+}
+
+// CanonicalName returns the canonical form of the name in the token for
+// comparing names in the specified SQL dialect. Two names refer to the
+// same object when their canonical names are equal.
+//
+// Unquoted names are folded to lower case for PostgreSQL and to upper
+// case for Oracle and standard SQL while quoted names are used as
+// written (without the quotes). For the case insensitive dialects both
+// quoted and unquoted names are folded to lower case.
+func (f NameFolding) CanonicalName(t Token, dialect int) string {
+	return f.CanonicalObjectName(t.tokenString, dialect)
+}
+
+// CanonicalObjectName returns the canonical form of the, possibly
+// qualified and possibly quoted, name as written (see CanonicalName)
+func (f NameFolding) CanonicalObjectName(name string, dialect int) string {
+
+	parts := splitQualifiedName(name)
+	for i, part := range parts {
+		quoted := isQuotedIdentifier(part)
+		if quoted {
+			part = unquoteIdentifier(part)
+		}
+
+		switch {
+		case isMySQLFamily(dialect) && f.MySQLCaseSensitive, dialect == MSSQL && f.MSSQLCaseSensitive:
+		case isMySQLFamily(dialect), dialect == MSSQL, dialect == SQLite:
+			part = strings.ToLower(part)
+		case !quoted:
+			part = foldIdentifier(part, dialect)
+		}
+		parts[i] = part
+	}
+	return strings.Join(parts, ".")
+}
+
+// NeedsQuoting returns true if the identifier must be quoted in order
+// to be used as-is in the specified SQL dialect. That is the case when
+// the identifier is not a valid unquoted identifier for the dialect,
+// when it is a reserved keyword, or when the dialect would fold the case
+// of the unquoted identifier (PostgreSQL folds to lower case, Oracle and
+// standard SQL to upper case). Quoting does not affect the case
+// sensitivity of identifiers in MySQL, MariaDB, MSSQL, and SQLite (for
+// MySQL that depends on the lower_case_table_names setting instead) so
+// the case is not considered for those dialects.
+//
+// The keyword lists for MariaDB, MSSQL, and SQLite do not distinguish
+// the reserved keywords so any keyword needs quoting for those dialects.
+//
+// The identifier is a single name, a name containing a period needs
+// quoting (see QuoteIdent).
+func NeedsQuoting(name string, dialect int) bool {
+
+	switch {
+	case name == "", strings.Contains(name, "."):
+		return true
+	case !IsIdentifier(name, dialect), IsReservedKeyword(name, dialect):
+		return true
+	case (dialect == MariaDB || dialect == MSSQL || dialect == SQLite) && IsKeyword(name, dialect):
+		return true
+	}
+	return foldIdentifier(name, dialect) != name
+}
+
+// QuoteIdent returns the identifier, quoted for the specified SQL dialect
+// if it needs quoting (see NeedsQuoting). Double quotes are used other
+// than for MySQL and MariaDB (back-ticks) and MSSQL (square brackets).
+// Qualified names should be quoted one part at a time.
+func QuoteIdent(name string, dialect int) string {
+	if !NeedsQuoting(name, dialect) {
+		return name
+	}
+	s, _ := quoteIdentifier(name, dialect)
+	return s
+}
+
+// UnquoteIdent returns the identifier of a quoted identifier token
+// (DoubleQuotedToken, BacktickQuotedToken, or BracketQuotedToken)
+// without the enclosing quotes and with any escaped closing quotes
+// unescaped. The value of any other type of token is returned as-is.
+// Note that quoted identifiers that contain escaped quotes are split
+// into adjacent tokens by the tokenizer (as are strings).
+func UnquoteIdent(t Token) string {
+	switch t.tokenType {
+	case DoubleQuotedToken, BacktickQuotedToken, BracketQuotedToken:
+		return unquoteIdentifier(t.tokenString)
+	}
+	return t.tokenString
+}
+
+// quoteIdentifier returns the name quoted for the dialect along with the
+// type of the quoted token
+func quoteIdentifier(name string, dialect int) (s string, tokenType int) {
+	switch {
+	case isMySQLFamily(dialect):
+		return "`" + strings.Replace(name, "`", "``", -1) + "`", BacktickQuotedToken
+	case dialect == MSSQL:
+		return "[" + strings.Replace(name, "]", "]]", -1) + "]", BracketQuotedToken
+	}
+	return `"` + strings.Replace(name, `"`, `""`, -1) + `"`, DoubleQuotedToken
+}
+
+// unquoteIdentifier returns the quoted identifier without the enclosing
+// quotes and with any escaped closing quotes unescaped
+func unquoteIdentifier(s string) string {
+	if len(s) < 2 {
+		return s
+	}
+	closing := s[len(s)-1:]
+	switch s[0] {
+	case '"', '`', '[':
+		return strings.Replace(s[1:len(s)-1], closing+closing, closing, -1)
+	}
+	return s
+}
+
+// splitQualifiedName returns the parts of a qualified name, periods
+// within quoted parts do not separate the parts
+func splitQualifiedName(name string) (parts []string) {
+
+	var closing byte
+	start := 0
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+		switch {
+		case closing != 0:
+			if c == closing {
+				if i+1 < len(name) && name[i+1] == closing {
+					// an escaped closing quote
+					i++
+				} else {
+					closing = 0
+				}
+			}
+		case c == '"' || c == '`':
+			closing = c
+		case c == '[':
+			closing = ']'
+		case c == '.':
+			parts = append(parts, name[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, name[start:])
+}
+
+// foldIdentifier returns the name with the case folded to that of an
+// unquoted identifier for the dialect. PostgreSQL folds to lower case,
+// Oracle and standard SQL to upper case, and the other dialects do not
+// fold names.
+func foldIdentifier(name string, dialect int) string {
+	switch dialect {
+	case PostgreSQL:
+		return strings.ToLower(name)
+	case MySQL, MariaDB, MSSQL, SQLite:
+		return name
+	}
+	return strings.ToUpper(name)
+}
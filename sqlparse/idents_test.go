@@ -0,0 +1,112 @@
+package sqlparse
+
+import (
+	"testing"
+)
+
+func TestQuoteIdent(t *testing.T) {
+
+	cases := []struct {
+		dialect  int
+		name     string
+		expected string
+	}{
+		{PostgreSQL, "customer_id", "customer_id"},
+		{PostgreSQL, "CustomerId", `"CustomerId"`},
+		{PostgreSQL, "order", `"order"`},
+		{PostgreSQL, "first name", `"first name"`},
+		{PostgreSQL, "1abc", `"1abc"`},
+		{PostgreSQL, `say "hi"`, `"say ""hi"""`},
+		{PostgreSQL, "x.y", `"x.y"`},
+		{Oracle, "CUSTOMER_ID", "CUSTOMER_ID"},
+		{Oracle, "customer_id", `"customer_id"`},
+		{Oracle, "LEVEL", `"LEVEL"`},
+		{MySQL, "CustomerId", "CustomerId"},
+		{MySQL, "1abc", "1abc"},
+		{MySQL, "order", "`order`"},
+		{MySQL, "a`b", "`a``b`"},
+		{MariaDB, "order", "`order`"},
+		{MSSQL, "CustomerId", "CustomerId"},
+		{MSSQL, "order", "[order]"},
+		{MSSQL, "a]b", "[a]]b]"},
+		{SQLite, "table", `"table"`},
+		{StandardSQL, "customer_id", `"customer_id"`},
+	}
+
+	for _, c := range cases {
+		got := QuoteIdent(c.name, c.dialect)
+		if got != c.expected {
+			t.Errorf("%s %q: expected %s, got %s", SQLDialectName(c.dialect), c.name, c.expected, got)
+		}
+		if NeedsQuoting(c.name, c.dialect) != (got != c.name) {
+			t.Errorf("%s %q: NeedsQuoting does not agree with QuoteIdent", SQLDialectName(c.dialect), c.name)
+		}
+	}
+}
+
+func TestUnquoteIdent(t *testing.T) {
+
+	cases := []struct {
+		input     string
+		tokenType int
+		expected  string
+	}{
+		{`"say ""hi"""`, DoubleQuotedToken, `say "hi"`},
+		{"`a``b`", BacktickQuotedToken, "a`b"},
+		{"[a]]b]", BracketQuotedToken, "a]b"},
+		{"[first name]", BracketQuotedToken, "first name"},
+		{"plain", IdentToken, "plain"},
+		{"'string'", SingleQuotedToken, "'string'"},
+	}
+
+	for _, c := range cases {
+		if got := UnquoteIdent(NewToken(c.input, c.tokenType, "")); got != c.expected {
+			t.Errorf("%s: expected %s, got %s", c.input, c.expected, got)
+		}
+	}
+
+	tl := ParseStatements("SELECT [first name] FROM t", MSSQL)
+	if got := UnquoteIdent(tl.At(1)); got != "first name" {
+		t.Errorf("expected first name, got %s", got)
+	}
+}
+
+func TestCanonicalName(t *testing.T) {
+
+	cases := []struct {
+		dialect  int
+		input    string
+		expected string
+	}{
+		{PostgreSQL, `Foo`, "foo"},
+		{PostgreSQL, `"Foo"`, "Foo"},
+		{PostgreSQL, `app."Order.Lines"`, "app.Order.Lines"},
+		{Oracle, `foo`, "FOO"},
+		{Oracle, `"foo"`, "foo"},
+		{Oracle, `hr."EMP"`, "HR.EMP"},
+		{StandardSQL, `Foo`, "FOO"},
+		{MySQL, "`Foo`", "foo"},
+		{MSSQL, "dbo.[Foo]", "dbo.foo"},
+		{SQLite, `"Foo"`, "foo"},
+	}
+
+	for _, c := range cases {
+		tl := ParseStatements(c.input, c.dialect)
+		if got := CanonicalObjectName(c.input, c.dialect); got != c.expected {
+			t.Errorf("%s %s: expected %s, got %s", SQLDialectName(c.dialect), c.input, c.expected, got)
+		}
+		if tl.Len() == 1 {
+			if got := CanonicalName(tl.At(0), c.dialect); got != c.expected {
+				t.Errorf("%s %s: expected %s from the token, got %s", SQLDialectName(c.dialect), c.input, c.expected, got)
+			}
+		}
+	}
+
+	f := NameFolding{MySQLCaseSensitive: true, MSSQLCaseSensitive: true}
+	if got := f.CanonicalObjectName("`Foo`.Bar", MySQL); got != "Foo.Bar" {
+		t.Errorf("expected Foo.Bar, got %s", got)
+	}
+	if got := f.CanonicalObjectName("dbo.[Foo]", MSSQL); got != "dbo.Foo" {
+		t.Errorf("expected dbo.Foo, got %s", got)
+	}
+}
@@ -0,0 +1,226 @@
+package sqlparse
+
+/*
+
+injection.go provides the functionality for detecting when the input
+that was concatenated into a query has changed the token structure of
+the query (SQL injection).
+
+*/
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Injection finding kinds
+const (
+	// NullInjection indicates an undefined kind of finding
+	NullInjection = iota
+	// StructureInjection is any change to the token structure of the query
+	StructureInjection
+	// StackedQueryInjection is an additional statement following a ;
+	StackedQueryInjection
+	// CommentInjection is a comment that is not in the template
+	CommentInjection
+	// TautologyInjection is a condition that is always true (OR 1=1)
+	TautologyInjection
+	// UnionInjection is a UNION SELECT that is not in the template
+	UnionInjection
+)
+
+// InjectionFinding provides the diagnostic for a change to the token
+// structure of a query
+type InjectionFinding struct {
+	Kind   int    // the kind of finding
+	Index  int    // the index of the first offending token in the query token list
+	Line   int    // the line number of the token
+	Col    int    // the column number of the token
+	Tokens string // the text of the offending tokens
+	Msg    string // the description of the finding
+}
+
+// Error implements the error interface for the injection finding
+func (e InjectionFinding) Error() string {
+	return fmt.Sprintf("line %d, column %d: %s: %s", e.Line, e.Col, e.Msg, e.Tokens)
+}
+
+// InjectionKindName returns the string representation of the injection
+// finding kind
+func InjectionKindName(kind int) (s string) {
+
+	var names = map[int]string{
+		NullInjection:         "NullInjection",
+		StructureInjection:    "StructureInjection",
+		StackedQueryInjection: "StackedQueryInjection",
+		CommentInjection:      "CommentInjection",
+		TautologyInjection:    "TautologyInjection",
+		UnionInjection:        "UnionInjection",
+	}
+
+	if s, ok := names[kind]; ok {
+		return s
+	}
+	return ""
+}
+
+// CheckInjection compares the token structure of a query with that of
+// the template that the query was built from. The points in the
+// template where input is inserted are bind parameters (?, :name, ...)
+// or literals (an empty string or 0), each of which must match a single
+// literal in the query. The tokens of the query that differ from the
+// template are reported, classified where possible as stacked queries,
+// comments, tautologies, or UNION injections.
+//
+// The extent of each string literal is determined per the quoting and
+// escaping rules of the dialect (as for Redact), so that input that
+// escapes the closing quote of a literal with a backslash (MySQL) is
+// detected.
+func CheckInjection(template, query string, dialect int) (findings []InjectionFinding) {
+
+	tt, _ := literalTokens(ParseStatements(template, dialect), dialect)
+	qt, _ := literalTokens(ParseStatements(query, dialect), dialect)
+	ti := structuralTokens(tt)
+	qi := structuralTokens(qt)
+
+	// the matching prefix
+	p := 0
+	for p < len(ti) && p < len(qi) && tokensMatch(tt.tokens[ti[p]], qt.tokens[qi[p]]) {
+		p++
+	}
+	if p == len(ti) && p == len(qi) {
+		return nil
+	}
+
+	// the matching suffix, that does not overlap the prefix
+	s := 0
+	for s < len(ti)-p && s < len(qi)-p && tokensMatch(tt.tokens[ti[len(ti)-1-s]], qt.tokens[qi[len(qi)-1-s]]) {
+		s++
+	}
+
+	injected := qi[p : len(qi)-s]
+	if len(injected) == 0 {
+		// tokens were removed rather than added
+		i := 0
+		switch {
+		case p < len(qi):
+			i = qi[p]
+		case len(qi) > 0:
+			i = qi[len(qi)-1]
+		}
+		return []InjectionFinding{newFinding(&qt, StructureInjection, i, i, "the query is missing tokens of the template")}
+	}
+
+	findings = classifyInjection(&qt, injected)
+	if len(findings) == 0 {
+		findings = append(findings, newFinding(&qt, StructureInjection, injected[0], injected[len(injected)-1], "the token structure of the query differs from the template"))
+	}
+	return findings
+}
+
+// structuralTokens returns the indexes of the tokens that make up the
+// structure of the token list (the significant tokens and comments)
+func structuralTokens(tl Tokens) (idx []int) {
+	for i := 0; i < tl.length; i++ {
+		switch tl.tokens[i].tokenType {
+		case NullToken, WhiteSpaceToken:
+		default:
+			idx = append(idx, i)
+		}
+	}
+	return idx
+}
+
+// tokensMatch determines whether or not a query token matches the
+// template token. Bind parameters and literals in the template match
+// any single literal.
+func tokensMatch(t, q Token) bool {
+	if isInputPoint(t) {
+		return isInputPoint(q)
+	}
+	if t.tokenType != q.tokenType {
+		return false
+	}
+	return strings.EqualFold(t.tokenString, q.tokenString)
+}
+
+// isInputPoint determines whether or not the token is a point where
+// input is inserted, or a literal value
+func isInputPoint(t Token) bool {
+	switch t.tokenType {
+	case BindParameterToken, SingleQuotedToken, NumericToken:
+		return true
+	}
+	return false
+}
+
+// classifyInjection returns the findings for the injected tokens (the
+// indexes of the tokens in the query token list)
+func classifyInjection(qt *Tokens, injected []int) (findings []InjectionFinding) {
+
+	last := injected[len(injected)-1]
+	for k, i := range injected {
+		t := qt.tokens[i]
+		w := tokenWord(t)
+		switch {
+		case t.tokenType == OtherToken && t.tokenString == ";" && k < len(injected)-1:
+			findings = append(findings, newFinding(qt, StackedQueryInjection, i, last, "an additional statement follows the query"))
+		case isCommentToken(t.tokenType):
+			findings = append(findings, newFinding(qt, CommentInjection, i, i, "a comment is introduced"))
+		case w == "UNION":
+			findings = append(findings, newFinding(qt, UnionInjection, i, last, "a UNION is introduced"))
+		case w == "OR" && tautologyLength(qt, injected[k+1:]) > 0:
+			end := injected[k+tautologyLength(qt, injected[k+1:])]
+			findings = append(findings, newFinding(qt, TautologyInjection, i, end, "a condition that is always true is introduced"))
+		}
+	}
+	return findings
+}
+
+// tautologyLength returns the number of tokens, following an OR, that
+// form a condition that is always true (1=1, 'a'='a', x=x, 1, or TRUE).
+// Zero is returned if the tokens do not form such a condition.
+func tautologyLength(qt *Tokens, idx []int) int {
+
+	if len(idx) == 0 {
+		return 0
+	}
+	a := qt.tokens[idx[0]]
+
+	if len(idx) < 3 || qt.tokens[idx[1]].tokenString != "=" {
+		// a lone true value
+		switch {
+		case tokenWord(a) == "TRUE":
+			return 1
+		case a.tokenType == NumericToken && strings.Trim(a.tokenString, "0.") != "":
+			return 1
+		}
+		return 0
+	}
+
+	b := qt.tokens[idx[2]]
+	if a.tokenType == b.tokenType && a.tokenString == b.tokenString {
+		return 3
+	}
+	return 0
+}
+
+// newFinding returns the finding for the tokens of the query from index
+// i through index j
+func newFinding(qt *Tokens, kind, i, j int, msg string) InjectionFinding {
+
+	line, col := qt.Position(i)
+	s := qt.Slice(i, j+1)
+	if s.length > 0 {
+		s.tokens[0].leadingWhiteSpace = ""
+	}
+	s.trailingWhiteSpace = ""
+	return InjectionFinding{
+		Kind:   kind,
+		Index:  i,
+		Line:   line,
+		Col:    col,
+		Tokens: s.String(),
+		Msg:    msg,
+	}
+}
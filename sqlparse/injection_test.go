@@ -0,0 +1,128 @@
+package sqlparse
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestCheckInjection(t *testing.T) {
+
+	cases := []struct {
+		name     string
+		template string
+		query    string
+		expected string
+	}{
+		{
+			"clean string",
+			"SELECT * FROM users WHERE name = '' AND active = 1",
+			"SELECT * FROM users WHERE name = 'O''Brien' AND active = 1",
+			"",
+		},
+		{
+			"clean parameter",
+			"SELECT * FROM users WHERE id = ?",
+			"SELECT * FROM users WHERE id = 42",
+			"",
+		},
+		{
+			"tautology",
+			"SELECT * FROM users WHERE name = '' AND active = 1",
+			"SELECT * FROM users WHERE name = '' OR '1'='1' AND active = 1",
+			"TautologyInjection 1:37 [OR '1'='1']",
+		},
+		{
+			"numeric tautology",
+			"SELECT * FROM users WHERE id = 0",
+			"SELECT * FROM users WHERE id = 7 OR 1",
+			"TautologyInjection 1:34 [OR 1]",
+		},
+		{
+			"comment",
+			"SELECT * FROM users WHERE name = '' AND password = ''",
+			"SELECT * FROM users WHERE name = 'admin'--' AND password = ''",
+			"CommentInjection 1:41 [--' AND password = '']",
+		},
+		{
+			"stacked query",
+			"SELECT * FROM users WHERE id = 0",
+			"SELECT * FROM users WHERE id = 1; DROP TABLE users",
+			"StackedQueryInjection 1:33 [; DROP TABLE users]",
+		},
+		{
+			"union",
+			"SELECT name FROM products WHERE id = ?",
+			"SELECT name FROM products WHERE id = 1 UNION SELECT password FROM users",
+			"UnionInjection 1:40 [UNION SELECT password FROM users]",
+		},
+		{
+			"missing tokens",
+			"SELECT * FROM t WHERE a = ? AND b = ?",
+			"SELECT * FROM t WHERE a = 1",
+			"StructureInjection 1:27 [1]",
+		},
+		{
+			"structure",
+			"SELECT * FROM t ORDER BY name",
+			"SELECT * FROM t ORDER BY (SELECT 1)",
+			"StructureInjection 1:26 [(SELECT 1)]",
+		},
+	}
+
+	for _, c := range cases {
+		var got []string
+		for _, f := range CheckInjection(c.template, c.query, PostgreSQL) {
+			got = append(got, fmt.Sprintf("%s %d:%d [%s]", InjectionKindName(f.Kind), f.Line, f.Col, f.Tokens))
+		}
+		if s := strings.Join(got, ", "); s != c.expected {
+			t.Errorf("%s: expected %q, got %q", c.name, c.expected, s)
+		}
+	}
+}
+
+func TestCheckInjectionBackslashEscapes(t *testing.T) {
+
+	template := "SELECT * FROM users WHERE name = '' AND pw = ''"
+	cases := []struct {
+		name     string
+		dialect  int
+		query    string
+		expected string
+	}{
+		{
+			"clean escape",
+			MySQL,
+			`SELECT * FROM users WHERE name = 'O\'Brien' AND pw = 'x'`,
+			"",
+		},
+		{
+			"escaped quote",
+			MySQL,
+			`SELECT * FROM users WHERE name = '\'' OR 1=1 -- ' AND pw = 'x'`,
+			"TautologyInjection 1:39 [OR 1=1], CommentInjection 1:46 [-- ' AND pw = 'x']",
+		},
+		{
+			"escaped closing quote",
+			MySQL,
+			`SELECT * FROM users WHERE name = 'a\' AND pw = ' OR 1=1 -- '`,
+			"TautologyInjection 1:50 [OR 1=1], CommentInjection 1:57 [-- ']",
+		},
+		{
+			"E string",
+			PostgreSQL,
+			`SELECT * FROM users WHERE name = E'\'' OR 1=1 -- ' AND pw = 'x'`,
+			"TautologyInjection 1:40 [OR 1=1], CommentInjection 1:47 [-- ' AND pw = 'x']",
+		},
+	}
+
+	for _, c := range cases {
+		var got []string
+		for _, f := range CheckInjection(template, c.query, c.dialect) {
+			got = append(got, fmt.Sprintf("%s %d:%d [%s]", InjectionKindName(f.Kind), f.Line, f.Col, f.Tokens))
+		}
+		if s := strings.Join(got, ", "); s != c.expected {
+			t.Errorf("%s: expected %q, got %q", c.name, c.expected, s)
+		}
+	}
+}
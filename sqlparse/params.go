@@ -0,0 +1,268 @@
+package sqlparse
+
+/*
+
+params.go provides the functionality for finding and rewriting the bind
+parameter placeholders in a token list.
+
+*/
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Bind parameter styles
+const (
+	// NullParameterStyle indicates an undefined parameter style
+	NullParameterStyle = iota
+	// QuestionParameterStyle is ?, ?, ... (MySQL, SQLite, ODBC)
+	QuestionParameterStyle
+	// NumberedParameterStyle is ?1, ?2, ... (SQLite)
+	NumberedParameterStyle
+	// DollarParameterStyle is $1, $2, ... (PostgreSQL)
+	DollarParameterStyle
+	// ColonParameterStyle is :1, :2, ... (Oracle)
+	ColonParameterStyle
+	// AtParameterStyle is @p1, @p2, ... (MSSQL)
+	AtParameterStyle
+)
+
+// Parameter provides the mapping of a bind parameter placeholder to the
+// ordinal of the rewritten placeholder
+type Parameter struct {
+	Name    string // the original placeholder (?, :id, @p1, $2, ...)
+	Ordinal int    // the ordinal of the rewritten placeholder
+}
+
+// ParameterStyleName returns the string representation of the bind
+// parameter style
+func ParameterStyleName(style int) (s string) {
+
+	var names = map[int]string{
+		NullParameterStyle:     "NullParameterStyle",
+		QuestionParameterStyle: "QuestionParameterStyle",
+		NumberedParameterStyle: "NumberedParameterStyle",
+		DollarParameterStyle:   "DollarParameterStyle",
+		ColonParameterStyle:    "ColonParameterStyle",
+		AtParameterStyle:       "AtParameterStyle",
+	}
+
+	if s, ok := names[style]; ok {
+		return s
+	}
+	return ""
+}
+
+// RewriteParameters rewrites the bind parameter placeholders in the SQL
+// to the parameter style. Numbered placeholders ($1, :1, ?1, and the
+// MSSQL @p1) keep their numbers while the other placeholders are
+// numbered in the order of their first appearance, a named placeholder
+// that appears more than once keeps the same number. String literals,
+// comments, and PostgreSQL :: casts ($1::int) are left untouched. The
+// rewritten SQL is returned along with the original placeholder and
+// ordinal for each of the placeholders in the rewritten SQL. For the ?
+// style, where a named placeholder that appears more than once is bound
+// at each position, the ordinals give the parameter to bind at each
+// position.
+func RewriteParameters(sql string, dialect, style int) (s string, params []Parameter) {
+
+	tl := ParseStatements(sql, dialect)
+	e := NewEditor(tl)
+
+	found := tl.bindParameters(dialect)
+	ordinals, _ := rewriteParams(e, found, style, false)
+	for i, p := range found {
+		params = append(params, Parameter{
+			Name:    strings.TrimSuffix(tl.tokens[p.index].tokenString, p.cast),
+			Ordinal: ordinals[i],
+		})
+	}
+	return e.String(), params
+}
+
+// bindParameter provides a bind parameter placeholder in a token list
+type bindParameter struct {
+	index  int    // the index of the token
+	name   string // the name, or number, of the parameter, empty for ?
+	number int    // the number of a positional parameter ($2, :2, ?2, @p2), zero otherwise
+	cast   string // the PostgreSQL :: cast that is tokenized with the parameter ($1::int)
+}
+
+// dialectParamStyle returns the bind parameter style that is used by the
+// drivers for the dialect
+func dialectParamStyle(dialect int) int {
+	switch dialect {
+	case PostgreSQL:
+		return DollarParameterStyle
+	case Oracle:
+		return ColonParameterStyle
+	case MSSQL:
+		return AtParameterStyle
+	case SQLite:
+		return NumberedParameterStyle
+	}
+	return QuestionParameterStyle
+}
+
+// bindParameters returns the bind parameter placeholders in the token
+// list. MSSQL variables that are declared in the token list are not
+// parameters, nor are the Oracle :new and :old trigger references.
+func (d *Tokens) bindParameters(dialect int) (params []bindParameter) {
+
+	declared := make(map[string]bool)
+	prev := ""
+	for i := 0; i < d.length; i++ {
+		t := d.tokens[i]
+		if !SignificantTokens(t) {
+			continue
+		}
+		if name, ok := paramName(t, dialect); ok && prev == "DECLARE" {
+			declared[strings.ToUpper(name)] = true
+		}
+		prev = tokenWord(t)
+	}
+
+	for i := 0; i < d.length; i++ {
+		name, ok := paramName(d.tokens[i], dialect)
+		switch {
+		case !ok, declared[strings.ToUpper(name)]:
+			continue
+		case dialect == Oracle && isTriggerReference(name):
+			continue
+		}
+		params = append(params, bindParameter{
+			index:  i,
+			name:   name,
+			number: paramNumber(name, dialect),
+			cast:   paramCast(d.tokens[i]),
+		})
+	}
+	return params
+}
+
+// paramName returns the name (or number) of the bind parameter for
+// those tokens that are bind parameter placeholders. Any :: cast that is
+// tokenized with the parameter ($1::int) is not part of the name.
+func paramName(t Token, dialect int) (name string, ok bool) {
+
+	s := t.tokenString
+	switch t.tokenType {
+	case BindParameterToken:
+		if s == "?" {
+			return "", true
+		}
+		return s[1 : len(s)-len(paramCast(t))], true
+	case IdentToken, OtherToken:
+		switch {
+		case dialect != MSSQL && dialect != SQLite:
+			// @name is a user variable in MySQL and MariaDB
+		case len(s) > 1 && s[0] == '@' && s[1] != '@':
+			return s[1:], true
+		case dialect == SQLite && len(s) > 1 && s[0] == '?' && isNumber(s[1:]):
+			return s[1:], true
+		}
+	}
+	return "", false
+}
+
+// paramCast returns the PostgreSQL :: cast, if any, that is tokenized
+// with the bind parameter ($1::int)
+func paramCast(t Token) string {
+	if t.tokenType != BindParameterToken {
+		return ""
+	}
+	if i := strings.Index(t.tokenString, "::"); i > 0 {
+		return t.tokenString[i:]
+	}
+	return ""
+}
+
+// paramNumber returns the number of a positional bind parameter, given
+// the name of the parameter, or zero for the named parameters. The
+// MSSQL @p1, @p2, ... parameters are positional.
+func paramNumber(name string, dialect int) int {
+	if dialect == MSSQL && len(name) > 1 && (name[0] == 'p' || name[0] == 'P') && isNumber(name[1:]) {
+		name = name[1:]
+	}
+	if k, err := strconv.Atoi(name); err == nil && k > 0 {
+		return k
+	}
+	return 0
+}
+
+// isTriggerReference determines whether or not the Oracle bind
+// parameter name refers to the :new or :old row of a trigger
+func isTriggerReference(name string) bool {
+	s := strings.ToUpper(name)
+	if i := strings.Index(s, "."); i >= 0 {
+		s = s[:i]
+	}
+	return s == "NEW" || s == "OLD"
+}
+
+// rewriteParams replaces the bind parameter placeholders with those of
+// the parameter style. Numbered parameters retain their numbers, while
+// the other parameters are numbered in order of their first appearance.
+// Named parameters keep their names, for those styles that allow names,
+// when keepNames is set. The ordinal of each of the parameters is
+// returned along with the named parameters that are used more than once
+// as those require binding more than once when rewritten to the ? style.
+func rewriteParams(e *Editor, params []bindParameter, style int, keepNames bool) (ordinals []int, repeated []bindParameter) {
+
+	seen := make(map[string]int)
+	n := 0
+
+	for _, p := range params {
+		var ord int
+		key := strings.ToUpper(p.name)
+		switch {
+		case p.name == "":
+			n++
+			ord = n
+		case p.number > 0:
+			ord = p.number
+		case seen[key] > 0:
+			ord = seen[key]
+			repeated = append(repeated, p)
+		default:
+			n++
+			ord = n
+			seen[key] = ord
+		}
+		if ord > n {
+			n = ord
+		}
+		ordinals = append(ordinals, ord)
+
+		s := paramPlaceholder(p, ord, style, keepNames) + p.cast
+		if s != e.tokens.tokens[p.index].tokenString {
+			e.Replace(p.index, NewToken(s, BindParameterToken, ""))
+		}
+	}
+	return ordinals, repeated
+}
+
+// paramPlaceholder returns the placeholder for the bind parameter in
+// the parameter style
+func paramPlaceholder(p bindParameter, ord int, style int, keepNames bool) string {
+
+	named := keepNames && p.name != "" && p.number == 0
+	switch style {
+	case NumberedParameterStyle:
+		return "?" + strconv.Itoa(ord)
+	case DollarParameterStyle:
+		return "$" + strconv.Itoa(ord)
+	case ColonParameterStyle:
+		if named {
+			return ":" + p.name
+		}
+		return ":" + strconv.Itoa(ord)
+	case AtParameterStyle:
+		if named {
+			return "@" + p.name
+		}
+		return "@p" + strconv.Itoa(ord)
+	}
+	return "?"
+}
@@ -0,0 +1,76 @@
+package sqlparse
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestRewriteParameters(t *testing.T) {
+
+	cases := []struct {
+		dialect  int
+		style    int
+		input    string
+		expected string
+		params   string
+	}{
+		{
+			Oracle, DollarParameterStyle,
+			"SELECT * FROM t WHERE a = :id AND b = ':x' AND c = :name -- :y\n AND d = :id",
+			"SELECT * FROM t WHERE a = $1 AND b = ':x' AND c = $2 -- :y\n AND d = $1",
+			"[{:id 1} {:name 2} {:id 1}]",
+		},
+		{
+			PostgreSQL, QuestionParameterStyle,
+			"SELECT x::int, '$1' FROM t WHERE a = $2 AND b = $1 AND c = $2",
+			"SELECT x::int, '$1' FROM t WHERE a = ? AND b = ? AND c = ?",
+			"[{$2 2} {$1 1} {$2 2}]",
+		},
+		{
+			MySQL, AtParameterStyle,
+			"SELECT @total FROM t WHERE a = ? AND b = ?",
+			"SELECT @total FROM t WHERE a = @p1 AND b = @p2",
+			"[{? 1} {? 2}]",
+		},
+		{
+			MSSQL, ColonParameterStyle,
+			"DECLARE @n int = 1;\nSELECT @@ROWCOUNT FROM t WHERE a = @p1 AND b = @name AND c = @n",
+			"DECLARE @n int = 1;\nSELECT @@ROWCOUNT FROM t WHERE a = :1 AND b = :2 AND c = @n",
+			"[{@p1 1} {@name 2}]",
+		},
+		{
+			SQLite, NumberedParameterStyle,
+			"SELECT * FROM t WHERE a = ? AND b = :b AND c = ?",
+			"SELECT * FROM t WHERE a = ?1 AND b = ?2 AND c = ?3",
+			"[{? 1} {:b 2} {? 3}]",
+		},
+		{
+			PostgreSQL, DollarParameterStyle,
+			"SELECT * FROM t WHERE a = $2::text AND b = $1",
+			"SELECT * FROM t WHERE a = $2::text AND b = $1",
+			"[{$2 2} {$1 1}]",
+		},
+		{
+			PostgreSQL, QuestionParameterStyle,
+			"SELECT * FROM t WHERE a = $1::int AND b = $2::text[]",
+			"SELECT * FROM t WHERE a = ?::int AND b = ?::text[]",
+			"[{$1 1} {$2 2}]",
+		},
+		{
+			MSSQL, DollarParameterStyle,
+			"SELECT * FROM t WHERE a = @p2 AND b = @p1 AND c = @name",
+			"SELECT * FROM t WHERE a = $2 AND b = $1 AND c = $3",
+			"[{@p2 2} {@p1 1} {@name 3}]",
+		},
+	}
+
+	for _, c := range cases {
+		got, params := RewriteParameters(c.input, c.dialect, c.style)
+		if got != c.expected {
+			t.Errorf("%s to %s:\n  expected %s\n  got      %s", SQLDialectName(c.dialect), ParameterStyleName(c.style), c.expected, got)
+		}
+		if s := fmt.Sprintf("%v", params); s != c.params {
+			t.Errorf("%s to %s: expected %s, got %s", SQLDialectName(c.dialect), ParameterStyleName(c.style), c.params, s)
+		}
+	}
+}
@@ -107,9 +107,14 @@ func parsePassTwo(tlIn Tokens, dialect int) (tlOut Tokens) {
 
 		tokenType := t.Type()
 		switch tokenType {
-		case NullToken, WhiteSpaceToken:
+		case NullToken:
 			// do nothing
 			continue
+		case WhiteSpaceToken:
+			// only white space at the very end of the string remains as
+			// a token, keep it so that the string can be reconstructed
+			tlOut.trailingWhiteSpace = s
+			continue
 		case BacktickQuotedToken, BlockCommentToken, BracketQuotedToken, DoubleQuotedToken, LineCommentToken, SingleQuotedToken:
 			tlOut.Push(t)
 			continue
@@ -189,13 +194,10 @@ func parsePassThree(tlIn Tokens, dialect int) (tlOut Tokens) {
 						tlOut.Extend(OtherToken)
 					}
 
-					// leading white space
-					if ws != "" {
-						tlOut.SetWhiteSpace(ws)
-						ws = ""
-					} else {
-						tlOut.SetWhiteSpace(" ")
-					}
+					// leading white space (only the first of the split
+					// tokens has any)
+					tlOut.SetWhiteSpace(ws)
+					ws = ""
 
 					tlOut.Concat(s2)
 					tlOut.CloseToken()
@@ -211,6 +213,8 @@ func parsePassThree(tlIn Tokens, dialect int) (tlOut Tokens) {
 		}
 	}
 
+	tlOut.trailingWhiteSpace = tlIn.trailingWhiteSpace
+	tlOut.tagFunctions(dialect)
 	return tlOut
 }
 
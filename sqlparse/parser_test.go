@@ -45,27 +45,14 @@ func TestSQLFiles(t *testing.T) {
 
 		input := string(inBytes)
 		expected := string(expBytes)
-		var dialect int
 
-		// Extract the parsing args from the first line of the input
-		// and determine which dialect to use
-		l1 := strings.SplitN(input, "\n", 2)[0]
-		args := strings.Split(strings.Replace(l1, "-", "", 2), ",")
-
-		for i := 0; i < len(args); i++ {
-			kv := strings.SplitN(args[i], ":", 2)
-			if len(kv) > 1 {
-				key := strings.Trim(kv[0], " ")
-				value := strings.Trim(kv[1], " ")
-
-				if key == "dialect" {
-					dialect = SQLDialect(value)
-				}
-			}
+		// the dialect to use is specified by the directive on the first
+		// line of the input
+		tl, dir := ParseAuto(input)
+		if dir.Detected {
+			t.Errorf("%s has no dialect directive", inputFile)
 		}
 
-		tl := ParseStatements(input, dialect)
-
 		tl.Rewind()
 		var resultTokens []string
 
@@ -0,0 +1,243 @@
+package sqlparse
+
+/*
+
+portability.go provides the functionality for finding the identifiers
+in a token list that are reserved keywords in other SQL dialects.
+
+*/
+
+import (
+	"fmt"
+	"strings"
+)
+
+// PortabilityIssue provides an identifier that is a reserved keyword
+// in one or more of the target dialects
+type PortabilityIssue struct {
+	Index    int      // the index of the identifier in the token list
+	Line     int      // the line number of the identifier
+	Col      int      // the column number of the identifier
+	Name     string   // the identifier, as written
+	Dialects []int    // the target dialects that the identifier is reserved in
+	Quoted   []string // the quoted identifier for each of the dialects
+	Msg      string   // the description of the issue
+}
+
+// map[previous word]bool of the words (and punctuation) that may
+// precede an unquoted identifier
+var identifierPrecedingWords = map[string]bool{
+	"(":        true,
+	",":        true,
+	".":        true,
+	"AND":      true,
+	"ADD":      true,
+	"AS":       true,
+	"BY":       true,
+	"COLUMN":   true,
+	"DISTINCT": true,
+	"FROM":     true,
+	"INTO":     true,
+	"JOIN":     true,
+	"ON":       true,
+	"OR":       true,
+	"SELECT":   true,
+	"SET":      true,
+	"TABLE":    true,
+	"TO":       true,
+	"UPDATE":   true,
+	"WHERE":    true,
+}
+
+// map[next word]bool of the words (and punctuation) that may follow an
+// unquoted identifier
+var identifierFollowingWords = map[string]bool{
+	"":      true,
+	")":     true,
+	",":     true,
+	".":     true,
+	";":     true,
+	"AS":    true,
+	"FROM":  true,
+	"TO":    true,
+	"WHERE": true,
+}
+
+// Error implements the error interface for the portability issue
+func (e PortabilityIssue) Error() string {
+	return fmt.Sprintf("line %d, column %d: %s", e.Line, e.Col, e.Msg)
+}
+
+// CheckReservedIdentifiers returns the identifiers in the token list,
+// of the specified SQL dialect, that are reserved keywords in any of the
+// target dialects. Each issue includes the identifier quoted for each of
+// the dialects that it is reserved in (with the case folded to that of
+// unquoted identifiers for the dialect so that the quoted name refers to
+// the same object). Quoted identifiers are only portable to the target
+// dialects that accept the quoting (back-ticks are not accepted by
+// PostgreSQL or Oracle, for example), and are reported for the other
+// target dialects.
+//
+// Identifiers are recognized by their position, as keywords of the
+// specified dialect that are used as identifiers can only be told apart
+// from their use as keywords by the tokens around them.
+func CheckReservedIdentifiers(tl Tokens, dialect int, targets []int) (issues []PortabilityIssue) {
+
+	for i := 0; i < tl.length; i++ {
+		if !tl.isIdentifier(i, dialect) && !isQuotedIdentifierToken(tl.tokens[i], dialect) {
+			continue
+		}
+
+		// qualified names (schema.table) are tokenized as a single token
+		offset := 0
+		for _, name := range splitQualifiedName(tl.tokens[i].tokenString) {
+			if issue, ok := reservedIdentifier(name, targets); ok {
+				issue.Index = i
+				issue.Line, issue.Col = tl.Position(i)
+				issue.Col += offset
+				issues = append(issues, issue)
+			}
+			offset += len(name) + 1
+		}
+	}
+	return issues
+}
+
+// reservedIdentifier returns the portability issue for an identifier
+// (as written, quoted or not) that is a reserved keyword in any of the
+// target dialects that do not accept the quoting of the identifier
+func reservedIdentifier(name string, targets []int) (issue PortabilityIssue, ok bool) {
+
+	unquoted := name
+	if isQuotedIdentifier(name) {
+		unquoted = unquoteIdentifier(name)
+	}
+
+	var reserved []int
+	var quoted, labels []string
+	for _, target := range targets {
+		if !IsReservedKeyword(unquoted, target) || acceptsQuoting(name[0], target) {
+			continue
+		}
+		q, _ := quoteIdentifier(foldIdentifier(unquoted, target), target)
+		reserved = append(reserved, target)
+		quoted = append(quoted, q)
+		labels = append(labels, fmt.Sprintf("%s (%s)", q, SQLDialectName(target)))
+	}
+	if len(reserved) == 0 {
+		return issue, false
+	}
+
+	var names []string
+	for _, target := range reserved {
+		names = append(names, SQLDialectName(target))
+	}
+	return PortabilityIssue{
+		Name:     name,
+		Dialects: reserved,
+		Quoted:   quoted,
+		Msg:      fmt.Sprintf("%s is a reserved keyword in %s, quote it as %s", name, strings.Join(names, ", "), strings.Join(labels, ", ")),
+	}, true
+}
+
+// acceptsQuoting determines whether or not the dialect accepts
+// identifiers that are quoted with the quote character. No quoting is
+// accepted for the other characters.
+func acceptsQuoting(q byte, dialect int) bool {
+	switch q {
+	case '"':
+		return !isMySQLFamily(dialect)
+	case '`':
+		return isMySQLFamily(dialect) || dialect == SQLite
+	case '[':
+		return dialect == MSSQL || dialect == SQLite
+	}
+	return false
+}
+
+// isQuotedIdentifierToken determines whether or not the token is a
+// quoted identifier in the dialect (double quoted strings are string
+// literals in MySQL and MariaDB)
+func isQuotedIdentifierToken(t Token, dialect int) bool {
+	switch t.tokenType {
+	case BacktickQuotedToken, BracketQuotedToken:
+		return true
+	case DoubleQuotedToken:
+		return !isMySQLFamily(dialect)
+	}
+	return false
+}
+
+// isQuotedIdentifier determines whether or not the (part of a qualified)
+// name is quoted
+func isQuotedIdentifier(name string) bool {
+	return name != "" && (name[0] == '"' || name[0] == '`' || name[0] == '[')
+}
+
+// isIdentifier determines whether or not the token at index i is an
+// unquoted identifier. Identifiers that are keywords in the dialect are
+// recognized by the preceding and following tokens, as are data types
+// (which are not identifiers).
+func (d *Tokens) isIdentifier(i int, dialect int) bool {
+
+	t := d.tokens[i]
+	if t.tokenType != IdentToken && t.tokenType != KeywordToken {
+		return false
+	}
+
+	prev, next := "", ""
+	isType := false
+	for j := i - 1; j >= 0; j-- {
+		if SignificantTokens(d.tokens[j]) {
+			prev = identifierContext(d.tokens[j])
+			break
+		}
+	}
+	for j := i + 1; j < d.length; j++ {
+		if SignificantTokens(d.tokens[j]) {
+			next = identifierContext(d.tokens[j])
+			_, isType = DataTypeInfo(d.tokens[j].tokenString, dialect)
+			break
+		}
+	}
+
+	switch {
+	case prev == "." || next == ".":
+		// a part of a qualified name
+		return true
+	case prev == "(" && next == "FROM":
+		// the field of EXTRACT(YEAR FROM d)
+		return false
+	}
+
+	if _, ok := DataTypeInfo(t.tokenString, dialect); ok && (prev == "AS" || prev == "::" || !identifierPrecedingWords[prev]) {
+		// the data type of a column or a cast
+		return false
+	}
+	if t.tokenType == IdentToken {
+		return true
+	}
+	if IsReservedKeyword(t.tokenString, dialect) || !identifierPrecedingWords[prev] {
+		return false
+	}
+
+	switch {
+	case identifierFollowingWords[next], IsOperator(next, dialect):
+		return true
+	case prev == "(" || prev == "," || prev == "ADD" || prev == "COLUMN":
+		// a column definition, name data_type
+		return isType
+	case prev == "TABLE" || prev == "INTO":
+		return next == "("
+	}
+	return false
+}
+
+// identifierContext returns the upper-case word, or the punctuation,
+// of a token that precedes or follows an identifier
+func identifierContext(t Token) string {
+	if w := tokenWord(t); w != "" {
+		return w
+	}
+	return t.tokenString
+}
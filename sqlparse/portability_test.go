@@ -0,0 +1,82 @@
+package sqlparse
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCheckReservedIdentifiers(t *testing.T) {
+
+	cases := []struct {
+		dialect  int
+		targets  []int
+		input    string
+		expected string
+	}{
+		{
+			PostgreSQL, []int{PostgreSQL, MySQL, Oracle},
+			`CREATE TABLE app.events (
+    id integer PRIMARY KEY,
+    level integer NOT NULL,
+    comment text,
+    status varchar(20) DEFAULT 'new'
+);`,
+			"line 3, column 5: level is a reserved keyword in Oracle, quote it as \"LEVEL\" (Oracle); " +
+				"line 4, column 5: comment is a reserved keyword in Oracle, quote it as \"COMMENT\" (Oracle)",
+		},
+		{
+			PostgreSQL, []int{PostgreSQL, MySQL, Oracle},
+			"SELECT e.level, CAST(id AS text), EXTRACT(YEAR FROM d) FROM events e WHERE level > 1 AND \"comment\" = 'x'",
+			"line 1, column 10: level is a reserved keyword in Oracle, quote it as \"LEVEL\" (Oracle); " +
+				"line 1, column 76: level is a reserved keyword in Oracle, quote it as \"LEVEL\" (Oracle)",
+		},
+		{
+			SQLite, []int{PostgreSQL, MySQL, Oracle, MSSQL},
+			"INSERT INTO user (id, status) VALUES (1, 'a')",
+			"line 1, column 13: user is a reserved keyword in PostgreSQL, Oracle, quote it as \"user\" (PostgreSQL), \"USER\" (Oracle)",
+		},
+		{
+			PostgreSQL, []int{Oracle},
+			"ALTER TABLE t ADD COLUMN level int;\nALTER TABLE t ADD level int;\nALTER TABLE t RENAME COLUMN a TO level;",
+			"line 1, column 26: level is a reserved keyword in Oracle, quote it as \"LEVEL\" (Oracle); " +
+				"line 2, column 19: level is a reserved keyword in Oracle, quote it as \"LEVEL\" (Oracle); " +
+				"line 3, column 34: level is a reserved keyword in Oracle, quote it as \"LEVEL\" (Oracle)",
+		},
+		{
+			MySQL, []int{MySQL, PostgreSQL, Oracle},
+			"CREATE TABLE `order` (`id` int, `group` int, `t`.`select` int)",
+			"line 1, column 14: `order` is a reserved keyword in PostgreSQL, Oracle, quote it as \"order\" (PostgreSQL), \"ORDER\" (Oracle); " +
+				"line 1, column 33: `group` is a reserved keyword in PostgreSQL, Oracle, quote it as \"group\" (PostgreSQL), \"GROUP\" (Oracle); " +
+				"line 1, column 50: `select` is a reserved keyword in PostgreSQL, Oracle, quote it as \"select\" (PostgreSQL), \"SELECT\" (Oracle)",
+		},
+		{
+			PostgreSQL, []int{PostgreSQL, Oracle, MSSQL},
+			"SELECT \"order\" FROM t",
+			"",
+		},
+		{
+			PostgreSQL, []int{MySQL},
+			"UPDATE t SET a = 1 WHERE b = 2",
+			"",
+		},
+	}
+
+	for _, c := range cases {
+		var got []string
+		for _, issue := range CheckReservedIdentifiers(ParseStatements(c.input, c.dialect), c.dialect, c.targets) {
+			got = append(got, issue.Error())
+		}
+		if strings.Join(got, "; ") != c.expected {
+			t.Errorf("%s: expected %q, got %q", c.input, c.expected, strings.Join(got, "; "))
+		}
+	}
+
+	issues := CheckReservedIdentifiers(ParseStatements("SELECT t.order FROM t", MySQL), MySQL, []int{MySQL, Oracle, PostgreSQL})
+	if len(issues) != 1 {
+		t.Fatalf("expected 1 issue, got %d", len(issues))
+	}
+	issue := issues[0]
+	if issue.Name != "order" || issue.Col != 10 || strings.Join(issue.Quoted, " ") != "`order` \"ORDER\" \"order\"" {
+		t.Errorf("unexpected issue %+v", issue)
+	}
+}
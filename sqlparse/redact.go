@@ -0,0 +1,290 @@
+package sqlparse
+
+/*
+
+redact.go provides the functionality for redacting the literal values
+in a token list so that the SQL may be logged without exposing the
+values.
+
+*/
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// RedactOptions provides the options for redacting literals
+type RedactOptions struct {
+	Dialect    int    // the SQL dialect of the token list, for the quoting and escaping rules of string literals
+	StringMask string // the replacement for string (and date, hex, ...) literals, ? if not specified
+	NumberMask string // the replacement for numeric literals, ? if not specified
+	KeepLength bool   // replace string literals with a string of * of the same length
+	Hash       bool   // replace literals with a string containing a hash of the value
+	HashKey    string // the key for hashing (HMAC-SHA256), recommended as the values may be guessed otherwise
+}
+
+// the opening tag of a dollar quoted string ($$ or $tag$)
+var reDollarTag = regexp.MustCompile(`^\$([A-Za-z_][A-Za-z_0-9]*)?\$`)
+
+// map[opening delimiter]closing delimiter of Oracle q'[...]' strings
+var qQuoteClosing = map[byte]byte{
+	'[': ']',
+	'{': '}',
+	'(': ')',
+	'<': '>',
+}
+
+// Redact returns a copy of the token list with the string, numeric,
+// hex, and date literals replaced by masks. Prefixed strings (N'abc',
+// X'0F', ...) are replaced along with the prefix, while the keyword of
+// date literals (DATE '2020-01-31') is kept. Identifiers, keywords, bind
+// parameters, and comments are left untouched.
+//
+// The extent of each string literal is determined from the text per
+// the rules of the dialect (see RedactOptions), as the tokenizer does
+// not recognize all of the forms of string literals: backslash escapes
+// for MySQL and MariaDB (and PostgreSQL E'...' strings), Oracle q'[...]'
+// strings, and PostgreSQL dollar quoted strings ($$...$$, which
+// includes function bodies). Double quoted strings are considered to be
+// string literals for MySQL and MariaDB, and identifiers otherwise.
+// Redaction fails closed: any token that the literal extends into is
+// masked, and the text that follows the literal is tokenized anew.
+func Redact(tl Tokens, opts RedactOptions) (tlOut Tokens) {
+
+	lt, values := literalTokens(tl, opts.Dialect)
+	for i := 0; i < lt.length; i++ {
+		t := lt.tokens[i]
+
+		if value, ok := values[i]; ok {
+			s := redactedString(value, opts)
+			tlOut.tokens = append(tlOut.tokens, NewToken(s, maskTokenType(s), t.leadingWhiteSpace))
+			continue
+		}
+
+		if t.tokenType == NumericToken || isHexLiteral(t) {
+			s := opts.NumberMask
+			switch {
+			case opts.Hash:
+				s = hashValue(t.tokenString, opts)
+			case s == "":
+				s = "?"
+			}
+			tlOut.tokens = append(tlOut.tokens, NewToken(s, maskTokenType(s), t.leadingWhiteSpace))
+			continue
+		}
+		tlOut.tokens = append(tlOut.tokens, t)
+	}
+
+	tlOut.length = len(tlOut.tokens)
+	tlOut.trailingWhiteSpace = lt.trailingWhiteSpace
+	tlOut.delimited = lt.delimited
+	return tlOut
+}
+
+// literalTokens returns a copy of the token list with each string
+// literal, as determined from the text per the rules of the dialect (see
+// Redact), as a single SingleQuotedToken that includes any prefix,
+// along with map[token index]the (unescaped) value of the literal. Any
+// token that a literal extends into is made part of the literal, and
+// the text that follows the literal is tokenized anew.
+func literalTokens(tl Tokens, dialect int) (tlOut Tokens, values map[int]string) {
+
+	values = make(map[int]string)
+	text := tl.String()
+	tokens := tl.tokens[:tl.length]
+	trailing := tl.trailingWhiteSpace
+	base := 0
+
+	// the offset of each token in the text
+	var offsets []int
+	setOffsets := func() {
+		offsets = make([]int, len(tokens))
+		offset := base
+		for i, t := range tokens {
+			offset += len(t.leadingWhiteSpace)
+			offsets[i] = offset
+			offset += len(t.tokenString)
+		}
+	}
+	setOffsets()
+
+	for i := 0; i < len(tokens); i++ {
+		t := tokens[i]
+
+		first, quote := i, i
+		if t.tokenType == IdentToken && i+1 < len(tokens) && tokens[i+1].leadingWhiteSpace == "" && tokens[i+1].tokenType == SingleQuotedToken {
+			// the prefix, N'abc', X'0F', _utf8'abc', q'[abc]', ...
+			quote = i + 1
+		}
+
+		end, value, ok := stringLiteral(text, offsets[quote], tokens[first], quote > first, dialect)
+		if !ok {
+			tlOut.tokens = append(tlOut.tokens, t)
+			continue
+		}
+
+		values[len(tlOut.tokens)] = value
+		tlOut.tokens = append(tlOut.tokens, NewToken(text[offsets[first]:end], SingleQuotedToken, t.leadingWhiteSpace))
+
+		// the last token that the literal extends into
+		j := quote
+		for j+1 < len(tokens) && offsets[j+1] < end {
+			j++
+		}
+
+		if offsets[j]+len(tokens[j].tokenString) > end {
+			// the literal ends within the token, tokenize the rest
+			rest := ParseStatements(text[end:], dialect)
+			tokens = rest.tokens[:rest.length]
+			trailing = rest.trailingWhiteSpace
+			base = end
+			setOffsets()
+			i = -1
+			continue
+		}
+		i = j
+	}
+
+	tlOut.length = len(tlOut.tokens)
+	tlOut.trailingWhiteSpace = trailing
+	tlOut.delimited = tl.delimited
+	return tlOut, values
+}
+
+// stringLiteral determines whether or not a string literal starts at
+// the offset in the text and, if so, returns the offset following the
+// end of the literal along with the (unescaped) value of the literal.
+// The token is the first token of the literal, which is the prefix of
+// the literal when hasPrefix is set.
+func stringLiteral(text string, start int, t Token, hasPrefix bool, dialect int) (end int, value string, ok bool) {
+
+	prefix := ""
+	if hasPrefix {
+		prefix = strings.ToUpper(t.tokenString)
+	}
+
+	switch {
+	case hasPrefix && dialect == Oracle && (prefix == "Q" || prefix == "NQ"):
+		return qQuotedLiteral(text, start)
+	case hasPrefix:
+		return quotedLiteral(text, start, isMySQLFamily(dialect) || (dialect == PostgreSQL && prefix == "E"))
+	case t.tokenType == SingleQuotedToken:
+		return quotedLiteral(text, start, isMySQLFamily(dialect))
+	case t.tokenType == DoubleQuotedToken && isMySQLFamily(dialect):
+		return quotedLiteral(text, start, true)
+	case dialect == PostgreSQL && t.tokenType != BindParameterToken:
+		if tag := reDollarTag.FindString(text[start:]); tag != "" {
+			close := strings.Index(text[start+len(tag):], tag)
+			if close < 0 {
+				return len(text), text[start+len(tag):], true
+			}
+			close += start + len(tag)
+			return close + len(tag), text[start+len(tag) : close], true
+		}
+	}
+	return 0, "", false
+}
+
+// quotedLiteral returns the end and value of the quoted string that
+// starts at the offset in the text. Quotes are escaped by doubling them
+// and, if backslash is set, by a preceding backslash. An unterminated
+// string extends to the end of the text.
+func quotedLiteral(text string, start int, backslash bool) (end int, value string, ok bool) {
+
+	q := text[start]
+	var b strings.Builder
+	for i := start + 1; i < len(text); i++ {
+		c := text[i]
+		switch {
+		case backslash && c == '\\' && i+1 < len(text):
+			i++
+			b.WriteByte(text[i])
+		case c == q && i+1 < len(text) && text[i+1] == q:
+			i++
+			b.WriteByte(q)
+		case c == q:
+			return i + 1, b.String(), true
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return len(text), b.String(), true
+}
+
+// qQuotedLiteral returns the end and value of the Oracle q'[...]' string
+// that starts at the offset in the text (at the quote that follows the
+// q prefix)
+func qQuotedLiteral(text string, start int) (end int, value string, ok bool) {
+
+	if start+1 >= len(text) {
+		return len(text), "", true
+	}
+	open := text[start+1]
+	close, ok := qQuoteClosing[open]
+	if !ok {
+		close = open
+	}
+
+	i := strings.Index(text[start+2:], string(close)+"'")
+	if i < 0 {
+		return len(text), text[start+2:], true
+	}
+	i += start + 2
+	return i + 2, text[start+2 : i], true
+}
+
+// redactedString returns the replacement for a string literal
+func redactedString(value string, opts RedactOptions) string {
+	switch {
+	case opts.Hash:
+		return hashValue(value, opts)
+	case opts.KeepLength:
+		return "'" + strings.Repeat("*", utf8.RuneCountInString(value)) + "'"
+	case opts.StringMask != "":
+		return opts.StringMask
+	}
+	return "?"
+}
+
+// hashValue returns a string literal containing the hash of the value
+func hashValue(value string, opts RedactOptions) string {
+
+	var sum []byte
+	if opts.HashKey != "" {
+		mac := hmac.New(sha256.New, []byte(opts.HashKey))
+		mac.Write([]byte(value))
+		sum = mac.Sum(nil)
+	} else {
+		h := sha256.Sum256([]byte(value))
+		sum = h[:]
+	}
+	return "'#" + hex.EncodeToString(sum[:8]) + "'"
+}
+
+// maskTokenType returns the type of token for the mask
+func maskTokenType(s string) int {
+	switch {
+	case s == "?":
+		return BindParameterToken
+	case strings.HasPrefix(s, "'"):
+		return SingleQuotedToken
+	case isNumericString(s):
+		return NumericToken
+	}
+	return OtherToken
+}
+
+// isHexLiteral determines whether or not the token is a hexadecimal
+// literal of the form 0x0F
+func isHexLiteral(t Token) bool {
+	s := t.tokenString
+	if len(s) < 3 || s[0] != '0' || (s[1] != 'x' && s[1] != 'X') {
+		return false
+	}
+	_, err := hex.DecodeString(strings.Repeat("0", len(s)%2) + s[2:])
+	return err == nil
+}
@@ -0,0 +1,120 @@
+package sqlparse
+
+import (
+	"testing"
+)
+
+func TestRedact(t *testing.T) {
+
+	input := "SELECT * FROM users WHERE name = 'O''Brien' AND id IN (1, -2.5) AND born > DATE '1970-01-01'\n" +
+		"  AND flags = 0x1F AND nick = N'héllo' AND id = ? -- '555-1234'"
+
+	cases := []struct {
+		name     string
+		opts     RedactOptions
+		expected string
+	}{
+		{
+			"default",
+			RedactOptions{},
+			"SELECT * FROM users WHERE name = ? AND id IN (?, ?) AND born > DATE ?\n" +
+				"  AND flags = ? AND nick = ? AND id = ? -- '555-1234'",
+		},
+		{
+			"masks",
+			RedactOptions{StringMask: "'***'", NumberMask: "0"},
+			"SELECT * FROM users WHERE name = '***' AND id IN (0, 0) AND born > DATE '***'\n" +
+				"  AND flags = 0 AND nick = '***' AND id = ? -- '555-1234'",
+		},
+		{
+			"keep length",
+			RedactOptions{KeepLength: true},
+			"SELECT * FROM users WHERE name = '*******' AND id IN (?, ?) AND born > DATE '**********'\n" +
+				"  AND flags = ? AND nick = '*****' AND id = ? -- '555-1234'",
+		},
+	}
+
+	for _, c := range cases {
+		tl := Redact(ParseStatements(input, PostgreSQL), c.opts)
+		if got := tl.String(); got != c.expected {
+			t.Errorf("%s:\n  expected %s\n  got      %s", c.name, c.expected, got)
+		}
+	}
+}
+
+func TestRedactHash(t *testing.T) {
+
+	opts := RedactOptions{Hash: true, HashKey: "secret"}
+	a := Redact(ParseStatements("SELECT 'alice', 'alice', 'bob'", PostgreSQL), opts)
+	l := a.Filter(SignificantTokens)
+
+	// 0      1       2 3       4 5
+	// SELECT 'alice' , 'alice' , 'bob'
+	v1, v2, v3 := l.At(1), l.At(3), l.At(5)
+	if v1.Value() != v2.Value() || v1.Value() == v3.Value() || v1.Value() == "'alice'" {
+		t.Errorf("unexpected hashes: %s", a.String())
+	}
+
+	b := Redact(ParseStatements("SELECT 'alice'", PostgreSQL), RedactOptions{Hash: true, HashKey: "other"})
+	l = b.Filter(SignificantTokens)
+	if v := l.At(1); v.Value() == v1.Value() {
+		t.Errorf("expected the hash to depend on the key")
+	}
+}
+
+func TestRedactDialects(t *testing.T) {
+
+	cases := []struct {
+		dialect  int
+		input    string
+		expected string
+	}{
+		{
+			MySQL,
+			`SELECT * FROM t WHERE ssn = "123-45-6789" AND note = 'it\'s secret' AND id = 1`,
+			`SELECT * FROM t WHERE ssn = ? AND note = ? AND id = ?`,
+		},
+		{
+			MariaDB,
+			`SELECT 'a\\', "b\"c" FROM t`,
+			`SELECT ?, ? FROM t`,
+		},
+		{
+			PostgreSQL,
+			`SELECT "col" FROM t WHERE a = E'it\'s secret' AND b = 'x\'`,
+			`SELECT "col" FROM t WHERE a = ? AND b = ?`,
+		},
+		{
+			PostgreSQL,
+			"SELECT $$my secret$$, $tag$x 'y' $$ z$tag$, $1 FROM t",
+			"SELECT ?, ?, $1 FROM t",
+		},
+		{
+			Oracle,
+			"SELECT q'[it's secret]', Q'{a 'b' c}', nq'!d!' FROM dual WHERE x = :x",
+			"SELECT ?, ?, ? FROM dual WHERE x = :x",
+		},
+		{
+			MySQL,
+			"SELECT 'unterminated \\' FROM t",
+			"SELECT ?",
+		},
+	}
+
+	for _, c := range cases {
+		tl := Redact(ParseStatements(c.input, c.dialect), RedactOptions{Dialect: c.dialect})
+		if got := tl.String(); got != c.expected {
+			t.Errorf("%s:\n  expected %s\n  got      %s", SQLDialectName(c.dialect), c.expected, got)
+		}
+	}
+
+	// the values are unescaped
+	tl := Redact(ParseStatements(`SELECT 'it\'s' FROM t`, MySQL), RedactOptions{Dialect: MySQL, KeepLength: true})
+	if got := tl.String(); got != `SELECT '****' FROM t` {
+		t.Errorf("keep length: got %s", got)
+	}
+	tl = Redact(ParseStatements(`SELECT q'[a'b]' FROM dual`, Oracle), RedactOptions{Dialect: Oracle, KeepLength: true})
+	if got := tl.String(); got != `SELECT '***' FROM dual` {
+		t.Errorf("keep length: got %s", got)
+	}
+}
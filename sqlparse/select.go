@@ -0,0 +1,944 @@
+package sqlparse
+
+/*
+
+select.go provides the recursive-descent parser for SELECT statements.
+
+*/
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ParseError provides the diagnostic for a statement that could not be
+// parsed
+type ParseError struct {
+	Index int    // the index of the token where the error was detected
+	Line  int    // the line number of the token
+	Col   int    // the column number of the token
+	Msg   string // the description of the error
+}
+
+// Error implements the error interface for the parse error
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("line %d, column %d: %s", e.Line, e.Col, e.Msg)
+}
+
+// parser tracks the state for parsing a token list. Only the
+// significant tokens are parsed, the positions of the nodes refer to
+// the indexes of the tokens in the original token list.
+type parser struct {
+	tl      *Tokens
+	sig     []int // the indexes of the significant tokens
+	k       int   // the index (into sig) of the current token
+	dialect int   // the SQL dialect
+}
+
+// map[keyword]bool of the words that may follow a select item, or table
+// reference, and that are therefore not bare aliases
+var clauseWords = map[string]bool{
+	"APPLY":         true,
+	"AS":            true,
+	"ASC":           true,
+	"CONNECT":       true,
+	"CROSS":         true,
+	"DESC":          true,
+	"EXCEPT":        true,
+	"FETCH":         true,
+	"FOR":           true,
+	"FROM":          true,
+	"FULL":          true,
+	"GROUP":         true,
+	"HAVING":        true,
+	"INNER":         true,
+	"INTERSECT":     true,
+	"INTO":          true,
+	"JOIN":          true,
+	"LEFT":          true,
+	"LIMIT":         true,
+	"MINUS":         true,
+	"NATURAL":       true,
+	"NULLS":         true,
+	"OFFSET":        true,
+	"ON":            true,
+	"ONLY":          true,
+	"ORDER":         true,
+	"OUTER":         true,
+	"PERCENT":       true,
+	"QUALIFY":       true,
+	"RIGHT":         true,
+	"ROW":           true,
+	"ROWS":          true,
+	"START":         true,
+	"STRAIGHT_JOIN": true,
+	"UNION":         true,
+	"USING":         true,
+	"WHERE":         true,
+	"WINDOW":        true,
+	"WITH":          true,
+}
+
+// map[operator]precedence for the set operations, INTERSECT binds more
+// tightly than UNION and EXCEPT
+var setOperators = map[string]int{
+	"EXCEPT":    1,
+	"INTERSECT": 2,
+	"MINUS":     1,
+	"UNION":     1,
+}
+
+// ParseSelect parses the SELECT statement in the token list. The
+// statement may be preceded by comments and followed by a semicolon.
+func ParseSelect(tl Tokens, dialect int) (stmt *SelectStatement, err error) {
+
+	p := newParser(&tl, dialect)
+
+	stmt, err = p.parseQuery()
+	if err != nil {
+		return nil, err
+	}
+
+	p.accept(";")
+	if p.k < len(p.sig) {
+		return nil, p.errorf("unexpected %s", p.found())
+	}
+	return stmt, nil
+}
+
+// EmbeddedQueries parses the queries that are embedded in a statement
+// that is not itself a query: the subqueries of INSERT, UPDATE, DELETE,
+// ... statements (UPDATE t SET a = 1 WHERE b IN (SELECT ...)), and the
+// queries that follow the statement (INSERT INTO t SELECT ..., CREATE
+// VIEW v AS SELECT ...). Queries that are nested within those queries
+// are part of the outer query and are not returned separately. The
+// positions of the nodes of the queries are the indexes of the tokens
+// in the token list. Queries that can not be parsed are skipped.
+func EmbeddedQueries(tl Tokens, dialect int) (queries []*SelectStatement) {
+
+	p := newParser(&tl, dialect)
+	all := p.sig
+	groups := tl.GroupIndex()
+
+	for k := 0; k < len(all); k++ {
+		switch tokenWord(tl.tokens[all[k]]) {
+		case "SELECT", "WITH":
+		default:
+			continue
+		}
+
+		// a subquery extends to the closing parenthesis, while a query
+		// that follows the statement extends to the end of the statement
+		// (less any trailing clauses, ON CONFLICT, WITH CHECK OPTION, ...)
+		end := len(all)
+		inGroup := k > 0 && tl.tokens[all[k-1]].tokenString == "("
+		switch {
+		case inGroup:
+			close := groups.MatchingParen(all[k-1])
+			end = k
+			for end < len(all) && all[end] != close {
+				end++
+			}
+		case groups.Depth(all[k]) > 0:
+			continue
+		}
+
+		p.sig = all[k:end]
+		p.k = 0
+		q, err := p.parseQuery()
+		if err != nil || (inGroup && p.k < len(p.sig)) {
+			continue
+		}
+		queries = append(queries, q)
+		k += p.k - 1
+	}
+	return queries
+}
+
+// newParser creates a parser for the significant tokens of a token list
+func newParser(tl *Tokens, dialect int) *parser {
+
+	p := parser{
+		tl:      tl,
+		dialect: dialect,
+	}
+	for i := 0; i < tl.length; i++ {
+		if SignificantTokens(tl.tokens[i]) {
+			p.sig = append(p.sig, i)
+		}
+	}
+	return &p
+}
+
+// peek returns the significant token that is n tokens past the current
+// token
+func (p *parser) peek(n int) (t Token) {
+	if k := p.k + n; k >= 0 && k < len(p.sig) {
+		t = p.tl.tokens[p.sig[k]]
+	}
+	return t
+}
+
+// token returns the current token
+func (p *parser) token() Token {
+	return p.peek(0)
+}
+
+// word returns the upper-case value of the current token if it is a
+// keyword or identifier
+func (p *parser) word() string {
+	return tokenWord(p.peek(0))
+}
+
+// is determines whether or not the value of the current token is s
+func (p *parser) is(s string) bool {
+	return p.k < len(p.sig) && p.tl.tokens[p.sig[p.k]].tokenString == s
+}
+
+// next advances to the next token
+func (p *parser) next() {
+	if p.k < len(p.sig) {
+		p.k++
+	}
+}
+
+// pos returns the index, in the token list, of the current token
+func (p *parser) pos() int {
+	if p.k < len(p.sig) {
+		return p.sig[p.k]
+	}
+	return p.tl.length
+}
+
+// end returns the index, in the token list, following the last token
+// consumed
+func (p *parser) end() int {
+	if p.k > 0 {
+		return p.sig[p.k-1] + 1
+	}
+	return 0
+}
+
+// accept consumes the current token if its value is s
+func (p *parser) accept(s string) bool {
+	if p.is(s) {
+		p.next()
+		return true
+	}
+	return false
+}
+
+// acceptWord consumes the current token if it is one of the supplied
+// words
+func (p *parser) acceptWord(words ...string) bool {
+	w := p.word()
+	for _, word := range words {
+		if w == word {
+			p.next()
+			return true
+		}
+	}
+	return false
+}
+
+// expect consumes the current token if its value is s, otherwise an
+// error is returned
+func (p *parser) expect(s string) error {
+	if !p.accept(s) {
+		return p.errorf("expected %q, found %s", s, p.found())
+	}
+	return nil
+}
+
+// expectWord consumes the current token if it is the supplied word,
+// otherwise an error is returned
+func (p *parser) expectWord(word string) error {
+	if !p.acceptWord(word) {
+		return p.errorf("expected %s, found %s", word, p.found())
+	}
+	return nil
+}
+
+// found returns the description of the current token for error messages
+func (p *parser) found() string {
+	if p.k >= len(p.sig) {
+		return "end of input"
+	}
+	return fmt.Sprintf("%q", p.token().tokenString)
+}
+
+// errorf creates a parse error for the current token
+func (p *parser) errorf(format string, args ...interface{}) error {
+	e := ParseError{
+		Index: p.pos(),
+		Msg:   fmt.Sprintf(format, args...),
+	}
+	e.Line, e.Col = p.tl.Position(e.Index)
+	return &e
+}
+
+// parseQuery parses a complete query: the optional WITH clause, the
+// query body, and the ORDER BY and row limiting clauses
+func (p *parser) parseQuery() (q *SelectStatement, err error) {
+
+	q = &SelectStatement{}
+	q.pos = p.pos()
+
+	if p.word() == "WITH" {
+		if q.With, err = p.parseWith(); err != nil {
+			return nil, err
+		}
+	}
+
+	if q.Body, err = p.parseSetOperation(0); err != nil {
+		return nil, err
+	}
+
+	if p.word() == "ORDER" && tokenWord(p.peek(1)) == "BY" {
+		p.k += 2
+		if q.OrderBy, err = p.parseOrderBy(); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = p.parseLimit(q); err != nil {
+		return nil, err
+	}
+
+	q.end = p.end()
+	return q, nil
+}
+
+// parseWith parses the common table expressions of a WITH clause
+func (p *parser) parseWith() (w *WithClause, err error) {
+
+	w = &WithClause{}
+	w.pos = p.pos()
+	p.next()
+	w.Recursive = p.acceptWord("RECURSIVE")
+
+	for {
+		c := &CTE{}
+		c.pos = p.pos()
+		if c.Name = p.parseName(); c.Name == "" {
+			return nil, p.errorf("expected common table expression name, found %s", p.found())
+		}
+		if p.is("(") {
+			if c.Columns, err = p.parseNameList(); err != nil {
+				return nil, err
+			}
+		}
+		if err = p.expectWord("AS"); err != nil {
+			return nil, err
+		}
+		p.acceptWord("NOT")
+		p.acceptWord("MATERIALIZED")
+		if err = p.expect("("); err != nil {
+			return nil, err
+		}
+		if c.Query, err = p.parseQuery(); err != nil {
+			return nil, err
+		}
+		if err = p.expect(")"); err != nil {
+			return nil, err
+		}
+		c.end = p.end()
+		w.CTEs = append(w.CTEs, c)
+
+		if !p.accept(",") {
+			break
+		}
+	}
+
+	w.end = p.end()
+	return w, nil
+}
+
+// parseSetOperation parses a query term and any set operations of at
+// least the minimum precedence that follow it
+func (p *parser) parseSetOperation(minPrec int) (left QueryExpr, err error) {
+
+	start := p.pos()
+	if left, err = p.parseQueryTerm(); err != nil {
+		return nil, err
+	}
+
+	for {
+		op := p.word()
+		prec, ok := setOperators[op]
+		if !ok || prec < minPrec {
+			return left, nil
+		}
+		p.next()
+
+		s := &SetOperation{Op: op, Left: left}
+		if p.acceptWord("ALL") {
+			s.All = true
+		} else {
+			p.acceptWord("DISTINCT")
+		}
+		if s.Right, err = p.parseSetOperation(prec + 1); err != nil {
+			return nil, err
+		}
+		s.pos = start
+		s.end = p.end()
+		left = s
+	}
+}
+
+// parseQueryTerm parses a SELECT or a parenthesized query
+func (p *parser) parseQueryTerm() (q QueryExpr, err error) {
+
+	switch {
+	case p.is("("):
+		pq := &ParenQuery{}
+		pq.pos = p.pos()
+		p.next()
+		if pq.Query, err = p.parseQuery(); err != nil {
+			return nil, err
+		}
+		if err = p.expect(")"); err != nil {
+			return nil, err
+		}
+		pq.end = p.end()
+		return pq, nil
+
+	case p.word() == "SELECT":
+		return p.parseSelect()
+	}
+
+	return nil, p.errorf("expected SELECT, found %s", p.found())
+}
+
+// parseSelect parses a single SELECT query block
+func (p *parser) parseSelect() (s *Select, err error) {
+
+	s = &Select{}
+	s.pos = p.pos()
+	p.next()
+
+	if p.acceptWord("DISTINCT") {
+		s.Distinct = true
+		if p.acceptWord("ON") {
+			if err = p.expect("("); err != nil {
+				return nil, err
+			}
+			if s.DistinctOn, err = p.parseExprList(); err != nil {
+				return nil, err
+			}
+			if err = p.expect(")"); err != nil {
+				return nil, err
+			}
+		}
+	} else {
+		p.acceptWord("ALL")
+	}
+
+	if p.word() == "TOP" {
+		if err = p.parseTop(s); err != nil {
+			return nil, err
+		}
+	}
+
+	for {
+		var item *SelectItem
+		if item, err = p.parseSelectItem(); err != nil {
+			return nil, err
+		}
+		s.Columns = append(s.Columns, item)
+		if !p.accept(",") {
+			break
+		}
+	}
+
+	if p.acceptWord("INTO") {
+		p.acceptWord("TEMPORARY", "TEMP", "UNLOGGED")
+		p.acceptWord("TABLE")
+		for {
+			name := p.parseName()
+			if name == "" {
+				return nil, p.errorf("expected name, found %s", p.found())
+			}
+			s.Into = append(s.Into, name)
+			if !p.accept(",") {
+				break
+			}
+		}
+	}
+
+	if p.acceptWord("FROM") {
+		for {
+			var t TableExpr
+			if t, err = p.parseJoins(); err != nil {
+				return nil, err
+			}
+			s.From = append(s.From, t)
+			if !p.accept(",") {
+				break
+			}
+		}
+	}
+
+	if p.acceptWord("WHERE") {
+		if s.Where, err = p.parseExpr(); err != nil {
+			return nil, err
+		}
+	}
+
+	if p.word() == "GROUP" && tokenWord(p.peek(1)) == "BY" {
+		p.k += 2
+		p.acceptWord("ALL", "DISTINCT")
+		if s.GroupBy, err = p.parseExprList(); err != nil {
+			return nil, err
+		}
+	}
+
+	if p.acceptWord("HAVING") {
+		if s.Having, err = p.parseExpr(); err != nil {
+			return nil, err
+		}
+	}
+
+	s.end = p.end()
+	return s, nil
+}
+
+// parseTop parses the MSSQL TOP clause
+func (p *parser) parseTop(s *Select) (err error) {
+
+	p.next()
+	if p.accept("(") {
+		if s.Top, err = p.parseExpr(); err != nil {
+			return err
+		}
+		if err = p.expect(")"); err != nil {
+			return err
+		}
+	} else if s.Top, err = p.parsePrimary(); err != nil {
+		return err
+	}
+
+	p.acceptWord("PERCENT")
+	if p.word() == "WITH" && tokenWord(p.peek(1)) == "TIES" {
+		p.k += 2
+	}
+	return nil
+}
+
+// parseSelectItem parses an item in the select list
+func (p *parser) parseSelectItem() (item *SelectItem, err error) {
+
+	item = &SelectItem{}
+	item.pos = p.pos()
+
+	if item.Expr = p.parseStar(); item.Expr == nil {
+		if item.Expr, err = p.parseExpr(); err != nil {
+			return nil, err
+		}
+		if item.Alias, err = p.parseAlias(); err != nil {
+			return nil, err
+		}
+	}
+
+	item.end = p.end()
+	return item, nil
+}
+
+// parseStar parses a * or table.* select item. If the current token
+// does not start a star item then nil is returned and no tokens are
+// consumed.
+func (p *parser) parseStar() Expr {
+
+	start := p.k
+	name := ""
+	for p.k < len(p.sig) {
+		t := p.token()
+		if p.k > start && t.leadingWhiteSpace != "" {
+			break
+		}
+		if t.tokenString == "*" {
+			if name != "" && !strings.HasSuffix(name, ".") {
+				break
+			}
+			p.next()
+			s := &StarExpr{Table: strings.TrimSuffix(name, ".")}
+			s.pos = p.sig[start]
+			s.end = p.end()
+			return s
+		}
+		if t.tokenString != "." && !isNameToken(t, p.dialect) && !strings.HasSuffix(t.tokenString, ".") {
+			break
+		}
+		name += t.tokenString
+		p.next()
+	}
+
+	p.k = start
+	return nil
+}
+
+// parseAlias parses the optional alias that follows a select item or a
+// table reference
+func (p *parser) parseAlias() (alias string, err error) {
+
+	if p.acceptWord("AS") {
+		t := p.token()
+		switch t.tokenType {
+		case IdentToken, FunctionToken, KeywordToken, DoubleQuotedToken, BacktickQuotedToken, BracketQuotedToken, SingleQuotedToken:
+			p.next()
+			return t.tokenString, nil
+		}
+		return "", p.errorf("expected alias, found %s", p.found())
+	}
+
+	t := p.token()
+	if isAliasToken(t) && !clauseWords[tokenWord(t)] {
+		p.next()
+		return t.tokenString, nil
+	}
+	return "", nil
+}
+
+// parseJoins parses a table reference and any joins that follow it
+func (p *parser) parseJoins() (left TableExpr, err error) {
+
+	if left, err = p.parseTablePrimary(); err != nil {
+		return nil, err
+	}
+
+	for {
+		joinType := p.parseJoinType()
+		if joinType == "" {
+			return left, nil
+		}
+
+		j := &Join{Type: joinType, Left: left}
+		j.pos = left.Pos()
+		if j.Right, err = p.parseTablePrimary(); err != nil {
+			return nil, err
+		}
+
+		if p.acceptWord("ON") {
+			if j.On, err = p.parseExpr(); err != nil {
+				return nil, err
+			}
+		} else if p.acceptWord("USING") {
+			if j.Using, err = p.parseNameList(); err != nil {
+				return nil, err
+			}
+		}
+
+		j.end = p.end()
+		left = j
+	}
+}
+
+// parseJoinType parses the words that introduce a join and returns the
+// join type. If the current token does not start a join then an empty
+// string is returned and no tokens are consumed.
+func (p *parser) parseJoinType() string {
+
+	start := p.k
+	var words []string
+
+	accept := func(w ...string) bool {
+		if p.acceptWord(w...) {
+			words = append(words, tokenWord(p.peek(-1)))
+			return true
+		}
+		return false
+	}
+
+	switch {
+	case accept("STRAIGHT_JOIN"):
+		return "STRAIGHT_JOIN"
+	case accept("CROSS", "OUTER"):
+		if accept("APPLY") {
+			return strings.Join(words, " ")
+		}
+	case accept("NATURAL"):
+		if accept("LEFT", "RIGHT", "FULL") {
+			accept("OUTER")
+		} else {
+			accept("INNER")
+		}
+	case accept("LEFT", "RIGHT", "FULL"):
+		accept("OUTER")
+	default:
+		accept("INNER")
+	}
+
+	if len(words) > 0 && words[0] == "OUTER" {
+		p.k = start
+		return ""
+	}
+	if !accept("JOIN") {
+		p.k = start
+		return ""
+	}
+	return strings.Join(words, " ")
+}
+
+// parseTablePrimary parses a single table reference: a table name, a
+// table function, a derived table, or a parenthesized join
+func (p *parser) parseTablePrimary() (t TableExpr, err error) {
+
+	start := p.pos()
+	lateral := p.acceptWord("LATERAL")
+
+	if p.is("(") {
+		if !p.isDerivedTable() {
+			pt := &ParenTable{}
+			pt.pos = start
+			p.next()
+			if pt.Table, err = p.parseJoins(); err != nil {
+				return nil, err
+			}
+			if err = p.expect(")"); err != nil {
+				return nil, err
+			}
+			pt.end = p.end()
+			return pt, nil
+		}
+
+		d := &DerivedTable{Lateral: lateral}
+		d.pos = start
+		p.next()
+		if d.Query, err = p.parseQuery(); err != nil {
+			return nil, err
+		}
+		if err = p.expect(")"); err != nil {
+			return nil, err
+		}
+		if d.Alias, err = p.parseAlias(); err != nil {
+			return nil, err
+		}
+		if d.Alias != "" && p.is("(") {
+			if d.Columns, err = p.parseNameList(); err != nil {
+				return nil, err
+			}
+		}
+		d.end = p.end()
+		return d, nil
+	}
+
+	name := p.parseName()
+	if name == "" {
+		return nil, p.errorf("expected table name, found %s", p.found())
+	}
+
+	if p.accept("(") {
+		f := &TableFunction{Lateral: lateral, Name: name}
+		f.pos = start
+		if !p.is(")") {
+			if f.Args, err = p.parseExprList(); err != nil {
+				return nil, err
+			}
+		}
+		if err = p.expect(")"); err != nil {
+			return nil, err
+		}
+		if f.Alias, err = p.parseAlias(); err != nil {
+			return nil, err
+		}
+		if f.Alias != "" && p.is("(") {
+			if f.Columns, err = p.parseNameList(); err != nil {
+				return nil, err
+			}
+		}
+		f.end = p.end()
+		return f, nil
+	}
+
+	tn := &TableName{Name: name}
+	tn.pos = start
+	if tn.Alias, err = p.parseAlias(); err != nil {
+		return nil, err
+	}
+
+	// MSSQL table hints, WITH (NOLOCK)
+	if p.word() == "WITH" && p.peek(1).tokenString == "(" {
+		p.next()
+		p.skipGroup()
+	}
+
+	tn.end = p.end()
+	return tn, nil
+}
+
+// isDerivedTable determines whether the parenthesis at the current
+// token starts a subquery (as opposed to a parenthesized join)
+func (p *parser) isDerivedTable() bool {
+	n := 0
+	for p.peek(n).tokenString == "(" {
+		n++
+	}
+	switch tokenWord(p.peek(n)) {
+	case "SELECT", "WITH":
+		return true
+	}
+	return false
+}
+
+// skipGroup skips the parenthesized group that starts at the current
+// token
+func (p *parser) skipGroup() {
+	depth := 0
+	for ; p.k < len(p.sig); p.k++ {
+		switch p.token().tokenString {
+		case "(":
+			depth++
+		case ")":
+			depth--
+		}
+		if depth == 0 {
+			p.k++
+			return
+		}
+	}
+}
+
+// parseName parses a (possibly qualified) name. If the current token
+// does not start a name then an empty string is returned.
+func (p *parser) parseName() (name string) {
+
+	t := p.token()
+	if !isNameToken(t, p.dialect) {
+		return ""
+	}
+
+	name = t.tokenString
+	for p.next(); p.k < len(p.sig); p.next() {
+		n := p.token()
+		if n.leadingWhiteSpace != "" {
+			break
+		}
+		if n.tokenString == "." || strings.HasSuffix(name, ".") || strings.HasPrefix(n.tokenString, ".") {
+			name += n.tokenString
+			continue
+		}
+		break
+	}
+	return name
+}
+
+// parseNameList parses a parenthesized list of names
+func (p *parser) parseNameList() (names []string, err error) {
+
+	if err = p.expect("("); err != nil {
+		return nil, err
+	}
+	for {
+		name := p.parseName()
+		if name == "" {
+			return nil, p.errorf("expected name, found %s", p.found())
+		}
+		names = append(names, name)
+		if !p.accept(",") {
+			break
+		}
+	}
+	if err = p.expect(")"); err != nil {
+		return nil, err
+	}
+	return names, nil
+}
+
+// parseOrderBy parses the items of an ORDER BY clause
+func (p *parser) parseOrderBy() (items []*OrderItem, err error) {
+
+	for {
+		o := &OrderItem{}
+		o.pos = p.pos()
+		if o.Expr, err = p.parseExpr(); err != nil {
+			return nil, err
+		}
+		if p.acceptWord("DESC") {
+			o.Desc = true
+		} else {
+			p.acceptWord("ASC")
+		}
+		if p.acceptWord("NULLS") {
+			if !p.acceptWord("FIRST", "LAST") {
+				return nil, p.errorf("expected FIRST or LAST, found %s", p.found())
+			}
+			o.Nulls = tokenWord(p.peek(-1))
+		}
+		o.end = p.end()
+		items = append(items, o)
+
+		if !p.accept(",") {
+			return items, nil
+		}
+	}
+}
+
+// parseLimit parses the LIMIT, OFFSET, and FETCH clauses of a query
+func (p *parser) parseLimit(q *SelectStatement) (err error) {
+
+	for {
+		switch p.word() {
+		case "LIMIT":
+			p.next()
+			if p.acceptWord("ALL") {
+				continue
+			}
+			if q.Limit, err = p.parseExpr(); err != nil {
+				return err
+			}
+			if p.accept(",") {
+				// LIMIT offset, count
+				q.Offset = q.Limit
+				if q.Limit, err = p.parseExpr(); err != nil {
+					return err
+				}
+			}
+
+		case "OFFSET":
+			p.next()
+			if q.Offset, err = p.parseExpr(); err != nil {
+				return err
+			}
+			p.acceptWord("ROW", "ROWS")
+
+		case "FETCH":
+			p.next()
+			if !p.acceptWord("FIRST", "NEXT") {
+				return p.errorf("expected FIRST or NEXT, found %s", p.found())
+			}
+			if w := p.word(); w != "ROW" && w != "ROWS" {
+				if q.Limit, err = p.parseExpr(); err != nil {
+					return err
+				}
+				p.acceptWord("PERCENT")
+			}
+			if !p.acceptWord("ROW", "ROWS") {
+				return p.errorf("expected ROWS, found %s", p.found())
+			}
+			if p.acceptWord("WITH") {
+				if err = p.expectWord("TIES"); err != nil {
+					return err
+				}
+			} else if err = p.expectWord("ONLY"); err != nil {
+				return err
+			}
+
+		default:
+			return nil
+		}
+	}
+}
+
+// parseExprList parses a comma separated list of expressions
+func (p *parser) parseExprList() (l []Expr, err error) {
+	for {
+		var e Expr
+		if e, err = p.parseExpr(); err != nil {
+			return nil, err
+		}
+		l = append(l, e)
+		if !p.accept(",") {
+			return l, nil
+		}
+	}
+}
@@ -0,0 +1,279 @@
+package sqlparse
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// formatNode renders a syntax tree with braces around the set
+// operations and joins so that the structure of the tree is visible
+func formatNode(tl Tokens, n Node) string {
+
+	exprs := func(l []Expr) string {
+		var s []string
+		for _, e := range l {
+			s = append(s, formatNode(tl, e))
+		}
+		return strings.Join(s, ", ")
+	}
+	alias := func(a string) string {
+		if a != "" {
+			return " AS " + a
+		}
+		return ""
+	}
+
+	switch n := n.(type) {
+	case *SelectStatement:
+		s := ""
+		if n.With != nil {
+			s = "WITH "
+			for i, c := range n.With.CTEs {
+				if i > 0 {
+					s += ", "
+				}
+				s += c.Name + " AS (" + formatNode(tl, c.Query) + ")"
+			}
+			s += " "
+		}
+		s += formatNode(tl, n.Body)
+		if len(n.OrderBy) > 0 {
+			var o []string
+			for _, item := range n.OrderBy {
+				x := formatNode(tl, item.Expr)
+				if item.Desc {
+					x += " DESC"
+				}
+				o = append(o, x)
+			}
+			s += " ORDER BY " + strings.Join(o, ", ")
+		}
+		if n.Limit != nil {
+			s += " LIMIT " + formatNode(tl, n.Limit)
+		}
+		if n.Offset != nil {
+			s += " OFFSET " + formatNode(tl, n.Offset)
+		}
+		return s
+
+	case *Select:
+		s := "SELECT "
+		if n.Distinct {
+			s += "DISTINCT "
+		}
+		if n.Top != nil {
+			s += "TOP " + formatNode(tl, n.Top) + " "
+		}
+		var cols []string
+		for _, c := range n.Columns {
+			cols = append(cols, formatNode(tl, c.Expr)+alias(c.Alias))
+		}
+		s += strings.Join(cols, ", ")
+		if len(n.From) > 0 {
+			var from []string
+			for _, f := range n.From {
+				from = append(from, formatNode(tl, f))
+			}
+			s += " FROM " + strings.Join(from, ", ")
+		}
+		if n.Where != nil {
+			s += " WHERE " + formatNode(tl, n.Where)
+		}
+		if len(n.GroupBy) > 0 {
+			s += " GROUP BY " + exprs(n.GroupBy)
+		}
+		if n.Having != nil {
+			s += " HAVING " + formatNode(tl, n.Having)
+		}
+		return s
+
+	case *SetOperation:
+		op := n.Op
+		if n.All {
+			op += " ALL"
+		}
+		return "{" + formatNode(tl, n.Left) + " " + op + " " + formatNode(tl, n.Right) + "}"
+
+	case *ParenQuery:
+		return "(" + formatNode(tl, n.Query) + ")"
+
+	case *TableName:
+		return n.Name + alias(n.Alias)
+
+	case *DerivedTable:
+		return "(" + formatNode(tl, n.Query) + ")" + alias(n.Alias)
+
+	case *TableFunction:
+		return n.Name + "(" + exprs(n.Args) + ")" + alias(n.Alias)
+
+	case *Join:
+		s := "{" + formatNode(tl, n.Left) + " " + n.Type + " " + formatNode(tl, n.Right)
+		if n.On != nil {
+			s += " ON " + formatNode(tl, n.On)
+		}
+		if len(n.Using) > 0 {
+			s += " USING (" + strings.Join(n.Using, ", ") + ")"
+		}
+		return s + "}"
+
+	case *ParenTable:
+		return "(" + formatNode(tl, n.Table) + ")"
+
+	case *StarExpr:
+		if n.Table != "" {
+			return n.Table + ".*"
+		}
+		return "*"
+	}
+
+	s := tl.Slice(n.Pos(), n.End())
+	return strings.TrimSpace(s.String())
+}
+
+func TestParseSelect(t *testing.T) {
+
+	cases := []struct {
+		input    string
+		dialect  int
+		expected string
+	}{
+		{
+			"SELECT a, b + 1 AS c, d e, t.* FROM t",
+			PostgreSQL,
+			"SELECT a, b + 1 AS c, d AS e, t.* FROM t",
+		},
+		{
+			"-- the query\nSELECT DISTINCT a FROM t1 x LEFT OUTER JOIN t2 y ON x.id = y.id JOIN t3 USING (id), t4 WHERE a > 1 GROUP BY a HAVING count(*) > 1;",
+			PostgreSQL,
+			"SELECT DISTINCT a FROM {{t1 AS x LEFT OUTER JOIN t2 AS y ON x.id = y.id} JOIN t3 USING (id)}, t4 WHERE a > 1 GROUP BY a HAVING count(*) > 1",
+		},
+		{
+			"SELECT 1 UNION SELECT 2 INTERSECT SELECT 3 UNION ALL SELECT 4 ORDER BY 1 DESC LIMIT 10 OFFSET 5",
+			PostgreSQL,
+			"{{SELECT 1 UNION {SELECT 2 INTERSECT SELECT 3}} UNION ALL SELECT 4} ORDER BY 1 DESC LIMIT 10 OFFSET 5",
+		},
+		{
+			"WITH x AS (SELECT a FROM t), y (b) AS (SELECT b FROM u) SELECT * FROM x CROSS JOIN (SELECT 1) z",
+			PostgreSQL,
+			"WITH x AS (SELECT a FROM t), y AS (SELECT b FROM u) SELECT * FROM {x CROSS JOIN (SELECT 1) AS z}",
+		},
+		{
+			"(SELECT a FROM t) EXCEPT (SELECT a FROM u ORDER BY a LIMIT 1)",
+			PostgreSQL,
+			"{(SELECT a FROM t) EXCEPT (SELECT a FROM u ORDER BY a LIMIT 1)}",
+		},
+		{
+			"SELECT TOP 10 [a].*, CASE WHEN b = 1 THEN 'x' ELSE 'y' END flag FROM [dbo].[t] WITH (NOLOCK) CROSS APPLY f(t.id) AS g",
+			MSSQL,
+			"SELECT TOP 10 [a].*, CASE WHEN b = 1 THEN 'x' ELSE 'y' END AS flag FROM {[dbo].[t] CROSS APPLY f(t.id) AS g}",
+		},
+		{
+			"SELECT a FROM t ORDER BY a OFFSET 10 ROWS FETCH NEXT 5 ROWS ONLY",
+			Oracle,
+			"SELECT a FROM t ORDER BY a LIMIT 5 OFFSET 10",
+		},
+		{
+			"SELECT a FROM t LIMIT 20, 10",
+			MySQL,
+			"SELECT a FROM t LIMIT 10 OFFSET 20",
+		},
+		{
+			"SELECT LEFT(a, 2) FROM (t1 JOIN t2 ON t1.id = t2.id) NATURAL JOIN generate_series(1, 3) g",
+			PostgreSQL,
+			"SELECT LEFT(a, 2) FROM {({t1 JOIN t2 ON t1.id = t2.id}) NATURAL JOIN generate_series(1, 3) AS g}",
+		},
+	}
+
+	for _, c := range cases {
+		tl := ParseStatements(c.input, c.dialect)
+		stmt, err := ParseSelect(tl, c.dialect)
+		if err != nil {
+			t.Errorf("%q: unexpected error: %s", c.input, err)
+			continue
+		}
+		if got := formatNode(tl, stmt); got != c.expected {
+			t.Errorf("%q:\n  expected %s\n  got      %s", c.input, c.expected, got)
+		}
+	}
+}
+
+func TestParseSelectErrors(t *testing.T) {
+
+	cases := []struct {
+		input    string
+		expected string
+	}{
+		{"SELECT a FROM", "line 1, column 14: expected table name, found end of input"},
+		{"SELECT a\nFROM t WHERE (a = 1", "line 2, column 20: expected \")\", found end of input"},
+		{"UPDATE t SET a = 1", "line 1, column 1: expected SELECT, found \"UPDATE\""},
+		{"SELECT a FROM t t2 t3", "line 1, column 20: unexpected \"t3\""},
+	}
+
+	for _, c := range cases {
+		tl := ParseStatements(c.input, PostgreSQL)
+		_, err := ParseSelect(tl, PostgreSQL)
+		if err == nil {
+			t.Errorf("%q: expected an error", c.input)
+			continue
+		}
+		if err.Error() != c.expected {
+			t.Errorf("%q:\n  expected %s\n  got      %s", c.input, c.expected, err)
+		}
+	}
+}
+
+func TestWalk(t *testing.T) {
+
+	input := "SELECT a\nFROM t\nJOIN (SELECT b FROM u, v) x ON x.b = t.a\nWHERE EXISTS (SELECT 1 FROM w)"
+	tl := ParseStatements(input, PostgreSQL)
+	stmt, err := ParseSelect(tl, PostgreSQL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var tables []string
+	Inspect(stmt, func(n Node) bool {
+		if tn, ok := n.(*TableName); ok {
+			line, col := tl.Position(tn.Pos())
+			tables = append(tables, fmt.Sprintf("%s@%d:%d", tn.Name, line, col))
+		}
+		return true
+	})
+
+	expected := "t@2:6 u@3:21 v@3:24 w@4:29"
+	if got := strings.Join(tables, " "); got != expected {
+		t.Errorf("expected %s, got %s", expected, got)
+	}
+}
+
+func TestEmbeddedQueries(t *testing.T) {
+
+	cases := []struct {
+		input    string
+		expected string
+	}{
+		{"UPDATE t SET a = 1 WHERE b IN (SELECT * FROM u WHERE c IN (SELECT c FROM v))", "u@1:46 v@1:74"},
+		{"INSERT INTO t (a, b) SELECT a, b FROM u ON CONFLICT DO NOTHING", "u@1:39"},
+		{"CREATE VIEW v AS\nWITH x AS (SELECT a FROM u) SELECT * FROM x", "u@2:26 x@2:43"},
+		{"WITH x AS (SELECT a FROM u) DELETE FROM t WHERE a IN (SELECT a FROM x)", "u@1:26 x@1:69"},
+		{"CREATE TABLE t (a int DEFAULT 1)", ""},
+	}
+
+	for _, c := range cases {
+		tl := ParseStatements(c.input, PostgreSQL)
+		var tables []string
+		for _, q := range EmbeddedQueries(tl, PostgreSQL) {
+			Inspect(q, func(n Node) bool {
+				if tn, ok := n.(*TableName); ok {
+					line, col := tl.Position(tn.Pos())
+					tables = append(tables, fmt.Sprintf("%s@%d:%d", tn.Name, line, col))
+				}
+				return true
+			})
+		}
+		if got := strings.Join(tables, " "); got != c.expected {
+			t.Errorf("%q: expected %s, got %s", c.input, c.expected, got)
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package sqlparse
+
+/*
+
+statements.go provides the functionality for splitting the token list
+into individual statements.
+
+*/
+
+import (
+	"strings"
+)
+
+// StatementRange provides the range of the tokens that make up a single
+// statement. The range includes any comments that precede the statement
+// and the token that terminates the statement (if there is one).
+type StatementRange struct {
+	Start int // the index of the first token of the statement
+	End   int // the index following the last token of the statement
+}
+
+// StatementRanges returns the ranges of the statements in the token
+// list. Statements are terminated by semi-colons that are not enclosed
+// in parentheses and, depending on the dialect, by batch separators
+// (GO for MSSQL and a "/" on a line by itself for Oracle). Ranges that
+// would contain nothing but comments are not returned.
+//
+// When the token list was parsed with delimiters (a delimiter directive
+// or MySQL DELIMITER commands, see ParseAuto) the statements are
+// terminated by the delimiters instead of by semi-colons, regardless of
+// any parentheses.
+//
+// Note that procedural blocks (BEGIN ... END;) will be split at each
+// semi-colon within the block.
+func (d *Tokens) StatementRanges(dialect int) (r []StatementRange) {
+
+	start := 0
+	depth := 0
+	significant := false
+
+	for i := 0; i < d.length; i++ {
+		t := d.tokens[i]
+		isSeparator := d.isBatchSeparator(i, dialect)
+
+		// a batch separator following a terminated statement does not
+		// constitute a statement on its own
+		if SignificantTokens(t) && !isSeparator {
+			significant = true
+		}
+
+		if t.tokenType == OtherToken {
+			switch t.tokenString {
+			case "(":
+				depth++
+			case ")":
+				if depth > 0 {
+					depth--
+				}
+			}
+		}
+
+		if d.isTerminator(i, depth) || isSeparator {
+			if significant {
+				r = append(r, StatementRange{Start: start, End: i + 1})
+			}
+			start = i + 1
+			significant = false
+			depth = 0
+		}
+	}
+
+	if significant {
+		r = append(r, StatementRange{Start: start, End: d.length})
+	}
+
+	return r
+}
+
+// Statements returns a token list for each of the statements in the
+// token list
+func (d *Tokens) Statements(dialect int) (s []Tokens) {
+	for _, r := range d.StatementRanges(dialect) {
+		s = append(s, d.Slice(r.Start, r.End))
+	}
+	return s
+}
+
+// isTerminator determines whether or not the token at index i, at the
+// specified parenthesis depth, terminates a statement
+func (d *Tokens) isTerminator(i, depth int) bool {
+	t := d.tokens[i]
+	if t.tokenType != OtherToken {
+		return false
+	}
+	if d.delimited {
+		return t.terminator
+	}
+	return depth == 0 && t.tokenString == ";"
+}
+
+// isBatchSeparator determines whether or not the token at index i is
+// a client side batch separator that stands on a line by itself
+func (d *Tokens) isBatchSeparator(i int, dialect int) bool {
+
+	t := d.tokens[i]
+
+	switch dialect {
+	case MSSQL:
+		if t.tokenType != IdentToken || strings.ToUpper(t.tokenString) != "GO" {
+			return false
+		}
+	case Oracle:
+		if t.tokenType != OperatorToken || t.tokenString != "/" {
+			return false
+		}
+	default:
+		return false
+	}
+
+	if i > 0 && !strings.Contains(t.leadingWhiteSpace, "\n") {
+		return false
+	}
+	if i+1 < d.length {
+		return strings.Contains(d.tokens[i+1].leadingWhiteSpace, "\n")
+	}
+	return true
+}
@@ -0,0 +1,441 @@
+package sqlparse
+
+/*
+
+tables.go provides the functionality for determining which tables a
+statement reads from and writes to.
+
+*/
+
+import (
+	"strings"
+)
+
+// Table access modes
+const (
+	// NullAccess indicates an undetermined access mode
+	NullAccess = iota
+	// ReadAccess indicates that the table is read from
+	ReadAccess
+	// WriteAccess indicates that the table is written to
+	WriteAccess
+)
+
+// TableRef provides a reference to a table by a statement
+type TableRef struct {
+	Name   string // the name of the table, as qualified in the statement
+	Alias  string // the alias for the table, if any
+	Access int    // how the table is accessed (ReadAccess or WriteAccess)
+	Index  int    // the index of the first token of the table name
+}
+
+// TableReferences returns the tables that are referenced by the
+// SELECT, INSERT, UPDATE, DELETE, and MERGE statements in the token
+// list. Tables referenced in joins and subqueries are included while
+// the names of common table expressions, and table functions, are not.
+func TableReferences(tl Tokens, dialect int) (refs []TableRef) {
+
+	var sig []int
+	for i := 0; i < tl.length; i++ {
+		if SignificantTokens(tl.tokens[i]) {
+			sig = append(sig, i)
+		}
+	}
+
+	r := tableReader{
+		tl:      &tl,
+		sig:     sig,
+		dialect: dialect,
+		ctes:    make(map[string]bool),
+	}
+	return r.read()
+}
+
+// tableReader tracks the state for reading table references from a
+// list of significant token indexes
+type tableReader struct {
+	tl      *Tokens
+	sig     []int           // the indexes of the significant tokens
+	dialect int             // the SQL dialect
+	ctes    map[string]bool // the names of the common table expressions in the current statement
+	refs    []TableRef      // the table references found
+}
+
+// tableClause tracks the table list clause (if any) at a given
+// parenthesis depth
+type tableClause struct {
+	inFromList bool // the clause is a FROM list (where commas separate tables)
+	inFunction bool // the parentheses enclose the arguments to a function
+	access     int  // the access mode for tables in the clause
+}
+
+// map[keyword]bool of the keywords that may be followed by a
+// parenthesized list or subquery (as opposed to function arguments)
+var nonFunctionWords = map[string]bool{
+	"ALL":       true,
+	"AND":       true,
+	"ANY":       true,
+	"AS":        true,
+	"BY":        true,
+	"ELSE":      true,
+	"EXCEPT":    true,
+	"EXISTS":    true,
+	"FROM":      true,
+	"IN":        true,
+	"INTERSECT": true,
+	"INTO":      true,
+	"JOIN":      true,
+	"LATERAL":   true,
+	"MINUS":     true,
+	"NOT":       true,
+	"ON":        true,
+	"OR":        true,
+	"OVER":      true,
+	"RETURN":    true,
+	"SELECT":    true,
+	"SOME":      true,
+	"TABLE":     true,
+	"THEN":      true,
+	"UNION":     true,
+	"USING":     true,
+	"VALUES":    true,
+	"WHEN":      true,
+	"WHERE":     true,
+	"WITH":      true,
+}
+
+func (r *tableReader) read() []TableRef {
+
+	clauses := []tableClause{{}}
+	prevWord := ""
+
+	for k := 0; k < len(r.sig); k++ {
+		t := r.token(k)
+		cur := &clauses[len(clauses)-1]
+
+		switch t.tokenString {
+		case "(":
+			p := r.token(k - 1)
+			inFunction := isNameToken(p, r.dialect) && !nonFunctionWords[tokenWord(p)]
+			clauses = append(clauses, tableClause{inFunction: inFunction})
+			prevWord = ""
+			continue
+		case ")":
+			if len(clauses) > 1 {
+				clauses = clauses[:len(clauses)-1]
+			}
+			prevWord = ""
+			continue
+		case ";":
+			clauses = []tableClause{{}}
+			r.ctes = make(map[string]bool)
+			prevWord = ""
+			continue
+		case ",":
+			if cur.inFromList {
+				k = r.readTable(k+1, cur.access, false)
+			}
+			continue
+		}
+
+		word := tokenWord(t)
+		if cur.inFunction {
+			// EXTRACT(YEAR FROM ...), TRIM(BOTH FROM ...), etc.
+			continue
+		}
+
+		switch word {
+		case "WITH":
+			r.readCTEs(k + 1)
+
+		case "FROM":
+			access := ReadAccess
+			if prevWord == "DELETE" {
+				access = WriteAccess
+			}
+			cur.inFromList = true
+			cur.access = ReadAccess
+			k = r.readTable(k+1, access, false)
+
+		case "JOIN", "APPLY":
+			cur.inFromList = true
+			cur.access = ReadAccess
+			k = r.readTable(k+1, ReadAccess, false)
+
+		case "USING":
+			// MERGE ... USING source and DELETE ... USING source, but
+			// not JOIN ... USING (columns)
+			cur.inFromList = true
+			cur.access = ReadAccess
+			k = r.readTable(k+1, ReadAccess, false)
+
+		case "UPDATE":
+			// skip ON CONFLICT ... DO UPDATE and ON DUPLICATE KEY UPDATE
+			if prevWord != "DO" && prevWord != "KEY" && prevWord != "FOR" {
+				k = r.skipWords(k+1, "LOW_PRIORITY", "IGNORE", "ONLY", "OR", "ROLLBACK", "ABORT", "REPLACE", "FAIL")
+				k = r.readTable(k, WriteAccess, false)
+			}
+
+		case "INSERT", "REPLACE", "UPSERT":
+			if k == 0 || r.startsStatement(k) {
+				k = r.skipWords(k+1, "LOW_PRIORITY", "DELAYED", "HIGH_PRIORITY", "IGNORE", "OR", "ROLLBACK", "ABORT", "REPLACE", "FAIL", "INTO", "OVERWRITE", "TABLE")
+				k = r.readTable(k, WriteAccess, true)
+			}
+
+		case "MERGE":
+			k = r.skipWords(k+1, "INTO")
+			k = r.readTable(k, WriteAccess, true)
+
+		case "INTO":
+			// SELECT ... INTO new_table
+			if r.selectInto(k) {
+				k = r.readTable(k+1, WriteAccess, true)
+			}
+
+		case "WHERE", "GROUP", "HAVING", "ORDER", "LIMIT", "OFFSET", "FETCH", "UNION", "INTERSECT", "EXCEPT", "MINUS", "WINDOW", "SET", "RETURNING", "CONNECT", "START", "QUALIFY", "VALUES", "SELECT":
+			cur.inFromList = false
+		}
+
+		if word != "" {
+			prevWord = word
+		}
+	}
+
+	return r.refs
+}
+
+// token returns the k-th significant token
+func (r *tableReader) token(k int) (t Token) {
+	if k >= 0 && k < len(r.sig) {
+		t = r.tl.tokens[r.sig[k]]
+	}
+	return t
+}
+
+// startsStatement determines whether or not the k-th significant token
+// starts a statement (or the main statement following a WITH clause,
+// or a statement within a procedural block)
+func (r *tableReader) startsStatement(k int) bool {
+	p := r.token(k - 1)
+	switch tokenWord(p) {
+	case "EXPLAIN", "AS", "BEGIN", "THEN", "ELSE", "LOOP":
+		return true
+	}
+	return p.tokenString == ";" || p.tokenString == ")" || p.tokenString == "("
+}
+
+// selectInto determines whether the INTO at the k-th significant token
+// is part of a SELECT ... INTO new_table statement
+func (r *tableReader) selectInto(k int) bool {
+
+	switch r.dialect {
+	case Oracle:
+		// PL/SQL SELECT ... INTO variables
+		return false
+	}
+
+	next := r.token(k + 1)
+	if strings.HasPrefix(next.tokenString, "@") || strings.HasPrefix(next.tokenString, ":") {
+		return false
+	}
+	switch tokenWord(next) {
+	case "OUTFILE", "DUMPFILE", "TEMP", "TEMPORARY", "UNLOGGED":
+		return false
+	}
+
+	// look back for the SELECT at the same depth
+	depth := 0
+	for j := k - 1; j >= 0; j-- {
+		switch r.token(j).tokenString {
+		case ")":
+			depth++
+		case "(":
+			if depth == 0 {
+				return false
+			}
+			depth--
+		case ";":
+			return false
+		}
+		if depth == 0 {
+			switch tokenWord(r.token(j)) {
+			case "SELECT":
+				return true
+			case "INSERT", "MERGE", "REPLACE", "UPSERT":
+				return false
+			}
+		}
+	}
+	return false
+}
+
+// skipWords returns the index of the first significant token, starting
+// at k, that is not one of the supplied words
+func (r *tableReader) skipWords(k int, words ...string) int {
+	for ; k < len(r.sig); k++ {
+		w := tokenWord(r.token(k))
+		found := false
+		for _, word := range words {
+			if w == word {
+				found = true
+				break
+			}
+		}
+		if !found {
+			break
+		}
+	}
+	return k
+}
+
+// readCTEs records the names of the common table expressions in the
+// WITH clause that starts at the k-th significant token
+func (r *tableReader) readCTEs(k int) {
+
+	k = r.skipWords(k, "RECURSIVE")
+	for k < len(r.sig) {
+		name, next := r.readName(k)
+		if name == "" {
+			return
+		}
+		r.ctes[strings.ToUpper(unqualifiedName(name))] = true
+
+		// optional column list
+		k = r.skipGroup(next)
+		if tokenWord(r.token(k)) != "AS" {
+			return
+		}
+		k = r.skipWords(k+1, "NOT", "MATERIALIZED")
+		k = r.skipGroup(k)
+		if r.token(k).tokenString != "," {
+			return
+		}
+		k++
+	}
+}
+
+// skipGroup returns the index following the parenthesized group that
+// starts at the k-th significant token. If there is no group at k then
+// k is returned.
+func (r *tableReader) skipGroup(k int) int {
+	if r.token(k).tokenString != "(" {
+		return k
+	}
+	depth := 0
+	for ; k < len(r.sig); k++ {
+		switch r.token(k).tokenString {
+		case "(":
+			depth++
+		case ")":
+			depth--
+			if depth == 0 {
+				return k + 1
+			}
+		}
+	}
+	return k
+}
+
+// readTable reads the table reference that starts at the k-th
+// significant token and returns the index of the last token consumed.
+// When allowColumns is true the table name may be followed by a
+// parenthesized column list, otherwise a name followed by parentheses
+// is a table function.
+func (r *tableReader) readTable(k int, access int, allowColumns bool) int {
+
+	k = r.skipWords(k, "LATERAL", "ONLY")
+	if r.token(k).tokenString == "(" {
+		// derived table or subquery, the contents are read as the
+		// tokens are walked
+		return k - 1
+	}
+
+	start := k
+	name, next := r.readName(k)
+	if name == "" {
+		return k - 1
+	}
+	if r.token(next).tokenString == "(" && !allowColumns {
+		// table function
+		return next - 1
+	}
+
+	ref := TableRef{
+		Name:   name,
+		Access: access,
+		Index:  r.sig[start],
+	}
+
+	// the alias, if any
+	k = next
+	if tokenWord(r.token(k)) == "AS" {
+		if alias, n := r.readName(k + 1); alias != "" {
+			ref.Alias = alias
+			k = n
+		}
+	} else if isAliasToken(r.token(k)) {
+		ref.Alias = r.token(k).tokenString
+		k++
+	}
+
+	if !r.ctes[strings.ToUpper(name)] {
+		r.refs = append(r.refs, ref)
+	}
+	return k - 1
+}
+
+// readName reads the (possibly qualified) name that starts at the k-th
+// significant token and returns the name and the index of the token
+// following the name. If there is no name at k then an empty string
+// is returned.
+func (r *tableReader) readName(k int) (name string, next int) {
+
+	t := r.token(k)
+	if !isNameToken(t, r.dialect) {
+		return "", k
+	}
+
+	name = t.tokenString
+	for k++; k < len(r.sig); k++ {
+		n := r.token(k)
+		if n.leadingWhiteSpace != "" {
+			break
+		}
+		if n.tokenString == "." || strings.HasSuffix(name, ".") || strings.HasPrefix(n.tokenString, ".") {
+			name += n.tokenString
+			continue
+		}
+		break
+	}
+	return name, k
+}
+
+// isNameToken determines whether or not the token could be the name of
+// a table (or other database object)
+func isNameToken(t Token, dialect int) bool {
+	switch t.tokenType {
+	case IdentToken, FunctionToken, DoubleQuotedToken, BacktickQuotedToken, BracketQuotedToken:
+		return true
+	case KeywordToken:
+		return !IsReservedKeyword(t.tokenString, dialect)
+	}
+	return false
+}
+
+// isAliasToken determines whether or not the token could be an alias
+// that is not preceded by AS
+func isAliasToken(t Token) bool {
+	switch t.tokenType {
+	case IdentToken, FunctionToken, DoubleQuotedToken, BacktickQuotedToken, BracketQuotedToken:
+		return true
+	}
+	return false
+}
+
+// unqualifiedName returns the last part of a qualified name
+func unqualifiedName(name string) string {
+	if i := strings.LastIndex(name, "."); i >= 0 {
+		return name[i+1:]
+	}
+	return name
+}
@@ -0,0 +1,97 @@
+package sqlparse
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTableReferences(t *testing.T) {
+
+	cases := []struct {
+		input    string
+		dialect  int
+		expected string
+	}{
+		{
+			"SELECT a.x, b.y FROM app.a a JOIN b AS bb ON (a.id = bb.id), c WHERE EXTRACT(YEAR FROM a.d) = 2020",
+			PostgreSQL,
+			"R:app.a/a R:b/bb R:c",
+		},
+		{
+			"SELECT * FROM (SELECT id FROM t1) x, t2 LEFT JOIN t3 USING (id) WHERE x.id IN (SELECT id FROM t4)",
+			PostgreSQL,
+			"R:t1 R:t2 R:t3 R:t4",
+		},
+		{
+			"WITH recent AS (SELECT * FROM orders WHERE d > now()) SELECT * FROM recent r JOIN customers c ON c.id = r.cid",
+			PostgreSQL,
+			"R:orders R:customers/c",
+		},
+		{
+			"SELECT * FROM generate_series(1, 10) g, t",
+			PostgreSQL,
+			"R:t",
+		},
+		{
+			"INSERT INTO archive (id, name) SELECT id, name FROM users WHERE active = 0",
+			PostgreSQL,
+			"W:archive R:users",
+		},
+		{
+			"CREATE PROCEDURE p AS BEGIN IF @x = 1 THEN INSERT INTO log SELECT * FROM events; END IF; END",
+			MSSQL,
+			"W:log R:events",
+		},
+		{
+			"UPDATE t SET a = s.a FROM s WHERE t.id = s.id",
+			PostgreSQL,
+			"W:t R:s",
+		},
+		{
+			"DELETE FROM t WHERE id IN (SELECT id FROM u)",
+			PostgreSQL,
+			"W:t R:u",
+		},
+		{
+			"MERGE INTO t USING s ON (t.id = s.id) WHEN MATCHED THEN UPDATE SET t.a = s.a WHEN NOT MATCHED THEN INSERT (id, a) VALUES (s.id, s.a)",
+			Oracle,
+			"W:t R:s",
+		},
+		{
+			"SELECT * INTO #tmp FROM [dbo].[users] u",
+			MSSQL,
+			"W:#tmp R:[dbo].[users]/u",
+		},
+		{
+			"INSERT INTO t (a) VALUES (1) ON DUPLICATE KEY UPDATE a = 2",
+			MySQL,
+			"W:t",
+		},
+		{
+			"SELECT 1 FROM a; WITH a AS (SELECT 1) SELECT * FROM a",
+			PostgreSQL,
+			"R:a",
+		},
+	}
+
+	for _, c := range cases {
+		tl := ParseStatements(c.input, c.dialect)
+
+		var got []string
+		for _, ref := range TableReferences(tl, c.dialect) {
+			s := "R:"
+			if ref.Access == WriteAccess {
+				s = "W:"
+			}
+			s += ref.Name
+			if ref.Alias != "" {
+				s += "/" + ref.Alias
+			}
+			got = append(got, s)
+		}
+
+		if g := strings.Join(got, " "); g != c.expected {
+			t.Errorf("%q:\n  expected %s\n  got      %s", c.input, c.expected, g)
+		}
+	}
+}
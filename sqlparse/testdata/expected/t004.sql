@@ -9,14 +9,14 @@ IdentToken:  [p.proname]
 KeywordToken:  [as]
 DoubleQuotedToken:  ["Name"]
 OtherToken:  [,]
-IdentToken:  [pg_catalog.pg_get_function_result]
+FunctionToken:  [pg_catalog.pg_get_function_result]
 OtherToken:  [(]
 IdentToken:  [p.oid]
 OtherToken:  [)]
 KeywordToken:  [as]
 DoubleQuotedToken:  ["Result data type"]
 OtherToken:  [,]
-IdentToken:  [pg_catalog.pg_get_function_arguments]
+FunctionToken:  [pg_catalog.pg_get_function_arguments]
 OtherToken:  [(]
 IdentToken:  [p.oid]
 OtherToken:  [)]
@@ -57,7 +57,7 @@ IdentToken:  [n.oid]
 OperatorToken:  [=]
 IdentToken:  [p.pronamespace]
 KeywordToken:  [WHERE]
-IdentToken:  [pg_catalog.pg_function_is_visible]
+FunctionToken:  [pg_catalog.pg_function_is_visible]
 OtherToken:  [(]
 IdentToken:  [p.oid]
 OtherToken:  [)]
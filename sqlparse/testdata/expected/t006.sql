@@ -60,7 +60,7 @@ OtherToken:  [;]
 KeywordToken:  [SELECT]
 IdentToken:  [a.attname]
 OtherToken:  [,]
-IdentToken:  [pg_catalog.format_type]
+FunctionToken:  [pg_catalog.format_type]
 OtherToken:  [(]
 IdentToken:  [a.atttypid]
 OtherToken:  [,]
@@ -69,9 +69,9 @@ OtherToken:  [)]
 OtherToken:  [,]
 OtherToken:  [(]
 KeywordToken:  [SELECT]
-KeywordToken:  [substring]
+FunctionToken:  [substring]
 OtherToken:  [(]
-IdentToken:  [pg_catalog.pg_get_expr]
+FunctionToken:  [pg_catalog.pg_get_expr]
 OtherToken:  [(]
 IdentToken:  [d.adbin]
 OtherToken:  [,]
@@ -156,7 +156,7 @@ IdentToken:  [inhparent]
 OperatorToken:  [::]
 IdentToken:  [pg_catalog.regclass]
 OtherToken:  [,]
-IdentToken:  [pg_catalog.pg_get_expr]
+FunctionToken:  [pg_catalog.pg_get_expr]
 OtherToken:  [(]
 IdentToken:  [c.relpartbound]
 OtherToken:  [,]
@@ -46,6 +46,9 @@ const (
 	BindParameterToken
 	// OtherToken is any string not identified as any other type of token
 	OtherToken
+	// FunctionToken is an identifier that is the name of a function
+	//  (an identifier that is followed by an opening parenthesis)
+	FunctionToken
 	// TODO: Others?
 )
 
@@ -54,6 +57,16 @@ type Token struct {
 	tokenString       string // the portion of the SQL that the token contains
 	tokenType         int    // the indicator as to the kind of string that the token contains
 	leadingWhiteSpace string // the white space preceeding the token
+	terminator        bool   // indicates that the token is a statement delimiter (see ParseAuto)
+}
+
+// NewToken returns a new token of the specified type that contains the
+// supplied string and leading white space
+func NewToken(s string, tokenType int, ws string) (t Token) {
+	t.tokenString = s
+	t.tokenType = tokenType
+	t.leadingWhiteSpace = ws
+	return t
 }
 
 // Value returns the string contained in the token
@@ -84,6 +97,7 @@ func typeName(t int) (s string) {
 		BlockCommentToken:     "BlockCommentToken",
 		BracketQuotedToken:    "BracketQuotedToken",
 		DoubleQuotedToken:     "DoubleQuotedToken",
+		FunctionToken:         "FunctionToken",
 		IdentToken:            "IdentToken",
 		KeywordToken:          "KeywordToken",
 		LabelToken:            "LabelToken",
@@ -9,6 +9,7 @@ manipulating the token list.
 
 import (
 	"strings"
+	"unicode/utf8"
 )
 
 // Tokens provides a list of tokens
@@ -17,6 +18,9 @@ type Tokens struct {
 	idx         int     // the index of the current token
 	length      int     // the length of the token list
 	tokenIsOpen bool    // indicates if the end of the token has been reached or not
+
+	trailingWhiteSpace string // the white space following the last token
+	delimited          bool   // indicates that the statements are terminated by the delimiter tokens rather than by semi-colons (see ParseAuto)
 }
 
 // Extend adds a new token to the token list and sets the type of the
@@ -190,6 +194,170 @@ func (d *Tokens) Rewind() {
 	d.idx = 0
 }
 
+// Len returns the number of tokens in the list
+func (d *Tokens) Len() int {
+	return d.length
+}
+
+// Index returns the index of the current token in the list
+func (d *Tokens) Index() int {
+	return d.idx
+}
+
+// At returns the token at index i of the list. If no such token exists
+// then an empty token is returned.
+func (d *Tokens) At(i int) (t Token) {
+	if i >= 0 && d.length > i {
+		t = d.tokens[i]
+	}
+	return t
+}
+
+// Seek sets the index of the token list to i. Indexes past the end of
+// the list leave the list positioned at the end.
+func (d *Tokens) Seek(i int) {
+	switch {
+	case i < 0:
+		d.idx = 0
+	case i > d.length:
+		d.idx = d.length
+	default:
+		d.idx = i
+	}
+}
+
+// Prev moves the list back to the previous token and returns that
+// token. Used in a loop following a Seek(Len()), Prev walks the list
+// backward. If there is no previous token then an empty token is
+// returned.
+func (d *Tokens) Prev() (t Token) {
+	if d.idx > 0 && d.length > 0 {
+		if d.idx > d.length {
+			d.idx = d.length
+		}
+		d.idx--
+		t = d.tokens[d.idx]
+	}
+	return t
+}
+
+// Position returns the line and column numbers (both starting at 1) of
+// the token at index i. The position is determined from the text of the
+// preceding tokens and their leading white space.
+func (d *Tokens) Position(i int) (line, col int) {
+
+	line, col = 1, 1
+	if i > d.length {
+		i = d.length
+	}
+
+	for j := 0; j <= i; j++ {
+		var s string
+		if j < i {
+			s = d.tokens[j].leadingWhiteSpace + d.tokens[j].tokenString
+		} else if j < d.length {
+			s = d.tokens[j].leadingWhiteSpace
+		}
+
+		if n := strings.Count(s, "\n"); n > 0 {
+			line += n
+			col = 1
+			s = s[strings.LastIndex(s, "\n")+1:]
+		}
+		col += utf8.RuneCountInString(s)
+	}
+	return line, col
+}
+
+// Slice returns a new token list containing the tokens from index i up
+// to, but not including, index j.
+func (d *Tokens) Slice(i, j int) (s Tokens) {
+	if i < 0 {
+		i = 0
+	}
+	if j > d.length {
+		j = d.length
+	}
+	if i < j {
+		s.tokens = make([]Token, j-i)
+		copy(s.tokens, d.tokens[i:j])
+	}
+	s.length = len(s.tokens)
+	if j == d.length {
+		s.trailingWhiteSpace = d.trailingWhiteSpace
+	}
+	s.delimited = d.delimited
+	return s
+}
+
+// All returns a copy of the tokens in the list
+func (d *Tokens) All() []Token {
+	t := make([]Token, d.length)
+	copy(t, d.tokens)
+	return t
+}
+
+// TokenFilter is used for selecting tokens when walking the token list
+type TokenFilter func(t Token) bool
+
+// NonCommentTokens is a TokenFilter that selects all tokens other than
+// comments
+func NonCommentTokens(t Token) bool {
+	return !isCommentToken(t.tokenType)
+}
+
+// SignificantTokens is a TokenFilter that selects those tokens that are
+// neither comments nor white space
+func SignificantTokens(t Token) bool {
+	switch t.tokenType {
+	case NullToken, WhiteSpaceToken:
+		return false
+	}
+	return !isCommentToken(t.tokenType)
+}
+
+// NextFiltered returns the next token in the list that is selected by
+// the filter and advances the list past that token. If there is no such
+// token then an empty token is returned and the list is positioned at
+// the end.
+func (d *Tokens) NextFiltered(f TokenFilter) (t Token) {
+	for d.length > d.idx {
+		t = d.Next()
+		if f(t) {
+			return t
+		}
+	}
+	return Token{}
+}
+
+// PrevFiltered moves the list back to the previous token that is
+// selected by the filter and returns that token. If there is no such
+// token then an empty token is returned and the list is positioned at
+// the beginning.
+func (d *Tokens) PrevFiltered(f TokenFilter) (t Token) {
+	for d.idx > 0 {
+		t = d.Prev()
+		if f(t) {
+			return t
+		}
+	}
+	return Token{}
+}
+
+// Filter returns a new token list containing only those tokens that
+// are selected by the filter
+func (d *Tokens) Filter(f TokenFilter) (s Tokens) {
+	for i := 0; i < d.length; i++ {
+		if f(d.tokens[i]) {
+			s.tokens = append(s.tokens, d.tokens[i])
+		}
+	}
+	s.length = len(s.tokens)
+	s.trailingWhiteSpace = d.trailingWhiteSpace
+	s.delimited = d.delimited
+	return s
+}
+
 // Init initializes the token list by splitting the supplied data into
 // individual characters and using that to populate the token list.
 func (d *Tokens) Init(data string) {
@@ -205,3 +373,21 @@ func (d *Tokens) Init(data string) {
 	d.length = len(d.tokens)
 	d.idx = 0
 }
+
+// TrailingWhiteSpace returns the white space that followed the last
+// token in the list
+func (d *Tokens) TrailingWhiteSpace() string {
+	return d.trailingWhiteSpace
+}
+
+// String reconstructs the text of the token list by joining each token
+// with its leading white space
+func (d *Tokens) String() string {
+	var b strings.Builder
+	for i := 0; i < d.length; i++ {
+		b.WriteString(d.tokens[i].leadingWhiteSpace)
+		b.WriteString(d.tokens[i].tokenString)
+	}
+	b.WriteString(d.trailingWhiteSpace)
+	return b.String()
+}
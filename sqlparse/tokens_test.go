@@ -0,0 +1,84 @@
+package sqlparse
+
+import (
+	"testing"
+)
+
+func TestTokensRandomAccess(t *testing.T) {
+
+	tl := ParseStatements("SELECT a, /* x */ b -- y\nFROM t", PostgreSQL)
+
+	if tl.Len() != 8 {
+		t.Fatalf("expected 8 tokens, got %d", tl.Len())
+	}
+	if tk := tl.At(3); tk.Type() != BlockCommentToken {
+		t.Errorf("At(3): expected a block comment, got %s", tk)
+	}
+	if tk := tl.At(99); tk.Value() != "" {
+		t.Errorf("At(99): expected an empty token, got %s", tk)
+	}
+
+	s := tl.Slice(1, 3)
+	t0, t1 := s.At(0), s.At(1)
+	if s.Len() != 2 || t0.Value() != "a" || t1.Value() != "," {
+		t.Errorf("unexpected slice: %v", s.All())
+	}
+
+	tl.Seek(tl.Len())
+	var backward []string
+	for {
+		tk := tl.PrevFiltered(SignificantTokens)
+		if tk.Value() == "" {
+			break
+		}
+		backward = append(backward, tk.Value())
+	}
+	expected := []string{"t", "FROM", "b", ",", "a", "SELECT"}
+	if len(backward) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, backward)
+	}
+	for i := range expected {
+		if backward[i] != expected[i] {
+			t.Errorf("expected %v, got %v", expected, backward)
+			break
+		}
+	}
+
+	tl.Seek(3)
+	if tk := tl.NextFiltered(SignificantTokens); tk.Value() != "b" || tl.Index() != 5 {
+		t.Errorf("NextFiltered: got %s at index %d", tk, tl.Index())
+	}
+	if tk := tl.Prev(); tk.Value() != "b" {
+		t.Errorf("Prev: expected b, got %s", tk)
+	}
+
+	f := tl.Filter(NonCommentTokens)
+	if f.Len() != 6 {
+		t.Errorf("Filter: expected 6 tokens, got %d", f.Len())
+	}
+}
+
+func TestTokensPosition(t *testing.T) {
+
+	tl := ParseStatements("SELECT a,\n       bé, c -- x\nFROM t", PostgreSQL)
+
+	cases := []struct {
+		index int
+		line  int
+		col   int
+	}{
+		{0, 1, 1},
+		{1, 1, 8},
+		{3, 2, 8},
+		{5, 2, 12},
+		{7, 3, 1},
+		{tl.Len(), 3, 7},
+	}
+
+	for _, c := range cases {
+		line, col := tl.Position(c.index)
+		if line != c.line || col != c.col {
+			t.Errorf("token %d: expected %d:%d, got %d:%d", c.index, c.line, c.col, line, col)
+		}
+	}
+}
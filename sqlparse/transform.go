@@ -0,0 +1,218 @@
+package sqlparse
+
+/*
+
+transform.go provides functions that transform a token list into a
+new, equivalent, token list.
+
+*/
+
+import (
+	"strings"
+)
+
+// StripOptions provides the options for stripping comments
+type StripOptions struct {
+	KeepHints      bool // keep optimizer hints (/*+ ... */ and --+ ...)
+	KeepExecutable bool // keep MySQL/MariaDB executable comments (/*! ... */)
+}
+
+// StripComments returns a copy of the token list with the comments
+// removed. Any new-lines contained in, or terminating, the removed
+// comments are retained so that the line numbering of the remaining
+// tokens is unchanged.
+func StripComments(tl Tokens, opts StripOptions) (tlOut Tokens) {
+
+	var prev Token
+	removed := false
+	pendingWS := ""
+
+	for i := 0; i < tl.length; i++ {
+		t := tl.tokens[i]
+
+		if isCommentToken(t.tokenType) && !keepComment(t.tokenString, opts) {
+			pendingWS += t.leadingWhiteSpace + strings.Repeat("\n", strings.Count(t.tokenString, "\n"))
+			removed = true
+			continue
+		}
+
+		if removed {
+			ws := joinWhiteSpace(pendingWS, t.leadingWhiteSpace)
+			switch {
+			case strings.Contains(ws, "\n"):
+				t.leadingWhiteSpace = ws
+			case t.leadingWhiteSpace == "" && tlOut.length > 0:
+				t.leadingWhiteSpace = separatorWhiteSpace(prev, t)
+			}
+			removed = false
+			pendingWS = ""
+		}
+		tlOut.Push(t)
+		prev = t
+	}
+
+	tlOut.trailingWhiteSpace = tl.trailingWhiteSpace
+	tlOut.delimited = tl.delimited
+	if removed {
+		tlOut.trailingWhiteSpace = strings.TrimRight(joinWhiteSpace(pendingWS, tl.trailingWhiteSpace), " \t")
+	}
+	tlOut.CloseToken()
+	return tlOut
+}
+
+// keepComment determines whether or not the comment is to be kept when
+// stripping comments
+func keepComment(s string, opts StripOptions) bool {
+	if opts.KeepHints && isHintComment(s) {
+		return true
+	}
+	if opts.KeepExecutable && isExecutableComment(s) {
+		return true
+	}
+	return false
+}
+
+// isHintComment determines whether or not the comment is an optimizer
+// hint
+func isHintComment(s string) bool {
+	return strings.HasPrefix(s, "/*+") || strings.HasPrefix(s, "--+")
+}
+
+// isExecutableComment determines whether or not the comment is a
+// MySQL/MariaDB executable comment
+func isExecutableComment(s string) bool {
+	return strings.HasPrefix(s, "/*!") || strings.HasPrefix(s, "/*M!")
+}
+
+// joinWhiteSpace combines the white space from removed tokens with the
+// white space of the following token. Spaces and tabs that would be
+// left dangling at the end of a line are dropped.
+func joinWhiteSpace(removed, ws string) string {
+
+	lines := strings.Split(removed, "\n")
+	for i := 0; i < len(lines)-1; i++ {
+		lines[i] = strings.TrimRight(lines[i], " \t")
+	}
+	if strings.HasPrefix(ws, "\n") || strings.HasPrefix(ws, "\r\n") {
+		lines[len(lines)-1] = strings.TrimRight(lines[len(lines)-1], " \t")
+	}
+	return strings.Join(lines, "\n") + ws
+}
+
+// Minify returns the shortest text that tokenizes to the same sequence
+// of tokens as the token list with the comments removed. Executable
+// comments (/*! ... */) are kept as they contain statement text, and
+// batch separators (such as the MSSQL GO) remain on lines by themselves.
+// For token lists with statement delimiters (see ParseAuto) each
+// statement, and each MySQL DELIMITER command, starts on a new line as
+// the client reads the commands by line.
+func Minify(tl Tokens, dialect int) string {
+
+	stripped := StripComments(tl, StripOptions{KeepExecutable: true})
+
+	var out Tokens
+	newLine := false
+
+	for i := 0; i < stripped.length; i++ {
+		t := stripped.tokens[i]
+		origWS := t.leadingWhiteSpace
+		t.leadingWhiteSpace = ""
+
+		switch {
+		case out.length == 0:
+			// nothing to separate from
+		case stripped.isBatchSeparator(i, dialect):
+			t.leadingWhiteSpace = "\n"
+			newLine = true
+		case stripped.isDelimiterCommand(i - 1):
+			// the new delimiter of the command
+			t.leadingWhiteSpace = " "
+			newLine = true
+		case newLine, stripped.delimited && out.tokens[out.length-1].terminator:
+			t.leadingWhiteSpace = "\n"
+			newLine = false
+		case origWS == "":
+			// adjacent tokens in the original text remain adjacent
+		case isQuotedToken(t.tokenType) && t.tokenType == out.tokens[out.length-1].tokenType:
+			// adjacent quoted strings would be read as a single string
+			// with an escaped quote, and the SQL standard requires
+			// consecutive string constants to be separated by a new-line
+			if strings.Contains(origWS, "\n") {
+				t.leadingWhiteSpace = "\n"
+			} else {
+				t.leadingWhiteSpace = " "
+			}
+		case tokensMerge(out, t, dialect):
+			t.leadingWhiteSpace = " "
+		}
+
+		out.Push(t)
+	}
+
+	return out.String()
+}
+
+// isDelimiterCommand determines whether or not the token at index i is
+// a MySQL DELIMITER command (see ParseAuto), the following token being
+// the new delimiter
+func (d *Tokens) isDelimiterCommand(i int) bool {
+
+	if !d.delimited || i < 0 || i+1 >= d.length || !d.tokens[i+1].terminator {
+		return false
+	}
+	if tokenWord(d.tokens[i]) != "DELIMITER" || d.tokens[i+1].leadingWhiteSpace == "" {
+		return false
+	}
+	for j := i - 1; j >= 0; j-- {
+		if SignificantTokens(d.tokens[j]) {
+			return d.tokens[j].terminator
+		}
+	}
+	return true
+}
+
+// tokensMerge determines whether or not appending the token, with no
+// separating white space, to the end of the token list would cause the
+// token to be read differently (by merging with, or splitting, the
+// preceding tokens).
+func tokensMerge(tl Tokens, t Token, dialect int) bool {
+
+	// check the window consisting of the previous two tokens and the
+	// new token
+	start := tl.length - 2
+	if start < 0 {
+		start = 0
+	}
+	window := tl.Slice(start, tl.length)
+	if window.length > 0 {
+		window.tokens[0].leadingWhiteSpace = ""
+	}
+	window.Push(t)
+
+	chk := ParseStatements(window.String(), dialect)
+	if chk.length != window.length {
+		return true
+	}
+	for i := 0; i < chk.length; i++ {
+		if chk.tokens[i].tokenString != window.tokens[i].tokenString {
+			return true
+		}
+		if !sameTokenType(chk.tokens[i].tokenType, window.tokens[i].tokenType) {
+			return true
+		}
+	}
+	return false
+}
+
+// sameTokenType determines whether or not two token types are read the
+// same way. Identifiers and function names are the same as whether an
+// identifier is a function name depends on the preceding tokens.
+func sameTokenType(a, b int) bool {
+	switch {
+	case a == b:
+		return true
+	case a == IdentToken && b == FunctionToken, a == FunctionToken && b == IdentToken:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,128 @@
+package sqlparse
+
+import (
+	"io/ioutil"
+	"testing"
+)
+
+func TestStripComments(t *testing.T) {
+
+	cases := []struct {
+		input    string
+		dialect  int
+		opts     StripOptions
+		expected string
+	}{
+		{
+			"-- header\nSELECT a, -- the a\n    b /* the\nb */ FROM t;\n",
+			PostgreSQL,
+			StripOptions{},
+			"\nSELECT a,\n    b\n FROM t;\n",
+		},
+		{
+			"SELECT a/*x*/FROM t",
+			PostgreSQL,
+			StripOptions{},
+			"SELECT a FROM t",
+		},
+		{
+			"SELECT /*+ INDEX(t i) */ a FROM t -- done",
+			Oracle,
+			StripOptions{KeepHints: true},
+			"SELECT /*+ INDEX(t i) */ a FROM t",
+		},
+		{
+			"SELECT /*! STRAIGHT_JOIN */ a # pick a\nFROM t /*+ BKA(t) */;",
+			MySQL,
+			StripOptions{KeepExecutable: true},
+			"SELECT /*! STRAIGHT_JOIN */ a\nFROM t;",
+		},
+	}
+
+	for _, c := range cases {
+		tl := ParseStatements(c.input, c.dialect)
+		s := StripComments(tl, c.opts)
+		if got := s.String(); got != c.expected {
+			t.Errorf("%q: expected %q, got %q", c.input, c.expected, got)
+		}
+	}
+}
+
+func TestMinify(t *testing.T) {
+
+	cases := []struct {
+		input    string
+		dialect  int
+		expected string
+	}{
+		{
+			"SELECT a - 1,\n       b -1, c - -1 -- comment\n  FROM t\n WHERE x = 'a' ;",
+			PostgreSQL,
+			"SELECT a-1,b -1,c- -1 FROM t WHERE x='a';",
+		},
+		{
+			"SELECT 'a'\n'b', 'c''d' FROM t",
+			PostgreSQL,
+			"SELECT'a'\n'b','c''d'FROM t",
+		},
+		{
+			"SELECT /*! SQL_NO_CACHE */ a # comment\nFROM t ;",
+			MySQL,
+			"SELECT/*! SQL_NO_CACHE */a FROM t;",
+		},
+		{
+			"SELECT 1\nGO\nSELECT 2\nGO\n",
+			MSSQL,
+			"SELECT 1\nGO\nSELECT 2\nGO",
+		},
+	}
+
+	for _, c := range cases {
+		tl := ParseStatements(c.input, c.dialect)
+		if got := Minify(tl, c.dialect); got != c.expected {
+			t.Errorf("%q: expected %q, got %q", c.input, c.expected, got)
+		}
+	}
+
+	// DELIMITER commands, and the statements that follow the delimiters,
+	// are on lines by themselves
+	sql := "-- dialect: MySQL\nDELIMITER //\nCREATE PROCEDURE p()\nBEGIN\n  SELECT 1; -- one\n  SELECT 2;\nEND //\nDELIMITER ;\nCALL p(); CALL p();\n"
+	tl, dir := ParseAuto(sql)
+	m := Minify(tl, dir.Dialect)
+	expected := "DELIMITER //\nCREATE PROCEDURE p()BEGIN SELECT 1;SELECT 2;END //\nDELIMITER ;\nCALL p();\nCALL p();"
+	if m != expected {
+		t.Errorf("expected %q, got %q", expected, m)
+	}
+	stripped := StripComments(tl, StripOptions{})
+	if r := stripped.StatementRanges(dir.Dialect); len(r) != 5 {
+		t.Errorf("expected 5 statements after stripping comments, got %d", len(r))
+	}
+
+	// minified text re-tokenizes to the same significant tokens
+	for _, file := range []string{"t003", "t004", "t005", "t006"} {
+		b, err := ioutil.ReadFile("testdata/input/" + file + ".sql")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		tl := ParseStatements(string(b), PostgreSQL)
+		expected := tl.Filter(SignificantTokens)
+		m := Minify(tl, PostgreSQL)
+		got := ParseStatements(m, PostgreSQL)
+
+		if got.Len() != expected.Len() {
+			t.Errorf("%s: expected %d tokens, got %d", file, expected.Len(), got.Len())
+			continue
+		}
+		for i := 0; i < got.Len(); i++ {
+			g, e := got.At(i), expected.At(i)
+			if g.Value() != e.Value() || g.Type() != e.Type() {
+				t.Errorf("%s: token %d: expected %s, got %s", file, i, e, g)
+				break
+			}
+		}
+		if len(m) >= len(b) {
+			t.Errorf("%s: minified text is not shorter than the original", file)
+		}
+	}
+}
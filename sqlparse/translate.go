@@ -0,0 +1,463 @@
+package sqlparse
+
+/*
+
+translate.go provides the functionality for translating the lexical
+constructs (quoting, operators, comments, bind parameters, string
+prefixes, and function names) of one SQL dialect to those of another.
+
+*/
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// TranslateIssue provides the diagnostic for a construct that could not
+// be translated
+type TranslateIssue struct {
+	Index int    // the index of the token in the original token list
+	Line  int    // the line number of the token
+	Col   int    // the column number of the token
+	Msg   string // the description of the issue
+}
+
+// Error implements the error interface for the translation issue
+func (e TranslateIssue) Error() string {
+	return fmt.Sprintf("line %d, column %d: %s", e.Line, e.Col, e.Msg)
+}
+
+// map[function name]map[target dialect]the name of the equivalent
+// function. Names that are keywords, rather than functions, in the
+// target dialect (CURRENT_TIMESTAMP) are used without parentheses.
+var functionRenames = map[string]map[int]string{
+	"GETDATE": {
+		PostgreSQL:  "NOW",
+		MySQL:       "NOW",
+		MariaDB:     "NOW",
+		Oracle:      "CURRENT_TIMESTAMP",
+		SQLite:      "CURRENT_TIMESTAMP",
+		StandardSQL: "CURRENT_TIMESTAMP",
+	},
+	"IFNULL": {
+		PostgreSQL:  "COALESCE",
+		Oracle:      "NVL",
+		MSSQL:       "ISNULL",
+		StandardSQL: "COALESCE",
+	},
+	"ISNULL": {
+		PostgreSQL:  "COALESCE",
+		MySQL:       "IFNULL",
+		MariaDB:     "IFNULL",
+		Oracle:      "NVL",
+		SQLite:      "IFNULL",
+		StandardSQL: "COALESCE",
+	},
+	"LEN": {
+		PostgreSQL:  "LENGTH",
+		MySQL:       "CHAR_LENGTH",
+		MariaDB:     "CHAR_LENGTH",
+		Oracle:      "LENGTH",
+		SQLite:      "LENGTH",
+		StandardSQL: "CHAR_LENGTH",
+	},
+	"LENGTH": {
+		MSSQL:       "LEN",
+		StandardSQL: "CHAR_LENGTH",
+	},
+	"LISTAGG": {
+		PostgreSQL: "STRING_AGG",
+		MSSQL:      "STRING_AGG",
+	},
+	"NOW": {
+		MSSQL:       "GETDATE",
+		Oracle:      "CURRENT_TIMESTAMP",
+		SQLite:      "CURRENT_TIMESTAMP",
+		StandardSQL: "CURRENT_TIMESTAMP",
+	},
+	"NVL": {
+		PostgreSQL:  "COALESCE",
+		MySQL:       "IFNULL",
+		MSSQL:       "ISNULL",
+		SQLite:      "IFNULL",
+		StandardSQL: "COALESCE",
+	},
+	"RAND": {
+		PostgreSQL: "RANDOM",
+		SQLite:     "RANDOM",
+	},
+	"RANDOM": {
+		MySQL:   "RAND",
+		MariaDB: "RAND",
+	},
+	"STRING_AGG": {
+		Oracle: "LISTAGG",
+	},
+	"SUBSTR": {
+		MSSQL:       "SUBSTRING",
+		StandardSQL: "SUBSTRING",
+	},
+	"SUBSTRING": {
+		Oracle: "SUBSTR",
+	},
+	"SYSDATETIME": {
+		PostgreSQL:  "NOW",
+		MySQL:       "NOW",
+		MariaDB:     "NOW",
+		Oracle:      "CURRENT_TIMESTAMP",
+		SQLite:      "CURRENT_TIMESTAMP",
+		StandardSQL: "CURRENT_TIMESTAMP",
+	},
+}
+
+// translator tracks the state for translating a token list
+type translator struct {
+	tl     *Tokens
+	e      *Editor
+	groups GroupIndex // the parenthesized groups of the token list
+	sig    []int      // the indexes of the significant tokens
+	from   int        // the dialect being translated from
+	to     int        // the dialect being translated to
+	issues []TranslateIssue
+}
+
+// Translate translates those lexical constructs of the token list that
+// can be translated at the token level from one SQL dialect to another:
+//
+//   - quoted identifiers ([x], `x`, and "x"),
+//   - string concatenation (+ and ||) and :: casts,
+//   - comments (# and --),
+//   - bind parameters (?, :x, $1, and @x),
+//   - national and escape string prefixes (N'x' and E'x'), and
+//   - the names of built-in functions (NVL, ISNULL, GETDATE, ...)
+//
+// The translated token list is returned along with the issues for
+// those constructs that could not be translated.
+func Translate(tl Tokens, from, to int) (tlOut Tokens, issues []TranslateIssue) {
+
+	if from == to {
+		return tl, nil
+	}
+
+	t := translator{
+		tl:     &tl,
+		e:      NewEditor(tl),
+		groups: tl.GroupIndex(),
+		from:   from,
+		to:     to,
+	}
+	for i := 0; i < tl.length; i++ {
+		if SignificantTokens(tl.tokens[i]) {
+			t.sig = append(t.sig, i)
+		}
+	}
+
+	t.translateQuoting()
+	t.translateOperators()
+	t.translateComments()
+	t.translateStringPrefixes()
+	t.translateParameters()
+	t.translateFunctions()
+	t.translateWithinGroup()
+
+	sort.SliceStable(t.issues, func(i, j int) bool {
+		return t.issues[i].Index < t.issues[j].Index
+	})
+	return t.e.Tokens(), t.issues
+}
+
+// issuef records an issue for the token at index i
+func (t *translator) issuef(i int, format string, args ...interface{}) {
+	line, col := t.tl.Position(i)
+	t.issues = append(t.issues, TranslateIssue{
+		Index: i,
+		Line:  line,
+		Col:   col,
+		Msg:   fmt.Sprintf(format, args...),
+	})
+}
+
+// token returns the k-th significant token, or an empty token if there
+// is no such token
+func (t *translator) token(k int) (tk Token) {
+	if k >= 0 && k < len(t.sig) {
+		tk = t.tl.tokens[t.sig[k]]
+	}
+	return tk
+}
+
+// translateQuoting converts the quoted identifiers to the quoting of
+// the target dialect. Double quoted strings in MySQL and MariaDB are
+// string literals and are converted to single quoted strings.
+func (t *translator) translateQuoting() {
+
+	for _, i := range t.sig {
+		tk := t.tl.tokens[i]
+		switch tk.tokenType {
+		case DoubleQuotedToken, BacktickQuotedToken, BracketQuotedToken:
+		default:
+			continue
+		}
+
+		if tk.tokenType == DoubleQuotedToken && isMySQLFamily(t.from) {
+			if !isMySQLFamily(t.to) {
+				s := strings.Replace(unquoteIdentifier(tk.tokenString), "'", "''", -1)
+				t.e.Replace(i, NewToken("'"+s+"'", SingleQuotedToken, ""))
+			}
+			continue
+		}
+
+		s, tt := quoteIdentifier(unquoteIdentifier(tk.tokenString), t.to)
+		if s != tk.tokenString {
+			t.e.Replace(i, NewToken(s, tt, ""))
+		}
+	}
+}
+
+// translateOperators converts the MSSQL + string concatenation
+// operator to ||, and the reverse. Only those + operators that have a
+// string literal for an operand are known to be concatenations, those
+// that do not have numeric literals for both operands are reported.
+// PostgreSQL :: casts are reported.
+func (t *translator) translateOperators() {
+
+	for k, i := range t.sig {
+		tk := t.tl.tokens[i]
+		if tk.tokenType != OperatorToken {
+			continue
+		}
+
+		switch {
+		case tk.tokenString == "+" && t.from == MSSQL:
+			if !t.isStringLiteral(k-1) && !t.isStringLiteral(k+1) {
+				if t.token(k-1).tokenType != NumericToken || t.token(k+1).tokenType != NumericToken {
+					t.issuef(i, "the + operator may be a string concatenation, which is not translated to %s", SQLDialectName(t.to))
+				}
+				continue
+			}
+			if isMySQLFamily(t.to) {
+				t.issuef(i, "string concatenation requires the CONCAT function in %s", SQLDialectName(t.to))
+				continue
+			}
+			t.e.Replace(i, NewToken("||", OperatorToken, ""))
+
+		case tk.tokenString == "::" && t.from == PostgreSQL:
+			t.issuef(i, "the :: cast operator is not supported in %s", SQLDialectName(t.to))
+
+		case tk.tokenString == "||" && t.from != MSSQL && !isMySQLFamily(t.from):
+			switch {
+			case t.to == MSSQL:
+				t.e.Replace(i, NewToken("+", OperatorToken, ""))
+			case isMySQLFamily(t.to):
+				t.issuef(i, "string concatenation requires the CONCAT function in %s", SQLDialectName(t.to))
+			}
+		}
+	}
+}
+
+// isStringLiteral determines whether or not the k-th significant token
+// is a string literal, or the end of one
+func (t *translator) isStringLiteral(k int) bool {
+	if tk := t.token(k); tk.tokenType == SingleQuotedToken {
+		return true
+	}
+	// N'x' (the prefix precedes the string)
+	return t.isStringPrefix(k) && t.token(k+1).tokenType == SingleQuotedToken
+}
+
+// isStringPrefix determines whether or not the k-th significant token
+// is a prefix (N, E, X, ...) that is attached to the following string
+func (t *translator) isStringPrefix(k int) bool {
+	tk := t.token(k)
+	next := t.token(k + 1)
+	return tk.tokenType == IdentToken && len(tk.tokenString) == 1 &&
+		next.tokenType == SingleQuotedToken && next.leadingWhiteSpace == ""
+}
+
+// translateComments converts the MySQL # comments to -- comments, and
+// ensures that -- comments are followed by white space for MySQL
+func (t *translator) translateComments() {
+
+	for i := 0; i < t.tl.length; i++ {
+		tk := t.tl.tokens[i]
+		s := tk.tokenString
+		switch {
+		case tk.tokenType == LineCommentToken && strings.HasPrefix(s, "#"):
+			if !isMySQLFamily(t.to) {
+				t.e.Replace(i, NewToken(lineComment(s[1:]), LineCommentToken, ""))
+			}
+		case tk.tokenType == LineCommentToken && strings.HasPrefix(s, "--"):
+			if isMySQLFamily(t.to) && !strings.HasPrefix(s, "-- ") && len(s) > 2 {
+				t.e.Replace(i, NewToken(lineComment(s[2:]), LineCommentToken, ""))
+			}
+		case tk.tokenType == BlockCommentToken && isExecutableComment(s):
+			if !isMySQLFamily(t.to) {
+				t.issuef(i, "executable comments are not supported in %s", SQLDialectName(t.to))
+			}
+		}
+	}
+}
+
+// lineComment returns the -- comment for the comment text
+func lineComment(s string) string {
+	if s == "" || strings.HasPrefix(s, " ") || strings.HasPrefix(s, "\t") {
+		return "--" + s
+	}
+	return "-- " + s
+}
+
+// translateStringPrefixes removes the N prefix from national character
+// strings for those dialects that do not require it, and the E prefix
+// from PostgreSQL escape strings that contain no escapes
+func (t *translator) translateStringPrefixes() {
+
+	for k, i := range t.sig {
+		if !t.isStringPrefix(k) {
+			continue
+		}
+
+		switch strings.ToUpper(t.tl.tokens[i].tokenString) {
+		case "N":
+			if t.to == PostgreSQL || t.to == SQLite {
+				t.e.Delete(i, i)
+			}
+		case "E":
+			if t.from != PostgreSQL {
+				continue
+			}
+			if strings.Contains(t.token(k+1).tokenString, "\\") {
+				t.issuef(i, "escape strings are not supported in %s", SQLDialectName(t.to))
+				continue
+			}
+			t.e.Delete(i, i)
+		}
+	}
+}
+
+// translateParameters converts the bind parameters to the style used
+// by the target dialect. When converting to the ? style, the parameters
+// that are no longer bound by the same number (repeated named
+// parameters and numbered parameters that are out of order) are
+// reported.
+func (t *translator) translateParameters() {
+
+	style := dialectParamStyle(t.to)
+	params := t.tl.bindParameters(t.from)
+	ordinals, repeated := rewriteParams(t.e, params, style, true)
+	if style != QuestionParameterStyle {
+		return
+	}
+
+	isRepeated := make(map[int]bool)
+	for _, p := range repeated {
+		isRepeated[p.index] = true
+	}
+	for k, p := range params {
+		name := strings.TrimSuffix(t.tl.tokens[p.index].tokenString, p.cast)
+		switch {
+		case isRepeated[p.index]:
+			t.issuef(p.index, "parameter %s is used more than once and must be bound at each position", name)
+		case ordinals[k] != k+1:
+			t.issuef(p.index, "parameter %s is bound as parameter %d of the ? parameters", name, k+1)
+		}
+	}
+}
+
+// translateFunctions renames the built-in functions of the source
+// dialect that are not available in the target dialect to their
+// equivalents
+func (t *translator) translateFunctions() {
+
+	for k, i := range t.sig {
+		tk := t.tl.tokens[i]
+		if tk.tokenType != FunctionToken && tk.tokenType != KeywordToken {
+			continue
+		}
+		if t.token(k+1).tokenString != "(" || strings.Contains(tk.tokenString, ".") {
+			continue
+		}
+
+		name := strings.ToUpper(tk.tokenString)
+		if !IsFunction(name, t.from) || IsFunction(name, t.to) {
+			continue
+		}
+
+		s, ok := functionRenames[name][t.to]
+		if !ok {
+			t.issuef(i, "function %s is not available in %s", tk.tokenString, SQLDialectName(t.to))
+			continue
+		}
+		if tk.tokenString == strings.ToLower(tk.tokenString) {
+			s = strings.ToLower(s)
+		}
+
+		if IsFunction(s, t.to) || !IsKeyword(s, t.to) {
+			t.e.Replace(i, NewToken(s, FunctionToken, ""))
+			continue
+		}
+
+		// niladic keyword, GETDATE() becomes CURRENT_TIMESTAMP
+		open := t.sig[k+1]
+		close := t.groups.MatchingParen(open)
+		if close < 0 || k+2 >= len(t.sig) {
+			t.issuef(i, "function %s is missing the closing parenthesis", tk.tokenString)
+			continue
+		}
+		if close != t.sig[k+2] {
+			t.issuef(i, "function %s takes no arguments in %s", s, SQLDialectName(t.to))
+			continue
+		}
+		t.e.Replace(i, NewToken(s, KeywordToken, ""))
+		t.e.Delete(open, close)
+	}
+}
+
+// translateWithinGroup moves the ORDER BY of the WITHIN GROUP clause
+// of LISTAGG into the call for PostgreSQL, as STRING_AGG does not take
+// a WITHIN GROUP clause: LISTAGG(x, ',') WITHIN GROUP (ORDER BY x)
+// becomes STRING_AGG(x, ',' ORDER BY x)
+func (t *translator) translateWithinGroup() {
+
+	if t.to != PostgreSQL {
+		return
+	}
+
+	for k, i := range t.sig {
+		if tokenWord(t.tl.tokens[i]) != "LISTAGG" || t.token(k+1).tokenString != "(" {
+			continue
+		}
+		close := t.groups.MatchingParen(t.sig[k+1])
+		w := k + 1
+		for w < len(t.sig) && t.sig[w] <= close {
+			w++
+		}
+		if close < 0 || tokenWord(t.token(w)) != "WITHIN" {
+			continue
+		}
+
+		if tokenWord(t.token(w+1)) != "GROUP" || t.token(w+2).tokenString != "(" || tokenWord(t.token(w+3)) != "ORDER" {
+			t.issuef(t.sig[w], "the WITHIN GROUP clause is not supported in %s", SQLDialectName(t.to))
+			continue
+		}
+		groupClose := t.groups.MatchingParen(t.sig[w+2])
+		if groupClose < 0 || t.e.edited(t.sig[w+3], groupClose-1) {
+			t.issuef(t.sig[w], "the WITHIN GROUP clause is not supported in %s", SQLDialectName(t.to))
+			continue
+		}
+
+		var orderBy []Token
+		for j := t.sig[w+3]; j < groupClose; j++ {
+			orderBy = append(orderBy, t.tl.tokens[j])
+		}
+		orderBy[0].leadingWhiteSpace = " "
+		t.e.InsertBefore(close, orderBy...)
+		t.e.Delete(t.sig[w], groupClose)
+	}
+}
+
+// isMySQLFamily determines whether or not the dialect is MySQL or
+// MariaDB
+func isMySQLFamily(dialect int) bool {
+	return dialect == MySQL || dialect == MariaDB
+}
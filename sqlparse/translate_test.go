@@ -0,0 +1,112 @@
+package sqlparse
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTranslate(t *testing.T) {
+
+	cases := []struct {
+		from     int
+		to       int
+		input    string
+		expected string
+		issues   string
+	}{
+		{
+			MSSQL, PostgreSQL,
+			"SELECT [first name] + N'x', ISNULL(a, 0), GETDATE(), LEN(b) FROM dbo.[t] WHERE id = @p1 AND x = @name AND y = @p1",
+			`SELECT "first name" || 'x', COALESCE(a, 0), NOW(), LENGTH(b) FROM dbo."t" WHERE id = $1 AND x = $2 AND y = $1`,
+			"",
+		},
+		{
+			MSSQL, MySQL,
+			"DECLARE @n int;\nSELECT [a] + 'x' FROM t WHERE id = @id OR id2 = @id OR n = @n",
+			"DECLARE @n int;\nSELECT `a` + 'x' FROM t WHERE id = ? OR id2 = ? OR n = @n",
+			"line 2, column 12: string concatenation requires the CONCAT function in MySQL; " +
+				"line 2, column 49: parameter @id is used more than once and must be bound at each position",
+		},
+		{
+			MySQL, PostgreSQL,
+			"SELECT `a`, \"s\", ifnull(a,1), group_concat(x) FROM t # c\nWHERE id = ? /*! x */",
+			"SELECT \"a\", 's', coalesce(a,1), group_concat(x) FROM t -- c\nWHERE id = $1 /*! x */",
+			"line 1, column 31: function group_concat is not available in PostgreSQL; " +
+				"line 2, column 14: executable comments are not supported in PostgreSQL",
+		},
+		{
+			Oracle, MSSQL,
+			"SELECT nvl(a, 1) || 'x', substr(s, 1, 2) FROM t WHERE id = :1 AND y = :name AND z = :new.z",
+			"SELECT isnull(a, 1) + 'x', substring(s, 1, 2) FROM t WHERE id = @p1 AND y = @name AND z = :new.z",
+			"",
+		},
+		{
+			PostgreSQL, Oracle,
+			"SELECT now(), E'a', E'a\\n', x::int FROM \"T\" --x",
+			"SELECT current_timestamp, 'a', E'a\\n', x::int FROM \"T\" --x",
+			"line 1, column 21: escape strings are not supported in Oracle; " +
+				"line 1, column 30: the :: cast operator is not supported in Oracle",
+		},
+		{
+			MSSQL, PostgreSQL,
+			"SELECT first_name + last_name, 1 + 2, a + 1 FROM t",
+			"SELECT first_name + last_name, 1 + 2, a + 1 FROM t",
+			"line 1, column 19: the + operator may be a string concatenation, which is not translated to PostgreSQL; " +
+				"line 1, column 41: the + operator may be a string concatenation, which is not translated to PostgreSQL",
+		},
+		{
+			MSSQL, Oracle,
+			"SELECT GETDATE(",
+			"SELECT GETDATE(",
+			"line 1, column 8: function GETDATE is missing the closing parenthesis",
+		},
+		{
+			PostgreSQL, MySQL,
+			"SELECT $2, $1::int, $2 FROM t WHERE a = $3",
+			"SELECT ?, ?::int, ? FROM t WHERE a = ?",
+			"line 1, column 8: parameter $2 is bound as parameter 1 of the ? parameters; " +
+				"line 1, column 12: parameter $1 is bound as parameter 2 of the ? parameters; " +
+				"line 1, column 21: parameter $2 is bound as parameter 3 of the ? parameters; " +
+				"line 1, column 41: parameter $3 is bound as parameter 4 of the ? parameters",
+		},
+		{
+			Oracle, PostgreSQL,
+			"SELECT LISTAGG(name, ',') WITHIN GROUP (ORDER BY name DESC) FROM t",
+			"SELECT STRING_AGG(name, ',' ORDER BY name DESC) FROM t",
+			"",
+		},
+		{
+			Oracle, MSSQL,
+			"SELECT LISTAGG(name, ',') WITHIN GROUP (ORDER BY name) FROM t",
+			"SELECT STRING_AGG(name, ',') WITHIN GROUP (ORDER BY name) FROM t",
+			"",
+		},
+		{
+			Oracle, PostgreSQL,
+			"SELECT LISTAGG(name, ',') WITHIN GROUP (ORDER BY nvl(name, :1)) FROM t",
+			"SELECT STRING_AGG(name, ',') WITHIN GROUP (ORDER BY coalesce(name, $1)) FROM t",
+			"line 1, column 27: the WITHIN GROUP clause is not supported in PostgreSQL",
+		},
+		{
+			PostgreSQL, MariaDB,
+			"SELECT a || b FROM \"t\" --x",
+			"SELECT a || b FROM `t` -- x",
+			"line 1, column 10: string concatenation requires the CONCAT function in MariaDB",
+		},
+	}
+
+	for _, c := range cases {
+		tl, issues := Translate(ParseStatements(c.input, c.from), c.from, c.to)
+		if got := tl.String(); got != c.expected {
+			t.Errorf("%s to %s:\n  expected %s\n  got      %s", SQLDialectName(c.from), SQLDialectName(c.to), c.expected, got)
+		}
+
+		var l []string
+		for _, i := range issues {
+			l = append(l, i.Error())
+		}
+		if got := strings.Join(l, "; "); got != c.issues {
+			t.Errorf("%s to %s:\n  expected issues %s\n  got             %s", SQLDialectName(c.from), SQLDialectName(c.to), c.issues, got)
+		}
+	}
+}